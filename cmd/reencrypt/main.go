@@ -0,0 +1,130 @@
+// Команда reencrypt перешифровывает сохранённые данные действующими ключами:
+// после включения шифрования (открытые значения и файлы), после смены
+// мастер-ключа (ключи данных и файлов) и при ротации ключей данных (-rotate-data-keys).
+// Команду можно запускать повторно: уже перешифрованные данные пропускаются.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/health-hub-bot-api/internal/config"
+	"github.com/health-hub-bot-api/internal/infrastructure/database"
+	"github.com/health-hub-bot-api/internal/infrastructure/encryption"
+	"github.com/health-hub-bot-api/internal/infrastructure/repository"
+	"github.com/health-hub-bot-api/internal/infrastructure/storage"
+)
+
+func main() {
+	rotateDataKeys := flag.Bool("rotate-data-keys", false, "retire current data keys and re-encrypt columns with new ones")
+	withFiles := flag.Bool("files", true, "re-encrypt stored files")
+	// Ключ удаляется не сразу после вывода из оборота: сервер мог начать запись
+	// выведенным ключом до того, как команда его вывела
+	retiredKeyGrace := flag.Duration("retired-key-grace", time.Hour, "delete retired data keys that are unused and older than this")
+	flag.Parse()
+
+	// Загрузка конфигурации
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("failed to load config:", err)
+	}
+
+	keyring, err := encryption.NewKeyring(cfg.Encryption.MasterKeys, cfg.Encryption.ActiveMasterKey)
+	if err != nil {
+		log.Fatal("failed to load encryption keys:", err)
+	}
+	if keyring == nil {
+		log.Fatal("ENCRYPTION_MASTER_KEYS must be set to re-encrypt data")
+	}
+
+	db, err := database.NewPostgres(cfg.Database)
+	if err != nil {
+		log.Fatal("failed to connect to database:", err)
+	}
+	defer func() {
+		if err := database.Close(db); err != nil {
+			log.Printf("error closing database: %v", err)
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dataKeyRepo := repository.NewDataKeyRepository(db)
+	fieldCipher := encryption.NewFieldCipher(keyring, dataKeyRepo)
+	reencryptor := repository.NewReencryptor(db, fieldCipher)
+
+	// Ключи данных, зашифрованные прежними мастер-ключами
+	rewrapped, err := fieldCipher.RewrapDataKeys(ctx)
+	if err != nil {
+		log.Fatal("failed to rewrap data keys:", err)
+	}
+	log.Printf("rewrapped %d data keys with master key %q", rewrapped, keyring.ActiveID())
+
+	if *rotateDataKeys {
+		retired, err := fieldCipher.RotateDataKeys(ctx)
+		if err != nil {
+			log.Fatal("failed to rotate data keys:", err)
+		}
+		log.Printf("retired %d data keys", retired)
+	}
+
+	// Колонки: открытые значения и значения под выведенными ключами
+	reencrypted, err := reencryptor.ReencryptColumns(ctx)
+	if err != nil {
+		log.Fatalf("failed to re-encrypt columns after %d values: %v", reencrypted, err)
+	}
+	log.Printf("re-encrypted %d column values", reencrypted)
+
+	// Файлы: открытые и зашифрованные прежними мастер-ключами
+	if *withFiles {
+		files := storage.NewEncryptedStorage(storage.NewLocalStorage(cfg.Storage.Path), keyring)
+		urls, err := reencryptor.StoredFileURLs(ctx)
+		if err != nil {
+			log.Fatal("failed to list stored files:", err)
+		}
+
+		rewritten, failed := 0, 0
+		for _, url := range urls {
+			ok, err := files.Reencrypt(ctx, url)
+			if err != nil {
+				if ctx.Err() != nil {
+					log.Fatal("interrupted:", ctx.Err())
+				}
+				log.Printf("failed to re-encrypt file %s: %v", url, err)
+				failed++
+				continue
+			}
+			if ok {
+				rewritten++
+			}
+		}
+		log.Printf("re-encrypted %d of %d stored files, %d failed", rewritten, len(urls), failed)
+	}
+
+	// Выведенные ключи, которыми больше ничего не зашифровано
+	retired, err := dataKeyRepo.FindRetiredBefore(ctx, time.Now().Add(-*retiredKeyGrace))
+	if err != nil {
+		log.Fatal("failed to load retired data keys:", err)
+	}
+	deleted := 0
+	for _, key := range retired {
+		referenced, err := reencryptor.IsKeyReferenced(ctx, key.ID)
+		if err != nil {
+			log.Fatal("failed to check data key usage:", err)
+		}
+		if referenced {
+			continue
+		}
+		if err := dataKeyRepo.Delete(ctx, key.ID); err != nil {
+			log.Fatal("failed to delete data key:", err)
+		}
+		deleted++
+	}
+	log.Printf("deleted %d of %d retired data keys", deleted, len(retired))
+}
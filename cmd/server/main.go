@@ -9,13 +9,32 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/health-hub-bot-api/graphql/generated"
+	analysisapp "github.com/health-hub-bot-api/internal/application/analysis"
+	auditapp "github.com/health-hub-bot-api/internal/application/audit"
+	consentapp "github.com/health-hub-bot-api/internal/application/consent"
+	dashboardapp "github.com/health-hub-bot-api/internal/application/dashboard"
+	doctorvisitapp "github.com/health-hub-bot-api/internal/application/doctorvisit"
+	engagementapp "github.com/health-hub-bot-api/internal/application/engagement"
+	exportapp "github.com/health-hub-bot-api/internal/application/export"
+	medicationapp "github.com/health-hub-bot-api/internal/application/medication"
+	reminderapp "github.com/health-hub-bot-api/internal/application/reminder"
+	sharingapp "github.com/health-hub-bot-api/internal/application/sharing"
+	symptomapp "github.com/health-hub-bot-api/internal/application/symptom"
+	userapp "github.com/health-hub-bot-api/internal/application/user"
 	"github.com/health-hub-bot-api/internal/config"
+	"github.com/health-hub-bot-api/internal/domain/reminder"
 	"github.com/health-hub-bot-api/internal/infrastructure/database"
+	"github.com/health-hub-bot-api/internal/infrastructure/encryption"
+	"github.com/health-hub-bot-api/internal/infrastructure/interaction"
 	"github.com/health-hub-bot-api/internal/infrastructure/repository"
+	"github.com/health-hub-bot-api/internal/infrastructure/scheduler"
+	"github.com/health-hub-bot-api/internal/infrastructure/storage"
+	"github.com/health-hub-bot-api/internal/infrastructure/telegram"
+	"github.com/health-hub-bot-api/internal/presentation/bot"
 	"github.com/health-hub-bot-api/internal/presentation/graphql"
+	"github.com/health-hub-bot-api/internal/presentation/web"
 )
 
 func main() {
@@ -36,13 +55,61 @@ func main() {
 		}
 	}()
 
+	// Шифрование чувствительных полей и файлов (выключено, если мастер-ключи не заданы)
+	keyring, err := encryption.NewKeyring(cfg.Encryption.MasterKeys, cfg.Encryption.ActiveMasterKey)
+	if err != nil {
+		log.Fatal("failed to load encryption keys:", err)
+	}
+	if keyring == nil {
+		log.Println("encryption master keys are not configured, sensitive data is stored unencrypted")
+	}
+	fieldCipher := encryption.NewFieldCipher(keyring, repository.NewDataKeyRepository(db))
+
 	// Инициализация репозиториев
 	userRepo := repository.NewUserRepository(db)
-	symptomRepo := repository.NewSymptomRepository(db)
+	symptomRepo := repository.NewSymptomRepository(db, fieldCipher)
 	analysisRepo := repository.NewAnalysisRepository(db)
 	medicationRepo := repository.NewMedicationRepository(db)
-	intakeRepo := repository.NewIntakeRepository(db)
-	doctorVisitRepo := repository.NewDoctorVisitRepository(db)
+	intakeRepo := repository.NewIntakeRepository(db, fieldCipher)
+	doctorVisitRepo := repository.NewDoctorVisitRepository(db, fieldCipher)
+	reminderRepo := repository.NewReminderRepository(db)
+	milestoneRepo := repository.NewMilestoneRepository(db)
+	courseRepo := repository.NewCourseRepository(db)
+	shareGrantRepo := repository.NewShareGrantRepository(db)
+	shareAccessLogRepo := repository.NewShareAccessLogRepository(db)
+	reportLinkRepo := repository.NewReportLinkRepository(db)
+	exportJobRepo := repository.NewExportJobRepository(db)
+	importRepo := repository.NewImportRepository(db, fieldCipher)
+	auditRepo := repository.NewAuditRepository(db)
+	consentRepo := repository.NewConsentRepository(db)
+	botUpdateRepo := repository.NewBotUpdateRepository(db)
+
+	// Локальный набор данных о составе лекарств и взаимодействиях
+	interactionSource, err := interaction.LoadFileSource(cfg.Interactions.DatasetPath)
+	if err != nil {
+		log.Fatal("failed to load interactions dataset:", err)
+	}
+
+	// Зависимости, общие для GraphQL API и фоновых задач
+	botClient := telegram.NewClient(cfg.Telegram.BotToken)
+	visitReminders := reminderapp.NewVisitReminderGenerator(doctorVisitRepo, userRepo, reminderRepo, cfg.Reminders.DoctorVisitLeadDays)
+	profilesUC := userapp.NewProfilesUseCase(userRepo)
+	accountDeletionUC := userapp.NewAccountDeletionUseCase(userRepo, cfg.Account.DeletionGrace)
+	consentUC := consentapp.NewConsentUseCase(consentRepo, cfg.Consent.PolicyVersion)
+	onboardingUC := userapp.NewOnboardingUseCase(userRepo, consentUC, symptomRepo, analysisRepo, medicationRepo)
+	shareAccessUC := sharingapp.NewAccessUseCase(shareGrantRepo, shareAccessLogRepo, userRepo)
+	endCourseUC := medicationapp.NewEndCourseUseCase(medicationRepo, intakeRepo, reminderRepo, courseRepo, userRepo)
+	createReportLinkUC := sharingapp.NewCreateReportLinkUseCase(reportLinkRepo, doctorVisitRepo, cfg.ReportLinks.TTL, cfg.ReportLinks.MaxViews)
+	openReportLinkUC := sharingapp.NewOpenReportLinkUseCase(reportLinkRepo,
+		doctorvisitapp.NewGenerateReportUseCase(doctorVisitRepo, symptomRepo, analysisRepo, medicationRepo, intakeRepo, courseRepo))
+	fileStore := storage.NewEncryptedStorage(storage.NewLocalStorage(cfg.Storage.Path), keyring)
+	fileSigner := storage.NewURLSigner(cfg.Storage.SigningKey, cfg.Server.PublicURL)
+	importArchiveUC := exportapp.NewImportArchiveUseCase(
+		userRepo, symptomRepo, analysisRepo, medicationRepo, intakeRepo, courseRepo, doctorVisitRepo, milestoneRepo, importRepo, fileStore)
+	recordAuditUC := auditapp.NewRecordUseCase(auditRepo)
+	snoozeReminderUC := reminderapp.NewSnoozeReminderUseCase(reminderRepo, map[reminder.Type]time.Duration{
+		reminder.TypeMedication: cfg.Reminders.MedicationSnooze,
+	})
 
 	// Инициализация resolver
 	resolver := graphql.NewResolver(
@@ -52,19 +119,117 @@ func main() {
 		medicationRepo,
 		intakeRepo,
 		doctorVisitRepo,
+		reminderRepo,
+		milestoneRepo,
+		courseRepo,
+		shareGrantRepo,
+		shareAccessLogRepo,
+		reportLinkRepo,
+		exportJobRepo,
+		botClient,
+		fileStore,
+		visitReminders,
+		profilesUC,
+		accountDeletionUC,
+		consentUC,
+		onboardingUC,
+		snoozeReminderUC,
+		endCourseUC,
+		interactionSource,
+		shareAccessUC,
+		createReportLinkUC,
+		importArchiveUC,
+		auditapp.NewGetAuditLogUseCase(auditRepo),
+		cfg.Server.PublicURL+web.ReportPagePath,
+		fileSigner,
+	)
+
+	// Обработка обновлений бота: те же use case, что и в GraphQL API
+	streakService := engagementapp.NewStreakService(userRepo, symptomRepo, intakeRepo, milestoneRepo, reminderRepo)
+	sendReportUC := doctorvisitapp.NewSendReportUseCase(
+		doctorvisitapp.NewGenerateReportUseCase(doctorVisitRepo, symptomRepo, analysisRepo, medicationRepo, intakeRepo, courseRepo),
+		userRepo, botClient)
+	telegramBot := bot.NewBot(
+		userRepo,
+		doctorVisitRepo,
+		reminderRepo,
+		botUpdateRepo,
+		consentUC,
+		onboardingUC,
+		symptomapp.NewCreateSymptomUseCase(symptomRepo, streakService),
+		dashboardapp.NewGetDashboardUseCase(symptomRepo, analysisRepo, medicationRepo, intakeRepo, doctorVisitRepo, streakService),
+		reminderapp.NewHandleActionUseCase(
+			reminderRepo,
+			userRepo,
+			snoozeReminderUC,
+			reminderapp.NewCompleteAnalysisReminderUseCase(analysisRepo, reminderRepo),
+			sendReportUC,
+			medicationapp.NewMarkIntakeUseCase(medicationRepo, intakeRepo, reminderRepo),
+		),
+		sendReportUC,
+		analysisapp.NewUploadAnalysisUseCase(analysisRepo, fileStore),
+		recordAuditUC,
+		botClient,
 	)
 
+	// Запуск фоновых задач напоминаний
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+
+	jobs := scheduler.New()
+	if cfg.Scheduler.Enabled {
+		jobs.Every(cfg.Scheduler.Interval, reminderapp.NewCheckInGenerator(userRepo, symptomRepo, reminderRepo))
+		jobs.Every(cfg.Scheduler.Interval, reminderapp.NewAnalysisReminderGenerator(analysisRepo, userRepo, reminderRepo, cfg.Reminders.AnalysisLeadDays))
+		jobs.Every(cfg.Scheduler.Interval, visitReminders)
+		jobs.Every(cfg.Scheduler.Interval, medicationapp.NewCourseExpirer(medicationRepo, userRepo, endCourseUC))
+		jobs.Every(cfg.Scheduler.Interval, medicationapp.NewIntakePlanner(medicationRepo, intakeRepo, userRepo))
+		jobs.Every(cfg.Scheduler.Interval, reminderapp.NewMedicationReminderGenerator(
+			medicationRepo, intakeRepo, reminderRepo, cfg.Reminders.MedicationMissedGrace, cfg.Reminders.MedicationFollowUpDelay))
+		jobs.Every(cfg.Scheduler.Interval, reminderapp.NewRefillReminderGenerator(medicationRepo, userRepo, reminderRepo, cfg.Reminders.RefillLeadDays))
+		jobs.Every(cfg.Scheduler.Interval, reminderapp.NewDispatcher(userRepo, reminderRepo, symptomRepo, botClient))
+		jobs.Every(cfg.Scheduler.Interval, exportapp.NewProcessor(
+			exportJobRepo,
+			userRepo,
+			exportapp.NewArchiveBuilder(userRepo, symptomRepo, analysisRepo, medicationRepo, intakeRepo, courseRepo, doctorVisitRepo, milestoneRepo, fileStore),
+			fileStore,
+			fileSigner,
+			botClient,
+			cfg.Export.LinkTTL,
+		))
+		jobs.Every(cfg.Scheduler.Interval, userapp.NewAccountPurger(
+			userRepo, symptomRepo, analysisRepo, exportJobRepo, fileStore, botClient, cfg.Account.DeletionGrace, cfg.Account.ReceiptSigningKey))
+		jobs.Every(cfg.Scheduler.Interval, auditapp.NewRetention(auditRepo, cfg.Audit.Retention))
+		jobs.Every(cfg.Scheduler.Interval, bot.NewUpdateRetention(botUpdateRepo))
+		jobs.Start(schedulerCtx)
+	}
+
 	// Настройка GraphQL сервера
-	// Используем type assertion для обхода проблемы с неэкспортированными типами introspection resolvers
-	// Методы __Field, __InputValue, __Schema, __Type возвращают анонимные интерфейсы,
-	// которые соответствуют неэкспортированным типам из generated пакета
-	resolverRoot := interface{}(resolver).(generated.ResolverRoot)
-	srv := handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: resolverRoot}))
+	srv := graphql.NewServer(generated.NewExecutableSchema(generated.Config{Resolvers: resolver}), exportapp.MaxArchiveSize)
+	srv.AroundRootFields(graphql.DeletionGate())
+	srv.AroundRootFields(graphql.ShareGate(shareAccessUC))
+	srv.AroundRootFields(graphql.ConsentGate(consentUC))
+	srv.AroundFields(graphql.AuditTrail(recordAuditUC))
+	authMiddleware := graphql.AuthMiddleware(userRepo, profilesUC, cfg.Telegram.BotToken)
+	shareMiddleware := graphql.ShareMiddleware(shareAccessUC)
 
 	// Настройка HTTP маршрутов
 	mux := http.NewServeMux()
 	mux.Handle("/", playground.Handler("GraphQL playground", "/query"))
-	mux.Handle("/query", srv)
+	mux.Handle("/query", authMiddleware(shareMiddleware(srv)))
+
+	// Веб-страница отчёта для врача, файлы анализов и архивы экспорта по подписанным ссылкам
+	reportPage := web.ReportPageHandler(openReportLinkUC, recordAuditUC, fileSigner, cfg.ReportLinks.FileURLTTL)
+	mux.Handle("GET "+web.ReportPagePath+"{token}", reportPage)
+	mux.Handle("POST "+web.ReportPagePath+"{token}", reportPage)
+	mux.Handle("GET "+storage.AnalysisFilePath+"{id}", web.AnalysisFileHandler(analysisRepo, recordAuditUC, fileSigner, fileStore))
+	mux.Handle("GET "+storage.ExportFilePath+"{id}", web.ExportFileHandler(exportJobRepo, recordAuditUC, fileSigner, fileStore))
+
+	// Обновления Telegram-бота принимаются, только если задан секрет вебхука
+	if cfg.Telegram.WebhookSecret != "" {
+		mux.Handle("POST "+bot.WebhookPath, bot.WebhookHandler(telegramBot, cfg.Telegram.WebhookSecret))
+	} else {
+		log.Println("telegram webhook secret is not configured, bot updates are not accepted")
+	}
 
 	// Определение адреса сервера
 	addr := ":" + cfg.Server.Port
@@ -75,7 +240,7 @@ func main() {
 	// Создание HTTP сервера с таймаутами
 	httpServer := &http.Server{
 		Addr:         addr,
-		Handler:      mux,
+		Handler:      web.RequestIDMiddleware(mux),
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
@@ -98,6 +263,10 @@ func main() {
 	<-sigChan
 	log.Println("Shutting down server...")
 
+	// Остановка фоновых задач
+	stopScheduler()
+	jobs.Wait()
+
 	// Создание контекста с таймаутом для graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -12,7 +12,10 @@ import (
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/health-hub-bot-api/internal/domain/analysis"
 	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+	"github.com/health-hub-bot-api/internal/domain/engagement"
+	"github.com/health-hub-bot-api/internal/domain/interaction"
 	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/sharing"
 	"github.com/health-hub-bot-api/internal/domain/symptom"
 	"github.com/health-hub-bot-api/internal/domain/user"
 )
@@ -34,6 +37,11 @@ type AnalysisFilter struct {
 	EndDate   *time.Time    `json:"endDate,omitempty"`
 }
 
+type AsNeededIntakeResult struct {
+	Intake   *medication.MedicationIntake `json:"intake"`
+	Warnings []*medication.DoseWarning    `json:"warnings"`
+}
+
 type CreateAnalysisInput struct {
 	Type             AnalysisType   `json:"type"`
 	Name             string         `json:"name"`
@@ -42,6 +50,12 @@ type CreateAnalysisInput struct {
 	NextReminderDate *time.Time     `json:"nextReminderDate,omitempty"`
 }
 
+type CreateDependentProfileInput struct {
+	Name   string       `json:"name"`
+	Age    *int         `json:"age,omitempty"`
+	Gender *user.Gender `json:"gender,omitempty"`
+}
+
 type CreateDoctorVisitInput struct {
 	VisitDate  time.Time `json:"visitDate"`
 	DoctorName *string   `json:"doctorName,omitempty"`
@@ -52,12 +66,36 @@ type CreateDoctorVisitInput struct {
 type CreateMedicationInput struct {
 	Name            string                  `json:"name"`
 	Dosage          string                  `json:"dosage"`
+	DosageDetails   *DosageDetailsInput     `json:"dosageDetails,omitempty"`
+	StockQuantity   *float64                `json:"stockQuantity,omitempty"`
 	ScheduleType    medication.ScheduleType `json:"scheduleType"`
 	ScheduleDetails *ScheduleDetailsInput   `json:"scheduleDetails"`
 	StartDate       time.Time               `json:"startDate"`
 	EndDate         *time.Time              `json:"endDate,omitempty"`
 }
 
+type CreateMedicationResult struct {
+	Medication          *medication.Medication `json:"medication"`
+	InteractionWarnings []*interaction.Warning `json:"interactionWarnings"`
+}
+
+type CreateReportLinkInput struct {
+	VisitID        string     `json:"visitId"`
+	StartDate      *time.Time `json:"startDate,omitempty"`
+	EndDate        *time.Time `json:"endDate,omitempty"`
+	ExpiresInHours *int       `json:"expiresInHours,omitempty"`
+	MaxViews       *int       `json:"maxViews,omitempty"`
+	Pin            *string    `json:"pin,omitempty"`
+}
+
+type CreateShareGrantInput struct {
+	GranteeTelegramUserID *string         `json:"granteeTelegramUserId,omitempty"`
+	Scopes                []sharing.Scope `json:"scopes"`
+	DataStartDate         *time.Time      `json:"dataStartDate,omitempty"`
+	DataEndDate           *time.Time      `json:"dataEndDate,omitempty"`
+	ExpiresAt             time.Time       `json:"expiresAt"`
+}
+
 type CreateSymptomEntryInput struct {
 	DateTime               time.Time       `json:"dateTime"`
 	Description            string          `json:"description"`
@@ -69,6 +107,22 @@ type CreateSymptomEntryInput struct {
 	Photo                  *graphql.Upload `json:"photo,omitempty"`
 }
 
+type Dashboard struct {
+	RecentSymptoms            []*symptom.SymptomEntry   `json:"recentSymptoms"`
+	TodayIntakes              []*TodayIntake            `json:"todayIntakes"`
+	NextDoctorVisit           *doctorvisit.DoctorVisit  `json:"nextDoctorVisit,omitempty"`
+	UpcomingAnalysisReminders []*analysis.Analysis      `json:"upcomingAnalysisReminders"`
+	WellbeingSeries           []*symptom.DailyWellbeing `json:"wellbeingSeries"`
+	Stats                     *DashboardStats           `json:"stats"`
+}
+
+type DashboardStats struct {
+	SymptomEntriesCount int      `json:"symptomEntriesCount"`
+	AverageWellbeing    *float64 `json:"averageWellbeing,omitempty"`
+	ActiveMedications   int      `json:"activeMedications"`
+	DiaryStreak         int      `json:"diaryStreak"`
+}
+
 type DoctorVisitConnection struct {
 	Edges      []*DoctorVisitEdge `json:"edges"`
 	PageInfo   *PageInfo          `json:"pageInfo"`
@@ -92,16 +146,38 @@ type DoctorVisitReport struct {
 	GeneratedAt    time.Time                   `json:"generatedAt"`
 }
 
+type DosageDetailsInput struct {
+	Amount float64               `json:"amount"`
+	Unit   medication.DosageUnit `json:"unit"`
+	Form   medication.DosageForm `json:"form"`
+}
+
+type DoseStepInput struct {
+	Days *int   `json:"days,omitempty"`
+	Dose string `json:"dose"`
+}
+
 type MarkMedicationIntakeInput struct {
-	MedicationID  string    `json:"medicationId"`
-	ScheduledTime time.Time `json:"scheduledTime"`
-	IsTaken       bool      `json:"isTaken"`
-	Notes         *string   `json:"notes,omitempty"`
+	MedicationID  string                   `json:"medicationId"`
+	ScheduledTime time.Time                `json:"scheduledTime"`
+	Status        *medication.IntakeStatus `json:"status,omitempty"`
+	IsTaken       *bool                    `json:"isTaken,omitempty"`
+	SkipReason    *string                  `json:"skipReason,omitempty"`
+	ActualDose    *string                  `json:"actualDose,omitempty"`
+	Notes         *string                  `json:"notes,omitempty"`
 }
 
 type Mutation struct {
 }
 
+type NotificationPreferencesInput struct {
+	CheckInTimes    []string                   `json:"checkInTimes"`
+	Channels        []user.NotificationChannel `json:"channels"`
+	QuietHoursStart *string                    `json:"quietHoursStart,omitempty"`
+	QuietHoursEnd   *string                    `json:"quietHoursEnd,omitempty"`
+	Days            []int                      `json:"days"`
+}
+
 type PageInfo struct {
 	HasNextPage     bool    `json:"hasNextPage"`
 	HasPreviousPage bool    `json:"hasPreviousPage"`
@@ -112,9 +188,23 @@ type PageInfo struct {
 type Query struct {
 }
 
+type ScheduleCycleInput struct {
+	DaysOn  int `json:"daysOn"`
+	DaysOff int `json:"daysOff"`
+}
+
 type ScheduleDetailsInput struct {
-	Times []string `json:"times"`
-	Days  []int    `json:"days,omitempty"`
+	Times         []string            `json:"times"`
+	Days          []int               `json:"days,omitempty"`
+	IntervalHours *int                `json:"intervalHours,omitempty"`
+	EveryNDays    *int                `json:"everyNDays,omitempty"`
+	Cycle         *ScheduleCycleInput `json:"cycle,omitempty"`
+	DoseSteps     []*DoseStepInput    `json:"doseSteps,omitempty"`
+}
+
+type Streaks struct {
+	SymptomLogging      *engagement.Streak `json:"symptomLogging"`
+	MedicationAdherence *engagement.Streak `json:"medicationAdherence"`
 }
 
 type SymptomConnection struct {
@@ -135,6 +225,11 @@ type SymptomFilter struct {
 	MaxWellbeingScale *int       `json:"maxWellbeingScale,omitempty"`
 }
 
+type TodayIntake struct {
+	Medication *medication.Medication         `json:"medication"`
+	Intakes    []*medication.MedicationIntake `json:"intakes"`
+}
+
 type UpdateAnalysisInput struct {
 	Type             *AnalysisType   `json:"type,omitempty"`
 	Name             *string         `json:"name,omitempty"`
@@ -153,6 +248,8 @@ type UpdateDoctorVisitInput struct {
 type UpdateMedicationInput struct {
 	Name            *string                  `json:"name,omitempty"`
 	Dosage          *string                  `json:"dosage,omitempty"`
+	DosageDetails   *DosageDetailsInput      `json:"dosageDetails,omitempty"`
+	StockQuantity   *float64                 `json:"stockQuantity,omitempty"`
 	ScheduleType    *medication.ScheduleType `json:"scheduleType,omitempty"`
 	ScheduleDetails *ScheduleDetailsInput    `json:"scheduleDetails,omitempty"`
 	StartDate       *time.Time               `json:"startDate,omitempty"`
@@ -237,3 +334,58 @@ func (e AnalysisType) MarshalJSON() ([]byte, error) {
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
 }
+
+type WellbeingPeriod string
+
+const (
+	WellbeingPeriodWeek  WellbeingPeriod = "WEEK"
+	WellbeingPeriodMonth WellbeingPeriod = "MONTH"
+)
+
+var AllWellbeingPeriod = []WellbeingPeriod{
+	WellbeingPeriodWeek,
+	WellbeingPeriodMonth,
+}
+
+func (e WellbeingPeriod) IsValid() bool {
+	switch e {
+	case WellbeingPeriodWeek, WellbeingPeriodMonth:
+		return true
+	}
+	return false
+}
+
+func (e WellbeingPeriod) String() string {
+	return string(e)
+}
+
+func (e *WellbeingPeriod) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WellbeingPeriod(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WellbeingPeriod", str)
+	}
+	return nil
+}
+
+func (e WellbeingPeriod) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *WellbeingPeriod) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e WellbeingPeriod) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,54 @@
+package analysis
+
+import (
+	"context"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/analysis"
+	"github.com/health-hub-bot-api/internal/domain/filestorage"
+	"github.com/health-hub-bot-api/internal/domain/reminder"
+)
+
+// DeleteAnalysisUseCase удаляет анализ вместе с файлом и напоминанием о повторном анализе
+type DeleteAnalysisUseCase struct {
+	analysisRepo analysis.Repository
+	reminderRepo reminder.Repository
+	files        filestorage.Storage
+}
+
+// NewDeleteAnalysisUseCase создаёт новый use case
+func NewDeleteAnalysisUseCase(analysisRepo analysis.Repository, reminderRepo reminder.Repository, files filestorage.Storage) *DeleteAnalysisUseCase {
+	return &DeleteAnalysisUseCase{
+		analysisRepo: analysisRepo,
+		reminderRepo: reminderRepo,
+		files:        files,
+	}
+}
+
+// Execute удаляет анализ пользователя
+func (uc *DeleteAnalysisUseCase) Execute(ctx context.Context, userID, analysisID uuid.UUID) error {
+	a, err := uc.analysisRepo.GetByID(ctx, analysisID)
+	if err != nil {
+		return err
+	}
+	if a == nil {
+		return analysis.ErrAnalysisNotFound
+	}
+	if a.UserID != userID {
+		return analysis.ErrUnauthorized
+	}
+
+	if err := uc.reminderRepo.DeletePendingByRelated(ctx, reminder.TypeAnalysis, a.ID); err != nil {
+		return err
+	}
+	if err := uc.analysisRepo.Delete(ctx, a.ID); err != nil {
+		return err
+	}
+
+	// Запись уже удалена, поэтому ошибка удаления файла не возвращается
+	if err := uc.files.Delete(ctx, a.FileURL); err != nil {
+		log.Printf("failed to delete file of analysis %s: %v", a.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package analysis
+
+import (
+	"context"
+	"io"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/analysis"
+	"github.com/health-hub-bot-api/internal/domain/filestorage"
+)
+
+// UpdateAnalysisUseCase изменяет анализ и при необходимости заменяет файл с результатами
+type UpdateAnalysisUseCase struct {
+	analysisRepo analysis.Repository
+	files        filestorage.Storage
+}
+
+// NewUpdateAnalysisUseCase создаёт новый use case
+func NewUpdateAnalysisUseCase(analysisRepo analysis.Repository, files filestorage.Storage) *UpdateAnalysisUseCase {
+	return &UpdateAnalysisUseCase{
+		analysisRepo: analysisRepo,
+		files:        files,
+	}
+}
+
+// UpdateAnalysisInput представляет входные данные; nil-поля не меняются.
+// Новый файл передаётся вместе с FileType и Ext, как при загрузке.
+type UpdateAnalysisInput struct {
+	UserID           uuid.UUID
+	AnalysisID       uuid.UUID
+	Type             *analysis.Type
+	Name             *string
+	DateTaken        *time.Time
+	NextReminderDate *time.Time
+	FileType         analysis.FileType
+	Ext              string
+	File             io.Reader
+}
+
+// Execute изменяет анализ пользователя. Новый файл сохраняется под своим
+// ключом, а прежний удаляется только после сохранения анализа.
+func (uc *UpdateAnalysisUseCase) Execute(ctx context.Context, input UpdateAnalysisInput) (*analysis.Analysis, error) {
+	a, err := uc.analysisRepo.GetByID(ctx, input.AnalysisID)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil {
+		return nil, analysis.ErrAnalysisNotFound
+	}
+	if a.UserID != input.UserID {
+		return nil, analysis.ErrUnauthorized
+	}
+
+	var fileURL *string
+	var fileType *analysis.FileType
+	previousURL := a.FileURL
+	if input.File != nil {
+		url, err := uc.files.Save(ctx, fileKey(a.UserID, uuid.New(), input.Ext), input.File)
+		if err != nil {
+			return nil, err
+		}
+		fileURL, fileType = &url, &input.FileType
+	}
+
+	a.Update(input.Type, input.Name, input.DateTaken, fileURL, fileType, input.NextReminderDate)
+	if err := uc.analysisRepo.Update(ctx, a); err != nil {
+		if fileURL != nil {
+			_ = uc.files.Delete(ctx, *fileURL)
+		}
+		return nil, err
+	}
+
+	// Анализ уже ссылается на новый файл, поэтому ошибка удаления прежнего не возвращается
+	if fileURL != nil {
+		if err := uc.files.Delete(ctx, previousURL); err != nil {
+			log.Printf("failed to delete replaced file of analysis %s: %v", a.ID, err)
+		}
+	}
+	return a, nil
+}
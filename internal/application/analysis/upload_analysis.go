@@ -0,0 +1,65 @@
+package analysis
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/analysis"
+	"github.com/health-hub-bot-api/internal/domain/filestorage"
+)
+
+// UploadAnalysisUseCase сохраняет файл с результатами анализа и создаёт анализ
+type UploadAnalysisUseCase struct {
+	analysisRepo analysis.Repository
+	files        filestorage.Storage
+}
+
+// NewUploadAnalysisUseCase создаёт новый use case
+func NewUploadAnalysisUseCase(analysisRepo analysis.Repository, files filestorage.Storage) *UploadAnalysisUseCase {
+	return &UploadAnalysisUseCase{
+		analysisRepo: analysisRepo,
+		files:        files,
+	}
+}
+
+// UploadAnalysisInput представляет входные данные загрузки
+type UploadAnalysisInput struct {
+	UserID    uuid.UUID
+	Type      analysis.Type
+	Name      string
+	DateTaken time.Time
+	FileType  analysis.FileType
+	// Ext — расширение файла с точкой, например ".pdf"
+	Ext  string
+	File io.Reader
+	// NextReminderDate — дата напоминания о повторном анализе
+	NextReminderDate *time.Time
+}
+
+// Execute сохраняет файл в хранилище и создаёт анализ; если анализ
+// не удалось создать, сохранённый файл удаляется
+func (uc *UploadAnalysisUseCase) Execute(ctx context.Context, input UploadAnalysisInput) (*analysis.Analysis, error) {
+	a := analysis.NewAnalysis(input.UserID, input.Type, input.Name, input.DateTaken, "", input.FileType)
+	if input.NextReminderDate != nil {
+		a.SetReminder(*input.NextReminderDate)
+	}
+
+	fileURL, err := uc.files.Save(ctx, fileKey(input.UserID, a.ID, input.Ext), input.File)
+	if err != nil {
+		return nil, err
+	}
+	a.FileURL = fileURL
+
+	if err := uc.analysisRepo.Create(ctx, a); err != nil {
+		_ = uc.files.Delete(ctx, fileURL)
+		return nil, err
+	}
+	return a, nil
+}
+
+// fileKey возвращает ключ файла анализа в хранилище
+func fileKey(userID, fileID uuid.UUID, ext string) string {
+	return "analyses/" + userID.String() + "/" + fileID.String() + ext
+}
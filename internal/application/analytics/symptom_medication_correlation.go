@@ -0,0 +1,173 @@
+package analytics
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/analytics"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/symptom"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+// defaultComparisonWindow — период до и после курса, если границы не заданы
+const defaultComparisonWindow = 30 * 24 * time.Hour
+
+const dayLayout = "2006-01-02"
+
+// SymptomMedicationCorrelationUseCase сопоставляет самочувствие с курсом лекарства
+type SymptomMedicationCorrelationUseCase struct {
+	symptomRepo    symptom.Repository
+	medicationRepo medication.Repository
+	intakeRepo     medication.IntakeRepository
+	userRepo       user.Repository
+}
+
+// NewSymptomMedicationCorrelationUseCase создаёт новый use case
+func NewSymptomMedicationCorrelationUseCase(
+	symptomRepo symptom.Repository,
+	medicationRepo medication.Repository,
+	intakeRepo medication.IntakeRepository,
+	userRepo user.Repository,
+) *SymptomMedicationCorrelationUseCase {
+	return &SymptomMedicationCorrelationUseCase{
+		symptomRepo:    symptomRepo,
+		medicationRepo: medicationRepo,
+		intakeRepo:     intakeRepo,
+		userRepo:       userRepo,
+	}
+}
+
+// SymptomMedicationCorrelationInput представляет входные данные
+type SymptomMedicationCorrelationInput struct {
+	UserID       uuid.UUID
+	MedicationID uuid.UUID
+	StartDate    *time.Time
+	EndDate      *time.Time
+}
+
+// Execute считает среднее самочувствие до, во время и после курса,
+// а также в дни с пропущенным приёмом и без пропусков
+func (uc *SymptomMedicationCorrelationUseCase) Execute(ctx context.Context, input SymptomMedicationCorrelationInput) (*analytics.SymptomMedicationCorrelation, error) {
+	med, err := uc.medicationRepo.GetByID(ctx, input.MedicationID)
+	if err != nil {
+		return nil, err
+	}
+	if med == nil {
+		return nil, medication.ErrMedicationNotFound
+	}
+	if med.UserID != input.UserID {
+		return nil, medication.ErrUnauthorized
+	}
+
+	// Дни самочувствия и приёмов сопоставляются в часовом поясе пользователя
+	loc := time.UTC
+	u, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if u != nil {
+		loc = u.Location()
+	}
+
+	now := time.Now()
+	startDate, endDate := comparisonPeriod(med, input.StartDate, input.EndDate, now)
+
+	days, err := uc.symptomRepo.GetDailyWellbeing(ctx, input.UserID, startDate, endDate, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	intakes, err := uc.intakeRepo.FindByMedicationAndPeriod(ctx, med.ID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Статус приёмов по дням: учитываются только наступившие приёмы
+	missedDays := make(map[string]bool)
+	var dueCount, takenCount int
+	for _, intake := range intakes {
+		if intake.ScheduledTime.After(now) {
+			continue
+		}
+		dueCount++
+		day := intake.ScheduledTime.In(loc).Format(dayLayout)
+		if intake.IsTaken() {
+			takenCount++
+			if _, ok := missedDays[day]; !ok {
+				missedDays[day] = false
+			}
+		} else {
+			missedDays[day] = true
+		}
+	}
+
+	courseStart := med.StartDate.Format(dayLayout)
+	courseEnd := now.In(loc).Format(dayLayout)
+	if med.EndDate != nil {
+		courseEnd = med.EndDate.Format(dayLayout)
+	}
+
+	var before, during, after, withMissed, allTaken []float64
+	for _, d := range days {
+		day := d.Date.Format(dayLayout)
+		switch {
+		case day < courseStart:
+			before = append(before, d.Average)
+		case day > courseEnd:
+			after = append(after, d.Average)
+		default:
+			during = append(during, d.Average)
+		}
+
+		if missed, ok := missedDays[day]; ok {
+			if missed {
+				withMissed = append(withMissed, d.Average)
+			} else {
+				allTaken = append(allTaken, d.Average)
+			}
+		}
+	}
+
+	result := &analytics.SymptomMedicationCorrelation{
+		MedicationID:          med.ID,
+		MedicationName:        med.Name,
+		StartDate:             startDate,
+		EndDate:               endDate,
+		BeforeCourse:          analytics.NewWellbeingStats(before),
+		DuringCourse:          analytics.NewWellbeingStats(during),
+		AfterCourse:           analytics.NewWellbeingStats(after),
+		DaysWithMissedDose:    analytics.NewWellbeingStats(withMissed),
+		DaysWithAllDosesTaken: analytics.NewWellbeingStats(allTaken),
+		Disclaimer:            analytics.CorrelationDisclaimer,
+	}
+	if dueCount > 0 {
+		rate := float64(takenCount) / float64(dueCount) * 100
+		result.AdherenceRate = &rate
+	}
+
+	return result, nil
+}
+
+// comparisonPeriod определяет границы сравнения: по умолчанию захватывает
+// месяц до начала курса и месяц после его окончания, но не позже текущего момента
+func comparisonPeriod(med *medication.Medication, startDate, endDate *time.Time, now time.Time) (time.Time, time.Time) {
+	start := med.StartDate.Add(-defaultComparisonWindow)
+	if startDate != nil {
+		start = *startDate
+	}
+
+	end := now
+	if med.EndDate != nil {
+		if afterCourse := med.EndDate.Add(defaultComparisonWindow); afterCourse.Before(now) {
+			end = afterCourse
+		}
+	}
+	if endDate != nil {
+		// Дата окончания включается в период целиком
+		end = endDate.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	return start, end
+}
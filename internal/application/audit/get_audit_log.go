@@ -0,0 +1,25 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/audit"
+)
+
+// GetAuditLogUseCase представляет use case для просмотра журнала аудита профиля
+type GetAuditLogUseCase struct {
+	auditRepo audit.Repository
+}
+
+// NewGetAuditLogUseCase создаёт новый use case
+func NewGetAuditLogUseCase(auditRepo audit.Repository) *GetAuditLogUseCase {
+	return &GetAuditLogUseCase{
+		auditRepo: auditRepo,
+	}
+}
+
+// Execute возвращает последние записи журнала профиля, новые первыми
+func (uc *GetAuditLogUseCase) Execute(ctx context.Context, ownerID uuid.UUID, filter audit.Filter, limit int) ([]*audit.Entry, error) {
+	return uc.auditRepo.FindByOwnerID(ctx, ownerID, filter, limit)
+}
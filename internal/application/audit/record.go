@@ -0,0 +1,27 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/health-hub-bot-api/internal/domain/audit"
+)
+
+// RecordUseCase представляет use case для записи в журнал аудита
+type RecordUseCase struct {
+	auditRepo audit.Repository
+}
+
+// NewRecordUseCase создаёт новый use case
+func NewRecordUseCase(auditRepo audit.Repository) *RecordUseCase {
+	return &RecordUseCase{
+		auditRepo: auditRepo,
+	}
+}
+
+// Execute добавляет запись в журнал, привязывая её к ID текущего запроса
+func (uc *RecordUseCase) Execute(ctx context.Context, entry *audit.Entry) error {
+	if entry.RequestID == "" {
+		entry.RequestID = RequestID(ctx)
+	}
+	return uc.auditRepo.Create(ctx, entry)
+}
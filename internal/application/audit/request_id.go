@@ -0,0 +1,17 @@
+package audit
+
+import "context"
+
+type requestIDContextKey struct{}
+
+// WithRequestID сохраняет ID запроса в контексте, чтобы связать записи журнала
+// с логами сервера
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestID возвращает ID запроса из контекста или пустую строку
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/health-hub-bot-api/internal/domain/audit"
+)
+
+// Retention удаляет записи журнала аудита старше срока хранения
+type Retention struct {
+	auditRepo audit.Repository
+	period    time.Duration
+}
+
+// NewRetention создаёт задачу очистки журнала со сроком хранения period
+func NewRetention(auditRepo audit.Repository, period time.Duration) *Retention {
+	return &Retention{
+		auditRepo: auditRepo,
+		period:    period,
+	}
+}
+
+// Name возвращает имя задачи для планировщика
+func (r *Retention) Name() string {
+	return "audit-retention"
+}
+
+// Run удаляет записи, срок хранения которых истёк
+func (r *Retention) Run(ctx context.Context) error {
+	deleted, err := r.auditRepo.DeleteBefore(ctx, time.Now().Add(-r.period))
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		log.Printf("audit: deleted %d expired entries", deleted)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package consent
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/consent"
+)
+
+// Status представляет состояние согласия аккаунта относительно действующей политики
+type Status struct {
+	// PolicyVersion — действующая версия политики обработки данных
+	PolicyVersion string
+	// Consent — последнее согласие аккаунта; nil, если его не было
+	Consent *consent.Consent
+}
+
+// Accepted проверяет, что действует согласие на текущую версию политики.
+// После смены версии в конфигурации возвращает false, и клиент снова запрашивает согласие.
+func (s *Status) Accepted() bool {
+	return s.Consent != nil && s.Consent.Covers(s.PolicyVersion)
+}
+
+// AcceptedVersion возвращает версию политики последнего согласия
+func (s *Status) AcceptedVersion() *string {
+	if s.Consent == nil {
+		return nil
+	}
+	return &s.Consent.PolicyVersion
+}
+
+// AcceptedAt возвращает время последнего согласия
+func (s *Status) AcceptedAt() *time.Time {
+	if s.Consent == nil {
+		return nil
+	}
+	return &s.Consent.AcceptedAt
+}
+
+// Channel возвращает канал последнего согласия
+func (s *Status) Channel() *consent.Channel {
+	if s.Consent == nil {
+		return nil
+	}
+	return &s.Consent.Channel
+}
+
+// WithdrawnAt возвращает время отзыва последнего согласия
+func (s *Status) WithdrawnAt() *time.Time {
+	if s.Consent == nil {
+		return nil
+	}
+	return s.Consent.WithdrawnAt
+}
+
+// ConsentUseCase представляет use case для согласия на обработку персональных данных
+type ConsentUseCase struct {
+	consentRepo   consent.Repository
+	policyVersion string
+}
+
+// NewConsentUseCase создаёт новый use case; policyVersion — действующая версия политики
+func NewConsentUseCase(consentRepo consent.Repository, policyVersion string) *ConsentUseCase {
+	return &ConsentUseCase{
+		consentRepo:   consentRepo,
+		policyVersion: policyVersion,
+	}
+}
+
+// Status возвращает состояние согласия аккаунта
+func (uc *ConsentUseCase) Status(ctx context.Context, accountID uuid.UUID) (*Status, error) {
+	latest, err := uc.consentRepo.GetLatest(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return &Status{PolicyVersion: uc.policyVersion, Consent: latest}, nil
+}
+
+// Accept записывает согласие на версию политики policyVersion, которую видел
+// пользователь; она должна совпадать с действующей. Повторное принятие той же
+// версии не создаёт новую запись.
+func (uc *ConsentUseCase) Accept(ctx context.Context, accountID uuid.UUID, policyVersion string, channel consent.Channel, evidenceHash string) (*Status, error) {
+	if policyVersion != uc.policyVersion {
+		return nil, consent.ErrPolicyVersionMismatch
+	}
+
+	status, err := uc.Status(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if status.Accepted() {
+		return status, nil
+	}
+
+	c, err := consent.NewConsent(accountID, policyVersion, channel, evidenceHash)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.consentRepo.Create(ctx, c); err != nil {
+		return nil, err
+	}
+
+	status.Consent = c
+	return status, nil
+}
+
+// Withdraw отзывает действующее согласие. Данные не удаляются, но изменять
+// их нельзя, пока согласие не дано снова.
+func (uc *ConsentUseCase) Withdraw(ctx context.Context, accountID uuid.UUID) (*Status, error) {
+	status, err := uc.Status(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if status.Consent == nil {
+		return nil, consent.ErrConsentNotGiven
+	}
+
+	if err := status.Consent.Withdraw(); err != nil {
+		return nil, err
+	}
+	if err := uc.consentRepo.Update(ctx, status.Consent); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// Check возвращает ErrConsentRequired, если у аккаунта нет согласия на текущую версию политики
+func (uc *ConsentUseCase) Check(ctx context.Context, accountID uuid.UUID) error {
+	status, err := uc.Status(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	if !status.Accepted() {
+		return consent.ErrConsentRequired
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package dashboard
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	engagementapp "github.com/health-hub-bot-api/internal/application/engagement"
+	"github.com/health-hub-bot-api/internal/domain/analysis"
+	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/symptom"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// upcomingVisitsHorizon — насколько вперёд искать ближайший визит к врачу
+	upcomingVisitsHorizon = 90 * 24 * time.Hour
+	// analysisRemindersHorizon — насколько вперёд показывать напоминания об анализах
+	analysisRemindersHorizon = 30 * 24 * time.Hour
+)
+
+// Dashboard представляет данные главного экрана
+type Dashboard struct {
+	RecentSymptoms            []*symptom.SymptomEntry
+	TodayIntakes              []TodayIntake
+	NextDoctorVisit           *doctorvisit.DoctorVisit
+	UpcomingAnalysisReminders []*analysis.Analysis
+	WellbeingSeries           []symptom.DailyWellbeing
+	Stats                     Stats
+}
+
+// TodayIntake представляет план приёма лекарства на сегодня
+type TodayIntake struct {
+	Medication *medication.Medication
+	Intakes    []*medication.MedicationIntake
+}
+
+// Stats представляет быструю статистику
+type Stats struct {
+	SymptomEntriesCount int
+	AverageWellbeing    *float64
+	ActiveMedications   int
+	DiaryStreak         int
+}
+
+// GetDashboardUseCase собирает данные главного экрана за один запрос
+type GetDashboardUseCase struct {
+	symptomRepo     symptom.Repository
+	analysisRepo    analysis.Repository
+	medicationRepo  medication.Repository
+	intakeRepo      medication.IntakeRepository
+	doctorVisitRepo doctorvisit.Repository
+	streakService   *engagementapp.StreakService
+}
+
+// NewGetDashboardUseCase создаёт новый use case
+func NewGetDashboardUseCase(
+	symptomRepo symptom.Repository,
+	analysisRepo analysis.Repository,
+	medicationRepo medication.Repository,
+	intakeRepo medication.IntakeRepository,
+	doctorVisitRepo doctorvisit.Repository,
+	streakService *engagementapp.StreakService,
+) *GetDashboardUseCase {
+	return &GetDashboardUseCase{
+		symptomRepo:     symptomRepo,
+		analysisRepo:    analysisRepo,
+		medicationRepo:  medicationRepo,
+		intakeRepo:      intakeRepo,
+		doctorVisitRepo: doctorVisitRepo,
+		streakService:   streakService,
+	}
+}
+
+// GetDashboardInput представляет входные данные
+type GetDashboardInput struct {
+	UserID        uuid.UUID
+	RecentLimit   int
+	WellbeingDays int
+	// Location — часовой пояс пользователя, в котором считаются дни; по умолчанию UTC
+	Location *time.Location
+}
+
+// Execute параллельно запрашивает все части главного экрана
+func (uc *GetDashboardUseCase) Execute(ctx context.Context, input GetDashboardInput) (*Dashboard, error) {
+	loc := input.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	seriesStart := today.AddDate(0, 0, -(input.WellbeingDays - 1))
+
+	result := &Dashboard{}
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		entries, _, err := uc.symptomRepo.FindByFilter(ctx, symptom.Filter{UserID: input.UserID}, input.RecentLimit, 0)
+		result.RecentSymptoms = entries
+		return err
+	})
+
+	g.Go(func() error {
+		medications, err := uc.medicationRepo.FindByUserID(ctx, input.UserID, true)
+		if err != nil {
+			return err
+		}
+		result.Stats.ActiveMedications = len(medications)
+		result.TodayIntakes = make([]TodayIntake, 0, len(medications))
+		for _, m := range medications {
+			intakes, err := uc.intakeRepo.FindByMedicationAndDate(ctx, m.ID, today)
+			if err != nil {
+				return err
+			}
+			result.TodayIntakes = append(result.TodayIntakes, TodayIntake{Medication: m, Intakes: intakes})
+		}
+		return nil
+	})
+
+	g.Go(func() error {
+		visits, err := uc.doctorVisitRepo.GetUpcomingVisits(ctx, input.UserID, now.Add(upcomingVisitsHorizon))
+		if err != nil {
+			return err
+		}
+		if len(visits) > 0 {
+			result.NextDoctorVisit = visits[0]
+		}
+		return nil
+	})
+
+	g.Go(func() error {
+		analyses, err := uc.analysisRepo.GetUpcomingReminders(ctx, input.UserID, now.Add(analysisRemindersHorizon))
+		result.UpcomingAnalysisReminders = analyses
+		return err
+	})
+
+	g.Go(func() error {
+		series, err := uc.symptomRepo.GetDailyWellbeing(ctx, input.UserID, seriesStart, now, loc)
+		if err != nil {
+			return err
+		}
+		result.WellbeingSeries = series
+
+		var sum float64
+		for _, day := range series {
+			result.Stats.SymptomEntriesCount += day.EntriesCount
+			sum += day.Average * float64(day.EntriesCount)
+		}
+		if result.Stats.SymptomEntriesCount > 0 {
+			average := sum / float64(result.Stats.SymptomEntriesCount)
+			result.Stats.AverageWellbeing = &average
+		}
+		return nil
+	})
+
+	g.Go(func() error {
+		streaks, err := uc.streakService.GetStreaks(ctx, input.UserID)
+		if err != nil {
+			return err
+		}
+		result.Stats.DiaryStreak = streaks.SymptomLogging.Current
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
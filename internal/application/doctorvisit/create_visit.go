@@ -0,0 +1,50 @@
+package doctorvisit
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+)
+
+// CreateVisitUseCase представляет use case для создания визита к врачу
+type CreateVisitUseCase struct {
+	doctorVisitRepo doctorvisit.Repository
+	reminderSyncer  VisitReminderSyncer
+}
+
+// NewCreateVisitUseCase создаёт новый use case
+func NewCreateVisitUseCase(doctorVisitRepo doctorvisit.Repository, reminderSyncer VisitReminderSyncer) *CreateVisitUseCase {
+	return &CreateVisitUseCase{
+		doctorVisitRepo: doctorVisitRepo,
+		reminderSyncer:  reminderSyncer,
+	}
+}
+
+// CreateVisitInput представляет входные данные для создания визита
+type CreateVisitInput struct {
+	UserID     uuid.UUID
+	VisitDate  time.Time
+	DoctorName *string
+	Specialty  *string
+	Questions  *string
+}
+
+// Execute создаёт визит и сразу планирует напоминание о нём
+func (uc *CreateVisitUseCase) Execute(ctx context.Context, input CreateVisitInput) (*doctorvisit.DoctorVisit, error) {
+	visit := doctorvisit.NewDoctorVisit(input.UserID, input.VisitDate)
+	visit.Update(nil, input.DoctorName, input.Specialty, input.Questions)
+
+	if err := uc.doctorVisitRepo.Create(ctx, visit); err != nil {
+		return nil, err
+	}
+
+	if uc.reminderSyncer != nil {
+		if err := uc.reminderSyncer.SyncVisit(ctx, visit); err != nil {
+			return nil, err
+		}
+	}
+
+	return visit, nil
+}
@@ -0,0 +1,39 @@
+package doctorvisit
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+	"github.com/health-hub-bot-api/internal/domain/reminder"
+)
+
+// DeleteVisitUseCase представляет use case для удаления визита к врачу
+type DeleteVisitUseCase struct {
+	doctorVisitRepo doctorvisit.Repository
+	reminderRepo    reminder.Repository
+}
+
+// NewDeleteVisitUseCase создаёт новый use case
+func NewDeleteVisitUseCase(doctorVisitRepo doctorvisit.Repository, reminderRepo reminder.Repository) *DeleteVisitUseCase {
+	return &DeleteVisitUseCase{
+		doctorVisitRepo: doctorVisitRepo,
+		reminderRepo:    reminderRepo,
+	}
+}
+
+// Execute удаляет визит пользователя и неотправленное напоминание о нём
+func (uc *DeleteVisitUseCase) Execute(ctx context.Context, userID, visitID uuid.UUID) error {
+	visit, err := uc.doctorVisitRepo.GetByID(ctx, visitID)
+	if err != nil {
+		return err
+	}
+	if visit.UserID != userID {
+		return ErrUnauthorized
+	}
+
+	if err := uc.reminderRepo.DeletePendingByRelated(ctx, reminder.TypeDoctorVisit, visit.ID); err != nil {
+		return err
+	}
+	return uc.doctorVisitRepo.Delete(ctx, visit.ID)
+}
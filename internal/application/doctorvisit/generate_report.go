@@ -17,6 +17,8 @@ type GenerateReportUseCase struct {
 	symptomRepo     symptom.Repository
 	analysisRepo    analysis.Repository
 	medicationRepo  medication.Repository
+	intakeRepo      medication.IntakeRepository
+	courseRepo      medication.CourseRepository
 }
 
 // NewGenerateReportUseCase создаёт новый use case
@@ -25,12 +27,16 @@ func NewGenerateReportUseCase(
 	symptomRepo symptom.Repository,
 	analysisRepo analysis.Repository,
 	medicationRepo medication.Repository,
+	intakeRepo medication.IntakeRepository,
+	courseRepo medication.CourseRepository,
 ) *GenerateReportUseCase {
 	return &GenerateReportUseCase{
 		doctorVisitRepo: doctorVisitRepo,
 		symptomRepo:     symptomRepo,
 		analysisRepo:    analysisRepo,
 		medicationRepo:  medicationRepo,
+		intakeRepo:      intakeRepo,
+		courseRepo:      courseRepo,
 	}
 }
 
@@ -94,32 +100,7 @@ func (uc *GenerateReportUseCase) Execute(ctx context.Context, input GenerateRepo
 
 	// Вычисляем статистику
 	if len(trendData) > 0 {
-		var sum int
-		min := trendData[0].Value
-		max := trendData[0].Value
-		dataPoints := make([]doctorvisit.WellbeingDataPoint, 0, len(trendData))
-
-		for _, point := range trendData {
-			sum += point.Value
-			if point.Value < min {
-				min = point.Value
-			}
-			if point.Value > max {
-				max = point.Value
-			}
-			dataPoints = append(dataPoints, doctorvisit.WellbeingDataPoint{
-				Date:  point.Date,
-				Value: point.Value,
-			})
-		}
-
-		average := float64(sum) / float64(len(trendData))
-		report.SetWellbeingTrend(doctorvisit.WellbeingTrend{
-			Average:    average,
-			Min:        min,
-			Max:        max,
-			DataPoints: dataPoints,
-		})
+		report.SetWellbeingTrend(WellbeingTrend(trendData))
 	}
 
 	// Получаем анализы за период
@@ -139,6 +120,7 @@ func (uc *GenerateReportUseCase) Execute(ctx context.Context, input GenerateRepo
 			Type:      string(a.Type),
 			Name:      a.Name,
 			DateTaken: a.DateTaken,
+			FileType:  string(a.FileType),
 		})
 	}
 
@@ -149,11 +131,42 @@ func (uc *GenerateReportUseCase) Execute(ctx context.Context, input GenerateRepo
 	}
 
 	for _, m := range medications {
-		report.AddMedication(doctorvisit.ReportMedication{
+		reportMedication := doctorvisit.ReportMedication{
 			ID:       m.ID,
 			Name:     m.Name,
 			Dosage:   m.Dosage,
 			IsActive: m.IsActive,
+		}
+
+		// Для лекарств по необходимости врачу важно, как часто они понадобились
+		if m.ScheduleType == medication.ScheduleTypeAsNeeded {
+			intakes, err := uc.intakeRepo.FindByMedicationAndPeriod(ctx, m.ID, input.StartDate, input.EndDate)
+			if err != nil {
+				return nil, err
+			}
+			for _, intake := range intakes {
+				if intake.IsAsNeeded && intake.IsTaken() {
+					reportMedication.AsNeededCount++
+				}
+			}
+		}
+
+		report.AddMedication(reportMedication)
+	}
+
+	// Курсы, завершённые за период, показывают врачу недавние изменения лечения
+	courses, err := uc.courseRepo.FindEndedBetween(ctx, input.UserID, input.StartDate, input.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range courses {
+		report.AddEndedCourse(doctorvisit.ReportCourse{
+			Name:      c.MedicationName,
+			Dosage:    c.Dosage,
+			StartDate: c.StartDate,
+			EndDate:   *c.EndDate,
+			Reason:    string(*c.Reason),
 		})
 	}
 
@@ -196,5 +209,38 @@ func (uc *GenerateReportUseCase) Execute(ctx context.Context, input GenerateRepo
 	return report, nil
 }
 
+// WellbeingTrend вычисляет среднее, минимум и максимум самочувствия по точкам тренда
+func WellbeingTrend(trendData []symptom.WellbeingDataPoint) doctorvisit.WellbeingTrend {
+	if len(trendData) == 0 {
+		return doctorvisit.WellbeingTrend{}
+	}
+
+	var sum int
+	min := trendData[0].Value
+	max := trendData[0].Value
+	dataPoints := make([]doctorvisit.WellbeingDataPoint, 0, len(trendData))
+
+	for _, point := range trendData {
+		sum += point.Value
+		if point.Value < min {
+			min = point.Value
+		}
+		if point.Value > max {
+			max = point.Value
+		}
+		dataPoints = append(dataPoints, doctorvisit.WellbeingDataPoint{
+			Date:  point.Date,
+			Value: point.Value,
+		})
+	}
+
+	return doctorvisit.WellbeingTrend{
+		Average:    float64(sum) / float64(len(trendData)),
+		Min:        min,
+		Max:        max,
+		DataPoints: dataPoints,
+	}
+}
+
 var ErrUnauthorized = doctorvisit.ErrUnauthorized
 
@@ -0,0 +1,167 @@
+package doctorvisit
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+)
+
+//go:embed templates/report.html
+var reportTemplates embed.FS
+
+var reportHTMLTemplate = template.Must(template.ParseFS(reportTemplates, "templates/report.html"))
+
+// FileURLFunc возвращает ссылку для просмотра файла анализа
+type FileURLFunc func(analysisID uuid.UUID) string
+
+// RenderReportHTML формирует веб-страницу отчёта для врача.
+// Файлы анализов встраиваются по ссылкам из fileURL; expiresAt показывается
+// как срок, до которого страница доступна.
+func RenderReportHTML(w io.Writer, report *doctorvisit.Report, fileURL FileURLFunc, expiresAt time.Time) error {
+	return reportHTMLTemplate.Execute(w, newReportPage(report, fileURL, expiresAt))
+}
+
+// reportPage представляет данные шаблона отчёта с уже отформатированными значениями
+type reportPage struct {
+	VisitDate   string
+	PeriodStart string
+	PeriodEnd   string
+	Wellbeing   *reportPageWellbeing
+	Symptoms    []reportPageSymptom
+	Analyses    []reportPageAnalysis
+	Medications []reportPageMedication
+	Courses     []reportPageCourse
+	Questions   string
+	GeneratedAt string
+	ExpiresAt   string
+}
+
+type reportPageWellbeing struct {
+	Average string
+	Min     int
+	Max     int
+	Points  []reportPagePoint
+}
+
+type reportPagePoint struct {
+	Date  string
+	Value int
+}
+
+type reportPageSymptom struct {
+	DateTime       string
+	Description    string
+	WellbeingScale int
+}
+
+type reportPageAnalysis struct {
+	Date    string
+	Name    string
+	Type    string
+	FileURL string
+	IsImage bool
+}
+
+type reportPageMedication struct {
+	Name          string
+	Dosage        string
+	AsNeededCount int
+}
+
+type reportPageCourse struct {
+	Name   string
+	Dosage string
+	Start  string
+	End    string
+	Reason string
+}
+
+// newReportPage подготавливает данные отчёта для шаблона
+func newReportPage(report *doctorvisit.Report, fileURL FileURLFunc, expiresAt time.Time) reportPage {
+	page := reportPage{
+		VisitDate:   report.VisitDate.Format(reportDateLayout),
+		PeriodStart: report.Period.StartDate.Format(reportDateLayout),
+		PeriodEnd:   report.Period.EndDate.Format(reportDateLayout),
+		GeneratedAt: report.GeneratedAt.Format(reportDateTimeLayout),
+		ExpiresAt:   expiresAt.Format(reportDateTimeLayout),
+	}
+
+	if len(report.WellbeingTrend.DataPoints) > 0 {
+		trend := report.WellbeingTrend
+		wellbeing := &reportPageWellbeing{
+			Average: fmt.Sprintf("%.1f", trend.Average),
+			Min:     trend.Min,
+			Max:     trend.Max,
+		}
+		for _, point := range trend.DataPoints {
+			wellbeing.Points = append(wellbeing.Points, reportPagePoint{
+				Date:  point.Date.Format(reportDateLayout),
+				Value: point.Value,
+			})
+		}
+		page.Wellbeing = wellbeing
+	}
+
+	for _, s := range report.Symptoms {
+		page.Symptoms = append(page.Symptoms, reportPageSymptom{
+			DateTime:       s.DateTime.Format(reportDateTimeLayout),
+			Description:    s.Description,
+			WellbeingScale: s.WellbeingScale,
+		})
+	}
+
+	for _, a := range report.Analyses {
+		page.Analyses = append(page.Analyses, reportPageAnalysis{
+			Date:    a.DateTaken.Format(reportDateLayout),
+			Name:    a.Name,
+			Type:    analysisTypeLabel(a.Type),
+			FileURL: fileURL(a.ID),
+			IsImage: a.FileType == "image",
+		})
+	}
+
+	for _, m := range report.Medications {
+		page.Medications = append(page.Medications, reportPageMedication{
+			Name:          m.Name,
+			Dosage:        m.Dosage,
+			AsNeededCount: m.AsNeededCount,
+		})
+	}
+
+	for _, c := range report.EndedCourses {
+		page.Courses = append(page.Courses, reportPageCourse{
+			Name:   c.Name,
+			Dosage: c.Dosage,
+			Start:  c.StartDate.Format(reportDateLayout),
+			End:    c.EndDate.Format(reportDateLayout),
+			Reason: courseEndReasonLabel(c.Reason),
+		})
+	}
+
+	if report.Questions != nil {
+		page.Questions = *report.Questions
+	}
+
+	return page
+}
+
+// analysisTypeLabel возвращает подпись типа анализа
+func analysisTypeLabel(analysisType string) string {
+	switch analysisType {
+	case "blood":
+		return "кровь"
+	case "urine":
+		return "моча"
+	case "ultrasound":
+		return "УЗИ"
+	case "xray":
+		return "рентген"
+	default:
+		return "другое"
+	}
+}
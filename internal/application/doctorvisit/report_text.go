@@ -0,0 +1,78 @@
+package doctorvisit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+)
+
+const (
+	reportDateLayout     = "02.01.2006"
+	reportDateTimeLayout = "02.01.2006 15:04"
+)
+
+// RenderReportText формирует текстовую версию отчёта для отправки в чат
+func RenderReportText(report *doctorvisit.Report) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Отчёт к визиту %s\n", report.VisitDate.Format(reportDateLayout))
+	fmt.Fprintf(&b, "Период: %s – %s\n",
+		report.Period.StartDate.Format(reportDateLayout), report.Period.EndDate.Format(reportDateLayout))
+
+	if len(report.WellbeingTrend.DataPoints) > 0 {
+		fmt.Fprintf(&b, "\nСамочувствие: в среднем %.1f (от %d до %d)\n",
+			report.WellbeingTrend.Average, report.WellbeingTrend.Min, report.WellbeingTrend.Max)
+	}
+
+	if len(report.Symptoms) > 0 {
+		b.WriteString("\nСимптомы:\n")
+		for _, s := range report.Symptoms {
+			fmt.Fprintf(&b, "• %s — %s (самочувствие %d)\n", s.DateTime.Format(reportDateTimeLayout), s.Description, s.WellbeingScale)
+		}
+	}
+
+	if len(report.Analyses) > 0 {
+		b.WriteString("\nАнализы:\n")
+		for _, a := range report.Analyses {
+			fmt.Fprintf(&b, "• %s — %s\n", a.DateTaken.Format(reportDateLayout), a.Name)
+		}
+	}
+
+	if len(report.Medications) > 0 {
+		b.WriteString("\nЛекарства:\n")
+		for _, m := range report.Medications {
+			if m.AsNeededCount > 0 {
+				fmt.Fprintf(&b, "• %s, %s — по необходимости %d раз за период\n", m.Name, m.Dosage, m.AsNeededCount)
+				continue
+			}
+			fmt.Fprintf(&b, "• %s, %s\n", m.Name, m.Dosage)
+		}
+	}
+
+	if len(report.EndedCourses) > 0 {
+		b.WriteString("\nЗавершённые курсы:\n")
+		for _, c := range report.EndedCourses {
+			fmt.Fprintf(&b, "• %s, %s: %s – %s, %s\n", c.Name, c.Dosage,
+				c.StartDate.Format(reportDateLayout), c.EndDate.Format(reportDateLayout), courseEndReasonLabel(c.Reason))
+		}
+	}
+
+	if report.Questions != nil && *report.Questions != "" {
+		fmt.Fprintf(&b, "\nВопросы к врачу:\n%s\n", *report.Questions)
+	}
+
+	return b.String()
+}
+
+// courseEndReasonLabel возвращает подпись причины завершения курса
+func courseEndReasonLabel(reason string) string {
+	switch reason {
+	case "completed":
+		return "завершён по плану"
+	case "replaced":
+		return "заменён другим лекарством"
+	default:
+		return "прекращён"
+	}
+}
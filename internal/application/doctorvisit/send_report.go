@@ -0,0 +1,107 @@
+package doctorvisit
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/notification"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+// DefaultReportPeriod — период отчёта по умолчанию перед визитом
+const DefaultReportPeriod = 30 * 24 * time.Hour
+
+// maxMessageLength — предел длины одного сообщения Telegram с запасом
+const maxMessageLength = 4000
+
+// SendReportUseCase представляет use case для отправки отчёта к визиту в чат
+type SendReportUseCase struct {
+	generateReport *GenerateReportUseCase
+	userRepo       user.Repository
+	notifier       notification.Notifier
+}
+
+// NewSendReportUseCase создаёт новый use case
+func NewSendReportUseCase(
+	generateReport *GenerateReportUseCase,
+	userRepo user.Repository,
+	notifier notification.Notifier,
+) *SendReportUseCase {
+	return &SendReportUseCase{
+		generateReport: generateReport,
+		userRepo:       userRepo,
+		notifier:       notifier,
+	}
+}
+
+// SendReportInput представляет входные данные для отправки отчёта
+type SendReportInput struct {
+	VisitID uuid.UUID
+	UserID  uuid.UUID
+}
+
+// Execute генерирует отчёт за период по умолчанию и отправляет его пользователю
+func (uc *SendReportUseCase) Execute(ctx context.Context, input SendReportInput) error {
+	u, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return err
+	}
+	if u == nil {
+		return user.ErrUserNotFound
+	}
+	// Отчёт подопечного профиля уходит в чат владельца аккаунта
+	recipient, err := user.Recipient(ctx, uc.userRepo, u)
+	if err != nil {
+		return err
+	}
+	if recipient == nil {
+		return user.ErrUserNotFound
+	}
+
+	endDate := time.Now()
+	report, err := uc.generateReport.Execute(ctx, GenerateReportInput{
+		VisitID:   input.VisitID,
+		UserID:    input.UserID,
+		StartDate: endDate.Add(-DefaultReportPeriod),
+		EndDate:   endDate,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, chunk := range splitMessage(u.LabelMessage(RenderReportText(report)), maxMessageLength) {
+		if err := uc.notifier.Send(ctx, recipient.TelegramUserID, notification.Message{Text: chunk}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// splitMessage делит текст на части не длиннее limit символов по границам строк
+func splitMessage(text string, limit int) []string {
+	var chunks []string
+	var current strings.Builder
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if current.Len() > 0 && utf8.RuneCountInString(current.String())+utf8.RuneCountInString(line) > limit {
+			chunks = append(chunks, current.String())
+			current.Reset()
+		}
+		// Слишком длинная строка режется по символам
+		for utf8.RuneCountInString(line) > limit {
+			runes := []rune(line)
+			chunks = append(chunks, string(runes[:limit]))
+			line = string(runes[limit:])
+		}
+		current.WriteString(line)
+	}
+	if current.Len() > 0 {
+		chunks = append(chunks, current.String())
+	}
+
+	return chunks
+}
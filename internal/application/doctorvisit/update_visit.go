@@ -0,0 +1,65 @@
+package doctorvisit
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+)
+
+// VisitReminderSyncer переносит напоминание вслед за датой визита
+type VisitReminderSyncer interface {
+	SyncVisit(ctx context.Context, visit *doctorvisit.DoctorVisit) error
+}
+
+// UpdateVisitUseCase представляет use case для обновления визита к врачу
+type UpdateVisitUseCase struct {
+	doctorVisitRepo doctorvisit.Repository
+	reminderSyncer  VisitReminderSyncer
+}
+
+// NewUpdateVisitUseCase создаёт новый use case
+func NewUpdateVisitUseCase(doctorVisitRepo doctorvisit.Repository, reminderSyncer VisitReminderSyncer) *UpdateVisitUseCase {
+	return &UpdateVisitUseCase{
+		doctorVisitRepo: doctorVisitRepo,
+		reminderSyncer:  reminderSyncer,
+	}
+}
+
+// UpdateVisitInput представляет входные данные для обновления визита
+type UpdateVisitInput struct {
+	VisitID    uuid.UUID
+	UserID     uuid.UUID
+	VisitDate  *time.Time
+	DoctorName *string
+	Specialty  *string
+	Questions  *string
+}
+
+// Execute обновляет визит и, если изменилась дата, переносит напоминание
+func (uc *UpdateVisitUseCase) Execute(ctx context.Context, input UpdateVisitInput) (*doctorvisit.DoctorVisit, error) {
+	visit, err := uc.doctorVisitRepo.GetByID(ctx, input.VisitID)
+	if err != nil {
+		return nil, err
+	}
+	if visit.UserID != input.UserID {
+		return nil, ErrUnauthorized
+	}
+
+	dateChanged := input.VisitDate != nil && !input.VisitDate.Equal(visit.VisitDate)
+	visit.Update(input.VisitDate, input.DoctorName, input.Specialty, input.Questions)
+
+	if err := uc.doctorVisitRepo.Update(ctx, visit); err != nil {
+		return nil, err
+	}
+
+	// Специальность входит в текст напоминания, поэтому синхронизируем и при её смене
+	if uc.reminderSyncer != nil && (dateChanged || input.Specialty != nil) {
+		if err := uc.reminderSyncer.SyncVisit(ctx, visit); err != nil {
+			return nil, err
+		}
+	}
+
+	return visit, nil
+}
@@ -0,0 +1,180 @@
+package engagement
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/engagement"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/reminder"
+	"github.com/health-hub-bot-api/internal/domain/symptom"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+// streakLookbackDays — глубина истории для подсчёта серий
+const streakLookbackDays = 730
+
+// Streaks представляет серии пользователя
+type Streaks struct {
+	SymptomLogging      engagement.Streak
+	MedicationAdherence engagement.Streak
+}
+
+// StreakService считает серии дней и отмечает достигнутые вехи
+type StreakService struct {
+	userRepo      user.Repository
+	symptomRepo   symptom.Repository
+	intakeRepo    medication.IntakeRepository
+	milestoneRepo engagement.MilestoneRepository
+	reminderRepo  reminder.Repository
+}
+
+// NewStreakService создаёт новый сервис серий
+func NewStreakService(
+	userRepo user.Repository,
+	symptomRepo symptom.Repository,
+	intakeRepo medication.IntakeRepository,
+	milestoneRepo engagement.MilestoneRepository,
+	reminderRepo reminder.Repository,
+) *StreakService {
+	return &StreakService{
+		userRepo:      userRepo,
+		symptomRepo:   symptomRepo,
+		intakeRepo:    intakeRepo,
+		milestoneRepo: milestoneRepo,
+		reminderRepo:  reminderRepo,
+	}
+}
+
+// GetStreaks считает текущие и самые длинные серии в часовом поясе пользователя
+func (s *StreakService) GetStreaks(ctx context.Context, userID uuid.UUID) (*Streaks, error) {
+	u, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, user.ErrUserNotFound
+	}
+
+	loc := u.Location()
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	from := today.AddDate(0, 0, -streakLookbackDays)
+
+	entryTimes, err := s.symptomRepo.GetEntryTimes(ctx, userID, from)
+	if err != nil {
+		return nil, err
+	}
+	intakes, err := s.intakeRepo.FindByUserAndPeriod(ctx, userID, from, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Streaks{
+		SymptomLogging: engagement.CalculateStreak(
+			symptomDayStatuses(entryTimes, loc), engagement.DayFailed, firstDay(entryTimes, loc, today), today),
+		// Дни без запланированных приёмов серию соблюдения режима не прерывают
+		MedicationAdherence: engagement.CalculateStreak(
+			adherenceDayStatuses(intakes, now, loc), engagement.DayNeutral, firstIntakeDay(intakes, loc, today), today),
+	}, nil
+}
+
+// CheckMilestones отмечает новые вехи и ставит в очередь поздравления
+func (s *StreakService) CheckMilestones(ctx context.Context, userID uuid.UUID) ([]*engagement.Milestone, error) {
+	streaks, err := s.GetStreaks(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := map[engagement.StreakKind]int{
+		engagement.StreakKindSymptomLogging:      streaks.SymptomLogging.Current,
+		engagement.StreakKindMedicationAdherence: streaks.MedicationAdherence.Current,
+	}
+
+	var achieved []*engagement.Milestone
+	for kind, days := range current {
+		for _, threshold := range engagement.ReachedMilestones(days) {
+			milestone := engagement.NewMilestone(userID, kind, threshold)
+			created, err := s.milestoneRepo.Record(ctx, milestone)
+			if err != nil {
+				return nil, err
+			}
+			if !created {
+				continue
+			}
+			achieved = append(achieved, milestone)
+		}
+	}
+
+	// Поздравляем только с самой крупной новой вехой каждого вида
+	for _, milestone := range highestPerKind(achieved) {
+		rem := reminder.NewReminder(userID, reminder.TypeMilestone, &milestone.ID, time.Now(), milestone.Message())
+		if err := s.reminderRepo.Create(ctx, rem); err != nil {
+			return nil, err
+		}
+	}
+
+	return achieved, nil
+}
+
+// symptomDayStatuses отмечает дни, в которые была хотя бы одна запись
+func symptomDayStatuses(entryTimes []time.Time, loc *time.Location) map[string]engagement.DayStatus {
+	statuses := make(map[string]engagement.DayStatus, len(entryTimes))
+	for _, t := range entryTimes {
+		statuses[engagement.DayKey(t, loc)] = engagement.DaySuccess
+	}
+	return statuses
+}
+
+// adherenceDayStatuses засчитывает дни, в которые все наступившие приёмы отмечены.
+// Дни без запланированных приёмов серию не прерывают.
+func adherenceDayStatuses(intakes []*medication.MedicationIntake, now time.Time, loc *time.Location) map[string]engagement.DayStatus {
+	statuses := make(map[string]engagement.DayStatus)
+	for _, intake := range intakes {
+		if intake.ScheduledTime.After(now) {
+			continue
+		}
+		key := engagement.DayKey(intake.ScheduledTime, loc)
+		if !intake.IsTaken() {
+			statuses[key] = engagement.DayFailed
+		} else if statuses[key] != engagement.DayFailed {
+			statuses[key] = engagement.DaySuccess
+		}
+	}
+	return statuses
+}
+
+// firstDay возвращает первый день с записью или today, если записей нет
+func firstDay(times []time.Time, loc *time.Location, today time.Time) time.Time {
+	if len(times) == 0 {
+		return today
+	}
+	t := times[0].In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// firstIntakeDay возвращает первый день с приёмом или today, если приёмов нет
+func firstIntakeDay(intakes []*medication.MedicationIntake, loc *time.Location, today time.Time) time.Time {
+	times := make([]time.Time, 0, len(intakes))
+	for _, intake := range intakes {
+		times = append(times, intake.ScheduledTime)
+	}
+	return firstDay(times, loc, today)
+}
+
+// highestPerKind оставляет по одной, самой крупной вехе каждого вида
+func highestPerKind(milestones []*engagement.Milestone) []*engagement.Milestone {
+	highest := make(map[engagement.StreakKind]*engagement.Milestone)
+	for _, m := range milestones {
+		if current, ok := highest[m.Kind]; !ok || m.Days > current.Days {
+			highest[m.Kind] = m
+		}
+	}
+
+	result := make([]*engagement.Milestone, 0, len(highest))
+	for _, m := range highest {
+		result = append(result, m)
+	}
+	return result
+}
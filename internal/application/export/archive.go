@@ -0,0 +1,415 @@
+package export
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/analysis"
+	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+	"github.com/health-hub-bot-api/internal/domain/engagement"
+	"github.com/health-hub-bot-api/internal/domain/export"
+	"github.com/health-hub-bot-api/internal/domain/filestorage"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/symptom"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+const (
+	// pageSize — размер страницы при чтении записей профиля
+	pageSize = 500
+	// intakeHorizon — насколько вперёд выгружаются запланированные приёмы
+	intakeHorizon = 365 * 24 * time.Hour
+)
+
+// ArchiveBuilder собирает все данные профиля в ZIP-архив:
+// manifest.json, data/<entity>.json и data/<entity>.csv, files/ с исходными файлами анализов
+type ArchiveBuilder struct {
+	userRepo        user.Repository
+	symptomRepo     symptom.Repository
+	analysisRepo    analysis.Repository
+	medicationRepo  medication.Repository
+	intakeRepo      medication.IntakeRepository
+	courseRepo      medication.CourseRepository
+	doctorVisitRepo doctorvisit.Repository
+	milestoneRepo   engagement.MilestoneRepository
+	files           filestorage.Storage
+}
+
+// NewArchiveBuilder создаёт сборщик архива
+func NewArchiveBuilder(
+	userRepo user.Repository,
+	symptomRepo symptom.Repository,
+	analysisRepo analysis.Repository,
+	medicationRepo medication.Repository,
+	intakeRepo medication.IntakeRepository,
+	courseRepo medication.CourseRepository,
+	doctorVisitRepo doctorvisit.Repository,
+	milestoneRepo engagement.MilestoneRepository,
+	files filestorage.Storage,
+) *ArchiveBuilder {
+	return &ArchiveBuilder{
+		userRepo:        userRepo,
+		symptomRepo:     symptomRepo,
+		analysisRepo:    analysisRepo,
+		medicationRepo:  medicationRepo,
+		intakeRepo:      intakeRepo,
+		courseRepo:      courseRepo,
+		doctorVisitRepo: doctorVisitRepo,
+		milestoneRepo:   milestoneRepo,
+		files:           files,
+	}
+}
+
+// Build собирает архив профиля userID, сохраняет его в хранилище под ключом key
+// и возвращает URL архива и его размер
+func (b *ArchiveBuilder) Build(ctx context.Context, userID uuid.UUID, key string) (string, int64, error) {
+	tmp, err := os.CreateTemp("", "healthhub-export-*.zip")
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	zw := zip.NewWriter(tmp)
+	if err := b.write(ctx, zw, userID); err != nil {
+		return "", 0, err
+	}
+	if err := zw.Close(); err != nil {
+		return "", 0, err
+	}
+
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", 0, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return "", 0, err
+	}
+
+	archiveURL, err := b.files.Save(ctx, key, tmp)
+	if err != nil {
+		return "", 0, err
+	}
+	return archiveURL, size, nil
+}
+
+// write записывает данные профиля и манифест в архив
+func (b *ArchiveBuilder) write(ctx context.Context, zw *zip.Writer, userID uuid.UUID) error {
+	exportedAt := time.Now()
+	manifest := export.NewManifest(userID, exportedAt)
+
+	u, err := b.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if u == nil {
+		return user.ErrUserNotFound
+	}
+	if err := writeEntity(zw, manifest, export.EntityProfile, []export.ProfileRecord{profileRecord(u)}); err != nil {
+		return err
+	}
+
+	// Дневник и показатели
+	symptoms, err := allSymptoms(ctx, b.symptomRepo, userID)
+	if err != nil {
+		return err
+	}
+	symptomRecords := make([]export.SymptomRecord, 0, len(symptoms))
+	vitalRecords := []export.VitalRecord{}
+	for _, s := range symptoms {
+		symptomRecords = append(symptomRecords, symptomRecord(s))
+		if vital, ok := vitalRecord(s); ok {
+			vitalRecords = append(vitalRecords, vital)
+		}
+	}
+	if err := writeEntity(zw, manifest, export.EntitySymptoms, symptomRecords); err != nil {
+		return err
+	}
+	if err := writeEntity(zw, manifest, export.EntityVitals, vitalRecords); err != nil {
+		return err
+	}
+
+	// Анализы вместе с исходными файлами
+	analyses, err := allAnalyses(ctx, b.analysisRepo, userID)
+	if err != nil {
+		return err
+	}
+	analysisRecords := make([]export.AnalysisRecord, 0, len(analyses))
+	for _, a := range analyses {
+		file := b.writeAnalysisFile(ctx, zw, a)
+		manifest.AddFile(file)
+		analysisRecords = append(analysisRecords, analysisRecord(a, file.Path))
+	}
+	if err := writeEntity(zw, manifest, export.EntityAnalyses, analysisRecords); err != nil {
+		return err
+	}
+
+	// Лекарства, приёмы и история курсов
+	medications, err := b.medicationRepo.FindByUserID(ctx, userID, false)
+	if err != nil {
+		return err
+	}
+	medicationRecords := make([]export.MedicationRecord, 0, len(medications))
+	for _, m := range medications {
+		medicationRecords = append(medicationRecords, medicationRecord(m))
+	}
+	if err := writeEntity(zw, manifest, export.EntityMedications, medicationRecords); err != nil {
+		return err
+	}
+
+	intakes, err := b.intakeRepo.FindByUserAndPeriod(ctx, userID, time.Time{}, exportedAt.Add(intakeHorizon))
+	if err != nil {
+		return err
+	}
+	intakeRecords := make([]export.IntakeRecord, 0, len(intakes))
+	for _, i := range intakes {
+		intakeRecords = append(intakeRecords, intakeRecord(i))
+	}
+	if err := writeEntity(zw, manifest, export.EntityIntakes, intakeRecords); err != nil {
+		return err
+	}
+
+	courses, err := b.courseRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	courseRecords := make([]export.CourseRecord, 0, len(courses))
+	for _, c := range courses {
+		courseRecords = append(courseRecords, courseRecord(c))
+	}
+	if err := writeEntity(zw, manifest, export.EntityCourses, courseRecords); err != nil {
+		return err
+	}
+
+	// Визиты и сформированные к ним отчёты
+	visits, err := allVisits(ctx, b.doctorVisitRepo, userID)
+	if err != nil {
+		return err
+	}
+	visitRecords := make([]export.DoctorVisitRecord, 0, len(visits))
+	snapshotRecords := []export.ReportSnapshotRecord{}
+	for _, v := range visits {
+		visitRecords = append(visitRecords, doctorVisitRecord(v))
+		if snapshot, ok := reportSnapshotRecord(v); ok {
+			snapshotRecords = append(snapshotRecords, snapshot)
+		}
+	}
+	if err := writeEntity(zw, manifest, export.EntityDoctorVisits, visitRecords); err != nil {
+		return err
+	}
+	if err := writeEntity(zw, manifest, export.EntityReportSnapshots, snapshotRecords); err != nil {
+		return err
+	}
+
+	milestones, err := b.milestoneRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	milestoneRecords := make([]export.MilestoneRecord, 0, len(milestones))
+	for _, m := range milestones {
+		milestoneRecords = append(milestoneRecords, milestoneRecord(m))
+	}
+	if err := writeEntity(zw, manifest, export.EntityMilestones, milestoneRecords); err != nil {
+		return err
+	}
+
+	return writeJSON(zw, export.ManifestPath, manifest)
+}
+
+// writeAnalysisFile копирует исходный файл анализа в архив. Недоступный файл
+// не прерывает выгрузку: причина записывается в манифест.
+func (b *ArchiveBuilder) writeAnalysisFile(ctx context.Context, zw *zip.Writer, a *analysis.Analysis) export.ManifestFile {
+	file := export.ManifestFile{AnalysisID: a.ID}
+	if a.FileURL == "" {
+		file.Error = filestorage.ErrFileNotFound.Error()
+		return file
+	}
+
+	src, err := b.files.Open(ctx, a.FileURL)
+	if err != nil {
+		file.Error = err.Error()
+		return file
+	}
+	defer src.Close()
+
+	name := export.FilesDir + "analyses/" + a.ID.String() + fileExtension(a)
+	dst, err := zw.Create(name)
+	if err != nil {
+		file.Error = err.Error()
+		return file
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(dst, hash), src)
+	if err != nil {
+		file.Error = err.Error()
+		return file
+	}
+
+	file.Path = name
+	file.Size = size
+	file.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	return file
+}
+
+// allSymptoms возвращает все записи дневника профиля
+func allSymptoms(ctx context.Context, repo symptom.Repository, userID uuid.UUID) ([]*symptom.SymptomEntry, error) {
+	var all []*symptom.SymptomEntry
+	for offset := 0; ; offset += pageSize {
+		page, total, err := repo.FindByFilter(ctx, symptom.Filter{UserID: userID}, pageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) == 0 || len(all) >= total {
+			return all, nil
+		}
+	}
+}
+
+// allAnalyses возвращает все анализы профиля
+func allAnalyses(ctx context.Context, repo analysis.Repository, userID uuid.UUID) ([]*analysis.Analysis, error) {
+	var all []*analysis.Analysis
+	for offset := 0; ; offset += pageSize {
+		page, total, err := repo.FindByFilter(ctx, analysis.Filter{UserID: userID}, pageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) == 0 || len(all) >= total {
+			return all, nil
+		}
+	}
+}
+
+// allVisits возвращает все визиты профиля
+func allVisits(ctx context.Context, repo doctorvisit.Repository, userID uuid.UUID) ([]*doctorvisit.DoctorVisit, error) {
+	var all []*doctorvisit.DoctorVisit
+	for offset := 0; ; offset += pageSize {
+		page, total, err := repo.FindByFilter(ctx, doctorvisit.Filter{UserID: userID}, pageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) == 0 || len(all) >= total {
+			return all, nil
+		}
+	}
+}
+
+// fileExtension возвращает расширение файла анализа по его URL или типу
+func fileExtension(a *analysis.Analysis) string {
+	ext := path.Ext(strings.SplitN(a.FileURL, "?", 2)[0])
+	if ext != "" && len(ext) <= 5 {
+		return strings.ToLower(ext)
+	}
+	if a.FileType == analysis.FileTypePDF {
+		return ".pdf"
+	}
+	return ".jpg"
+}
+
+// writeEntity записывает сущность в data/<name>.json и data/<name>.csv и добавляет её в манифест
+func writeEntity[T any](zw *zip.Writer, manifest *export.Manifest, name string, rows []T) error {
+	if rows == nil {
+		rows = []T{}
+	}
+	if err := writeJSON(zw, export.DataDir+name+".json", rows); err != nil {
+		return err
+	}
+	if err := writeCSV(zw, export.DataDir+name+".csv", rows); err != nil {
+		return err
+	}
+	manifest.AddEntity(name, len(rows))
+	return nil
+}
+
+// writeJSON записывает значение в архив как JSON
+func writeJSON(zw *zip.Writer, name string, value interface{}) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// writeCSV записывает записи в архив как CSV. Колонки называются по JSON-тегам
+// полей; вложенные структуры и списки записываются в ячейку как JSON.
+func writeCSV[T any](zw *zip.Writer, name string, rows []T) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	out := csv.NewWriter(w)
+
+	recordType := reflect.TypeOf((*T)(nil)).Elem()
+	header := make([]string, recordType.NumField())
+	for i := range header {
+		header[i] = strings.SplitN(recordType.Field(i).Tag.Get("json"), ",", 2)[0]
+	}
+	if err := out.Write(header); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		value := reflect.ValueOf(row)
+		cells := make([]string, len(header))
+		for i := range cells {
+			cell, err := csvCell(value.Field(i))
+			if err != nil {
+				return err
+			}
+			cells[i] = cell
+		}
+		if err := out.Write(cells); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// csvCell форматирует значение поля для ячейки CSV
+func csvCell(value reflect.Value) (string, error) {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return "", nil
+		}
+		value = value.Elem()
+	}
+
+	switch v := value.Interface().(type) {
+	case time.Time:
+		return v.Format(time.RFC3339), nil
+	case uuid.UUID:
+		return v.String(), nil
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case int, int64, bool:
+		return fmt.Sprint(v), nil
+	}
+
+	data, err := json.Marshal(value.Interface())
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,155 @@
+package export
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/export"
+)
+
+const (
+	// maxManifestSize ограничивает размер manifest.json
+	maxManifestSize = 1 << 20
+	// maxEntitySize ограничивает размер JSON одной сущности
+	maxEntitySize = 64 << 20
+	// maxAnalysisFileSize ограничивает размер одного файла анализа после распаковки
+	maxAnalysisFileSize = 64 << 20
+	// maxAnalysisFilesTotal ограничивает суммарный размер файлов анализов после распаковки
+	maxAnalysisFilesTotal = 1 << 30
+)
+
+// archiveReader читает и проверяет архив выгрузки
+type archiveReader struct {
+	zip      *zip.Reader
+	manifest *export.Manifest
+	files    map[uuid.UUID]export.ManifestFile
+}
+
+// openArchive открывает архив и проверяет его манифест
+func openArchive(r io.ReaderAt, size int64) (*archiveReader, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, export.ErrInvalidArchive
+	}
+
+	a := &archiveReader{zip: zr, files: make(map[uuid.UUID]export.ManifestFile)}
+	manifest := &export.Manifest{}
+	if err := a.decode(export.ManifestPath, maxManifestSize, manifest); err != nil {
+		return nil, err
+	}
+	if manifest.Format != export.ArchiveFormat {
+		return nil, export.ErrInvalidArchive
+	}
+	if manifest.SchemaVersion < 1 || manifest.SchemaVersion > export.SchemaVersion {
+		return nil, fmt.Errorf("%w: %d", export.ErrUnsupportedSchemaVersion, manifest.SchemaVersion)
+	}
+	var total int64
+	for _, file := range manifest.Files {
+		if file.Path != "" {
+			if err := checkManifestFile(file); err != nil {
+				return nil, err
+			}
+			total += file.Size
+		}
+		a.files[file.AnalysisID] = file
+	}
+	// Размеры из манифеста ограничивают распаковку (см. restoreFile),
+	// поэтому архив-бомба не может записать в хранилище больше заявленного
+	if total > maxAnalysisFilesTotal {
+		return nil, fmt.Errorf("%w: analysis files exceed %d MB", export.ErrInvalidArchive, maxAnalysisFilesTotal>>20)
+	}
+
+	a.manifest = manifest
+	return a, nil
+}
+
+// entity возвращает описание сущности из манифеста; false, если сущность не выгружалась
+func (a *archiveReader) entity(name string) (export.ManifestEntity, bool) {
+	for _, entity := range a.manifest.Entities {
+		if entity.Name == name {
+			return entity, true
+		}
+	}
+	return export.ManifestEntity{}, false
+}
+
+// file возвращает файл анализа из архива; false, если файла в архиве нет
+func (a *archiveReader) file(analysisID uuid.UUID) (export.ManifestFile, *zip.File, bool) {
+	entry, ok := a.files[analysisID]
+	if !ok || entry.Path == "" {
+		return export.ManifestFile{}, nil, false
+	}
+	for _, f := range a.zip.File {
+		if f.Name == entry.Path {
+			return entry, f, true
+		}
+	}
+	return export.ManifestFile{}, nil, false
+}
+
+// decode читает JSON-файл архива не больше limit байт
+func (a *archiveReader) decode(name string, limit int64, value interface{}) error {
+	f, err := a.zip.Open(name)
+	if err != nil {
+		return fmt.Errorf("%w: %s is missing", export.ErrInvalidArchive, name)
+	}
+	defer f.Close()
+
+	if err := json.NewDecoder(io.LimitReader(f, limit)).Decode(value); err != nil {
+		return fmt.Errorf("%w: %s: %v", export.ErrInvalidArchive, name, err)
+	}
+	return nil
+}
+
+// readEntity читает записи сущности и сверяет их число с манифестом.
+// Сущность, которой нет в манифесте, считается пустой.
+func readEntity[T any](a *archiveReader, name string) ([]T, error) {
+	entity, ok := a.entity(name)
+	if !ok {
+		return nil, nil
+	}
+
+	var rows []T
+	if err := a.decode(export.DataDir+name+".json", maxEntitySize, &rows); err != nil {
+		return nil, err
+	}
+	if len(rows) != entity.Rows {
+		return nil, fmt.Errorf("%w: %s has %d rows, manifest lists %d", export.ErrInvalidArchive, name, len(rows), entity.Rows)
+	}
+	return rows, nil
+}
+
+// checkManifestFile проверяет путь, размер и контрольную сумму файла анализа из манифеста
+func checkManifestFile(file export.ManifestFile) error {
+	if !isArchivePath(file.Path, export.FilesDir) {
+		return fmt.Errorf("%w: unexpected file path %q", export.ErrInvalidArchive, file.Path)
+	}
+	if file.Size < 0 || file.Size > maxAnalysisFileSize {
+		return fmt.Errorf("%w: file %q is larger than %d MB", export.ErrInvalidArchive, file.Path, maxAnalysisFileSize>>20)
+	}
+	if sum, err := hex.DecodeString(file.SHA256); err != nil || len(sum) != sha256.Size {
+		return fmt.Errorf("%w: file %q has no valid sha256", export.ErrInvalidArchive, file.Path)
+	}
+	return nil
+}
+
+// isArchivePath проверяет, что путь лежит внутри каталога dir архива
+func isArchivePath(name, dir string) bool {
+	return strings.HasPrefix(name, dir) && !strings.Contains(name, "..") && !strings.Contains(name, "\\")
+}
+
+// countingWriter считает записанные байты
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,673 @@
+package export
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/analysis"
+	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+	"github.com/health-hub-bot-api/internal/domain/engagement"
+	"github.com/health-hub-bot-api/internal/domain/export"
+	"github.com/health-hub-bot-api/internal/domain/filestorage"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/symptom"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+// MaxArchiveSize ограничивает размер загружаемого архива
+const MaxArchiveSize = 256 << 20
+
+const dateKeyLayout = "2006-01-02"
+
+// ImportArchiveUseCase представляет use case для импорта данных из архива выгрузки.
+// ID записей архива заменяются новыми, записи, которые уже есть у профиля,
+// пропускаются, файлы анализов восстанавливаются в хранилище. Записи каждой
+// сущности сохраняются в одной транзакции.
+type ImportArchiveUseCase struct {
+	userRepo        user.Repository
+	symptomRepo     symptom.Repository
+	analysisRepo    analysis.Repository
+	medicationRepo  medication.Repository
+	intakeRepo      medication.IntakeRepository
+	courseRepo      medication.CourseRepository
+	doctorVisitRepo doctorvisit.Repository
+	milestoneRepo   engagement.MilestoneRepository
+	importRepo      export.ImportRepository
+	files           filestorage.Storage
+}
+
+// NewImportArchiveUseCase создаёт новый use case
+func NewImportArchiveUseCase(
+	userRepo user.Repository,
+	symptomRepo symptom.Repository,
+	analysisRepo analysis.Repository,
+	medicationRepo medication.Repository,
+	intakeRepo medication.IntakeRepository,
+	courseRepo medication.CourseRepository,
+	doctorVisitRepo doctorvisit.Repository,
+	milestoneRepo engagement.MilestoneRepository,
+	importRepo export.ImportRepository,
+	files filestorage.Storage,
+) *ImportArchiveUseCase {
+	return &ImportArchiveUseCase{
+		userRepo:        userRepo,
+		symptomRepo:     symptomRepo,
+		analysisRepo:    analysisRepo,
+		medicationRepo:  medicationRepo,
+		intakeRepo:      intakeRepo,
+		courseRepo:      courseRepo,
+		doctorVisitRepo: doctorVisitRepo,
+		milestoneRepo:   milestoneRepo,
+		importRepo:      importRepo,
+		files:           files,
+	}
+}
+
+// archiveContents представляет записи архива, прочитанные до начала импорта
+type archiveContents struct {
+	profile    []export.ProfileRecord
+	symptoms   []export.SymptomRecord
+	analyses   []export.AnalysisRecord
+	meds       []export.MedicationRecord
+	intakes    []export.IntakeRecord
+	courses    []export.CourseRecord
+	visits     []export.DoctorVisitRecord
+	snapshots  []export.ReportSnapshotRecord
+	milestones []export.MilestoneRecord
+}
+
+// importRun хранит состояние одного импорта
+type importRun struct {
+	*ImportArchiveUseCase
+	profile *user.User
+	archive *archiveReader
+	ids     idMap
+	summary *export.ImportSummary
+}
+
+// Execute импортирует архив в профиль userID и возвращает итоги по сущностям.
+// Архив целиком проверяется до записи: повреждённый архив не импортируется вовсе.
+func (uc *ImportArchiveUseCase) Execute(ctx context.Context, userID uuid.UUID, archive io.Reader) (*export.ImportSummary, error) {
+	profile, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, user.ErrUserNotFound
+	}
+
+	tmp, err := os.CreateTemp("", "healthhub-import-*.zip")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, io.LimitReader(archive, MaxArchiveSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if size > MaxArchiveSize {
+		return nil, fmt.Errorf("%w: archive is larger than %d MB", export.ErrInvalidArchive, MaxArchiveSize>>20)
+	}
+
+	reader, err := openArchive(tmp, size)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := readContents(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &importRun{
+		ImportArchiveUseCase: uc,
+		profile:              profile,
+		archive:              reader,
+		ids:                  idMap{},
+		summary:              &export.ImportSummary{},
+	}
+	steps := []func(context.Context, *archiveContents) error{
+		run.importProfile,
+		run.importSymptoms,
+		run.importAnalyses,
+		run.importMedications,
+		run.importIntakes,
+		run.importCourses,
+		run.importDoctorVisits,
+		run.importMilestones,
+	}
+	for _, step := range steps {
+		if err := step(ctx, contents); err != nil {
+			return nil, err
+		}
+	}
+
+	return run.summary, nil
+}
+
+// readContents читает все сущности архива
+func readContents(a *archiveReader) (*archiveContents, error) {
+	c := &archiveContents{}
+	var err error
+	if c.profile, err = readEntity[export.ProfileRecord](a, export.EntityProfile); err != nil {
+		return nil, err
+	}
+	if c.symptoms, err = readEntity[export.SymptomRecord](a, export.EntitySymptoms); err != nil {
+		return nil, err
+	}
+	if c.analyses, err = readEntity[export.AnalysisRecord](a, export.EntityAnalyses); err != nil {
+		return nil, err
+	}
+	if c.meds, err = readEntity[export.MedicationRecord](a, export.EntityMedications); err != nil {
+		return nil, err
+	}
+	if c.intakes, err = readEntity[export.IntakeRecord](a, export.EntityIntakes); err != nil {
+		return nil, err
+	}
+	if c.courses, err = readEntity[export.CourseRecord](a, export.EntityCourses); err != nil {
+		return nil, err
+	}
+	if c.visits, err = readEntity[export.DoctorVisitRecord](a, export.EntityDoctorVisits); err != nil {
+		return nil, err
+	}
+	if c.snapshots, err = readEntity[export.ReportSnapshotRecord](a, export.EntityReportSnapshots); err != nil {
+		return nil, err
+	}
+	if c.milestones, err = readEntity[export.MilestoneRecord](a, export.EntityMilestones); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// importProfile дополняет профиль полями из архива, которые у профиля не заполнены
+func (r *importRun) importProfile(ctx context.Context, c *archiveContents) error {
+	summary := r.summary.Entity(export.EntityProfile)
+	for i, record := range c.profile {
+		r.ids[record.ID] = r.profile.ID
+		if i > 0 {
+			summary.Skipped++
+			continue
+		}
+
+		profileChanged, timezoneChanged := false, false
+		if r.profile.Age == nil && record.Age != nil {
+			r.profile.Age = record.Age
+			profileChanged = true
+		}
+		if r.profile.Gender == nil && record.Gender != nil {
+			gender := user.Gender(*record.Gender)
+			if gender == user.GenderMale || gender == user.GenderFemale || gender == user.GenderOther {
+				r.profile.Gender = &gender
+				profileChanged = true
+			}
+		}
+		if r.profile.Timezone == user.DefaultTimezone && record.Timezone != "" && record.Timezone != user.DefaultTimezone {
+			if err := r.profile.SetTimezone(record.Timezone); err != nil {
+				summary.Fail(i, err)
+				continue
+			}
+			timezoneChanged = true
+		}
+
+		if !profileChanged && !timezoneChanged {
+			summary.Skipped++
+			continue
+		}
+		if profileChanged {
+			if err := r.userRepo.UpdateProfile(ctx, r.profile); err != nil {
+				summary.Fail(i, err)
+				continue
+			}
+		}
+		if timezoneChanged {
+			if err := r.userRepo.UpdateTimezone(ctx, r.profile); err != nil {
+				summary.Fail(i, err)
+				continue
+			}
+		}
+		summary.Imported++
+	}
+	return nil
+}
+
+// importSymptoms импортирует записи дневника; показатели переносятся вместе с ними
+func (r *importRun) importSymptoms(ctx context.Context, c *archiveContents) error {
+	existing, err := allSymptoms(ctx, r.symptomRepo, r.profile.ID)
+	if err != nil {
+		return err
+	}
+	keys := make(map[string]uuid.UUID, len(existing))
+	for _, s := range existing {
+		keys[symptomKey(s.DateTime, s.Description)] = s.ID
+	}
+
+	summary := r.summary.Entity(export.EntitySymptoms)
+	batch := newImportBatch[*symptom.SymptomEntry](summary)
+	for i, record := range c.symptoms {
+		key := symptomKey(record.DateTime, record.Description)
+		if id, ok := keys[key]; ok {
+			r.ids[record.ID] = id
+			summary.Skipped++
+			continue
+		}
+		entry, err := symptomFromRecord(r.profile.ID, record)
+		if err != nil {
+			summary.Fail(i, err)
+			continue
+		}
+		keys[key] = entry.ID
+		r.ids[record.ID] = entry.ID
+		batch.add(i, record.ID, entry)
+	}
+	batch.save(ctx, r.ids, r.importRepo.ImportSymptoms)
+
+	// Показатели — производная таблица, они уже перенесены с записями дневника
+	if entity, ok := r.archive.entity(export.EntityVitals); ok {
+		r.summary.Entity(export.EntityVitals).Skipped = entity.Rows
+	}
+	return nil
+}
+
+// importAnalyses импортирует анализы и восстанавливает их файлы в хранилище
+func (r *importRun) importAnalyses(ctx context.Context, c *archiveContents) error {
+	existing, err := allAnalyses(ctx, r.analysisRepo, r.profile.ID)
+	if err != nil {
+		return err
+	}
+	keys := make(map[string]uuid.UUID, len(existing))
+	for _, a := range existing {
+		keys[analysisKey(string(a.Type), a.Name, a.DateTaken)] = a.ID
+	}
+
+	summary := r.summary.Entity(export.EntityAnalyses)
+	records := make(map[uuid.UUID]export.AnalysisRecord)
+	var fresh []*analysis.Analysis
+	rows := make(map[uuid.UUID]int)
+	for i, record := range c.analyses {
+		key := analysisKey(record.Type, record.Name, record.DateTaken)
+		if id, ok := keys[key]; ok {
+			r.ids[record.ID] = id
+			summary.Skipped++
+			continue
+		}
+		a := analysisFromRecord(r.profile.ID, record)
+		keys[key] = a.ID
+		r.ids[record.ID] = a.ID
+		records[a.ID] = record
+		rows[a.ID] = i
+		fresh = append(fresh, a)
+	}
+
+	batch := newImportBatch[*analysis.Analysis](summary)
+	for _, a := range orderByFollowUp(fresh, records, r.ids) {
+		record := records[a.ID]
+		if err := r.restoreFile(ctx, a, record); err != nil {
+			delete(r.ids, record.ID)
+			summary.Fail(rows[a.ID], err)
+			continue
+		}
+		batch.add(rows[a.ID], record.ID, a)
+	}
+
+	for _, a := range batch.save(ctx, r.ids, r.importRepo.ImportAnalyses) {
+		if a.FileURL != "" {
+			_ = r.files.Delete(ctx, a.FileURL)
+		}
+	}
+	return nil
+}
+
+// restoreFile копирует файл анализа из архива в хранилище и сверяет контрольную сумму.
+// Анализ, файл которого не попал в выгрузку, импортируется без файла.
+func (r *importRun) restoreFile(ctx context.Context, a *analysis.Analysis, record export.AnalysisRecord) error {
+	if record.File == "" {
+		return nil
+	}
+	entry, file, ok := r.archive.file(record.ID)
+	if !ok || entry.Path != record.File {
+		return filestorage.ErrFileNotFound
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	// Распаковка ограничена размером из манифеста (+1 байт, чтобы заметить превышение):
+	// заголовкам zip доверять нельзя
+	hash := sha256.New()
+	counter := &countingWriter{}
+	limited := io.LimitReader(src, entry.Size+1)
+	key := "analyses/" + r.profile.ID.String() + "/" + a.ID.String() + strings.ToLower(path.Ext(record.File))
+	fileURL, err := r.files.Save(ctx, key, io.TeeReader(limited, io.MultiWriter(hash, counter)))
+	if err != nil {
+		return err
+	}
+	if counter.n != entry.Size {
+		_ = r.files.Delete(ctx, fileURL)
+		return export.ErrFileSizeMismatch
+	}
+	if hex.EncodeToString(hash.Sum(nil)) != entry.SHA256 {
+		_ = r.files.Delete(ctx, fileURL)
+		return export.ErrFileChecksumMismatch
+	}
+
+	a.FileURL = fileURL
+	return nil
+}
+
+// importMedications импортирует лекарства
+func (r *importRun) importMedications(ctx context.Context, c *archiveContents) error {
+	existing, err := r.medicationRepo.FindByUserID(ctx, r.profile.ID, false)
+	if err != nil {
+		return err
+	}
+	keys := make(map[string]uuid.UUID, len(existing))
+	for _, m := range existing {
+		keys[medicationKey(m.Name, m.StartDate)] = m.ID
+	}
+
+	summary := r.summary.Entity(export.EntityMedications)
+	batch := newImportBatch[*medication.Medication](summary)
+	for i, record := range c.meds {
+		key := medicationKey(record.Name, record.StartDate)
+		if id, ok := keys[key]; ok {
+			r.ids[record.ID] = id
+			summary.Skipped++
+			continue
+		}
+		med, err := medicationFromRecord(r.profile.ID, record)
+		if err != nil {
+			summary.Fail(i, err)
+			continue
+		}
+		keys[key] = med.ID
+		r.ids[record.ID] = med.ID
+		batch.add(i, record.ID, med)
+	}
+	batch.save(ctx, r.ids, r.importRepo.ImportMedications)
+	return nil
+}
+
+// importIntakes импортирует приёмы перенесённых лекарств
+func (r *importRun) importIntakes(ctx context.Context, c *archiveContents) error {
+	existing, err := r.intakeRepo.FindByUserAndPeriod(ctx, r.profile.ID, time.Time{}, time.Now().Add(intakeHorizon))
+	if err != nil {
+		return err
+	}
+	keys := make(map[string]bool, len(existing))
+	for _, intake := range existing {
+		keys[intakeKey(intake.MedicationID, intake.ScheduledTime)] = true
+	}
+
+	summary := r.summary.Entity(export.EntityIntakes)
+	batch := newImportBatch[*medication.MedicationIntake](summary)
+	for i, record := range c.intakes {
+		medicationID, ok := r.ids[record.MedicationID]
+		if !ok {
+			summary.Fail(i, medication.ErrMedicationNotFound)
+			continue
+		}
+		key := intakeKey(medicationID, record.ScheduledTime)
+		if keys[key] {
+			summary.Skipped++
+			continue
+		}
+		intake, err := intakeFromRecord(medicationID, record)
+		if err != nil {
+			summary.Fail(i, err)
+			continue
+		}
+		keys[key] = true
+		batch.add(i, record.ID, intake)
+	}
+	batch.save(ctx, r.ids, r.importRepo.ImportIntakes)
+	return nil
+}
+
+// importCourses импортирует историю курсов
+func (r *importRun) importCourses(ctx context.Context, c *archiveContents) error {
+	existing, err := r.courseRepo.FindByUserID(ctx, r.profile.ID)
+	if err != nil {
+		return err
+	}
+	keys := make(map[string]bool, len(existing))
+	for _, course := range existing {
+		keys[courseKey(course.MedicationName, course.StartDate)] = true
+	}
+
+	summary := r.summary.Entity(export.EntityCourses)
+	batch := newImportBatch[*medication.Course](summary)
+	for i, record := range c.courses {
+		key := courseKey(record.MedicationName, record.StartDate)
+		if keys[key] {
+			summary.Skipped++
+			continue
+		}
+		course, err := courseFromRecord(r.profile.ID, record, r.ids)
+		if err != nil {
+			summary.Fail(i, err)
+			continue
+		}
+		keys[key] = true
+		batch.add(i, record.ID, course)
+	}
+	batch.save(ctx, r.ids, r.importRepo.ImportCourses)
+	return nil
+}
+
+// importDoctorVisits импортирует визиты вместе со снимками отчётов
+func (r *importRun) importDoctorVisits(ctx context.Context, c *archiveContents) error {
+	existing, err := allVisits(ctx, r.doctorVisitRepo, r.profile.ID)
+	if err != nil {
+		return err
+	}
+	keys := make(map[string]uuid.UUID, len(existing))
+	for _, v := range existing {
+		keys[visitKey(v.VisitDate, v.DoctorName, v.Specialty)] = v.ID
+	}
+
+	snapshots := make(map[uuid.UUID]export.ReportSnapshotRecord, len(c.snapshots))
+	for _, snapshot := range c.snapshots {
+		snapshots[snapshot.VisitID] = snapshot
+	}
+
+	summary := r.summary.Entity(export.EntityDoctorVisits)
+	batch := newImportBatch[*doctorvisit.DoctorVisit](summary)
+	added := make(map[uuid.UUID]*doctorvisit.DoctorVisit)
+	for i, record := range c.visits {
+		key := visitKey(record.VisitDate, record.DoctorName, record.Specialty)
+		if id, ok := keys[key]; ok {
+			r.ids[record.ID] = id
+			summary.Skipped++
+			continue
+		}
+		visit := doctorVisitFromRecord(r.profile.ID, record)
+		if snapshot, ok := snapshots[record.ID]; ok {
+			applyReportSnapshot(visit, snapshot, r.ids)
+		}
+		keys[key] = visit.ID
+		r.ids[record.ID] = visit.ID
+		added[record.ID] = visit
+		batch.add(i, record.ID, visit)
+	}
+	failed := make(map[uuid.UUID]bool)
+	for _, visit := range batch.save(ctx, r.ids, r.importRepo.ImportDoctorVisits) {
+		failed[visit.ID] = true
+	}
+
+	// Снимок отчёта хранится в визите и переносится вместе с ним;
+	// снимки визитов, которые уже есть у профиля, пропускаются
+	snapshotSummary := r.summary.Entity(export.EntityReportSnapshots)
+	for i, snapshot := range c.snapshots {
+		visit, ok := added[snapshot.VisitID]
+		switch {
+		case !ok:
+			snapshotSummary.Skipped++
+		case failed[visit.ID]:
+			snapshotSummary.Fail(i, doctorvisit.ErrVisitNotFound)
+		default:
+			snapshotSummary.Imported++
+		}
+	}
+	return nil
+}
+
+// importMilestones импортирует достигнутые вехи
+func (r *importRun) importMilestones(ctx context.Context, c *archiveContents) error {
+	existing, err := r.milestoneRepo.FindByUserID(ctx, r.profile.ID)
+	if err != nil {
+		return err
+	}
+	keys := make(map[string]bool, len(existing))
+	for _, m := range existing {
+		keys[milestoneKey(string(m.Kind), m.Days)] = true
+	}
+
+	summary := r.summary.Entity(export.EntityMilestones)
+	batch := newImportBatch[*engagement.Milestone](summary)
+	for i, record := range c.milestones {
+		key := milestoneKey(record.Kind, record.Days)
+		if keys[key] {
+			summary.Skipped++
+			continue
+		}
+		keys[key] = true
+		batch.add(i, record.ID, milestoneFromRecord(r.profile.ID, record))
+	}
+	batch.save(ctx, r.ids, r.importRepo.ImportMilestones)
+	return nil
+}
+
+// importBatch накапливает новые записи одной сущности для сохранения в одной транзакции
+type importBatch[T any] struct {
+	summary  *export.EntitySummary
+	entities []T
+	rows     []int
+	oldIDs   []uuid.UUID
+}
+
+// newImportBatch создаёт пустую партию
+func newImportBatch[T any](summary *export.EntitySummary) *importBatch[T] {
+	return &importBatch[T]{summary: summary}
+}
+
+// add добавляет сущность, созданную из строки row архива с ID oldID
+func (b *importBatch[T]) add(row int, oldID uuid.UUID, entity T) {
+	b.entities = append(b.entities, entity)
+	b.rows = append(b.rows, row)
+	b.oldIDs = append(b.oldIDs, oldID)
+}
+
+// save сохраняет партию, учитывает результат в итогах и убирает несохранённые
+// записи из ids, чтобы на них не ссылались другие сущности. Возвращает несохранённые сущности.
+func (b *importBatch[T]) save(ctx context.Context, ids idMap, store func(context.Context, []T) ([]error, error)) []T {
+	if len(b.entities) == 0 {
+		return nil
+	}
+
+	errs, err := store(ctx, b.entities)
+	var failed []T
+	for i, entity := range b.entities {
+		rowErr := err
+		if rowErr == nil {
+			rowErr = errs[i]
+		}
+		if rowErr != nil {
+			delete(ids, b.oldIDs[i])
+			b.summary.Fail(b.rows[i], rowErr)
+			failed = append(failed, entity)
+			continue
+		}
+		b.summary.Imported++
+	}
+	return failed
+}
+
+// orderByFollowUp упорядочивает новые анализы так, чтобы повторный анализ
+// сохранялся раньше анализа, который на него ссылается, и переводит ссылки
+func orderByFollowUp(fresh []*analysis.Analysis, records map[uuid.UUID]export.AnalysisRecord, ids idMap) []*analysis.Analysis {
+	byID := make(map[uuid.UUID]*analysis.Analysis, len(fresh))
+	for _, a := range fresh {
+		byID[a.ID] = a
+	}
+
+	ordered := make([]*analysis.Analysis, 0, len(fresh))
+	visited := make(map[uuid.UUID]bool, len(fresh))
+	emitted := make(map[uuid.UUID]bool, len(fresh))
+	var visit func(a *analysis.Analysis)
+	visit = func(a *analysis.Analysis) {
+		if visited[a.ID] {
+			return
+		}
+		visited[a.ID] = true
+
+		target := ids.ref(records[a.ID].FollowUpAnalysisID)
+		if target != nil {
+			if next, inBatch := byID[*target]; inBatch {
+				visit(next)
+				// Цикл ссылок: сохранить ссылку на ещё не записанный анализ нельзя
+				if !emitted[next.ID] {
+					target = nil
+				}
+			}
+		}
+		if target != nil && *target == a.ID {
+			target = nil
+		}
+		a.FollowUpAnalysisID = target
+		ordered = append(ordered, a)
+		emitted[a.ID] = true
+	}
+	for _, a := range fresh {
+		visit(a)
+	}
+	return ordered
+}
+
+// Ключи для поиска записей, которые уже есть у профиля
+
+func symptomKey(dateTime time.Time, description string) string {
+	return fmt.Sprintf("%d|%s", dateTime.UnixMicro(), strings.TrimSpace(description))
+}
+
+func analysisKey(analysisType, name string, dateTaken time.Time) string {
+	return analysisType + "|" + strings.ToLower(strings.TrimSpace(name)) + "|" + dateTaken.Format(dateKeyLayout)
+}
+
+func medicationKey(name string, startDate time.Time) string {
+	return strings.ToLower(strings.TrimSpace(name)) + "|" + startDate.Format(dateKeyLayout)
+}
+
+func intakeKey(medicationID uuid.UUID, scheduledTime time.Time) string {
+	return fmt.Sprintf("%s|%d", medicationID, scheduledTime.Truncate(time.Minute).Unix())
+}
+
+func courseKey(medicationName string, startDate time.Time) string {
+	return medicationKey(medicationName, startDate)
+}
+
+func visitKey(visitDate time.Time, doctorName, specialty *string) string {
+	key := visitDate.Format(dateKeyLayout)
+	for _, value := range []*string{doctorName, specialty} {
+		key += "|"
+		if value != nil {
+			key += strings.ToLower(strings.TrimSpace(*value))
+		}
+	}
+	return key
+}
+
+func milestoneKey(kind string, days int) string {
+	return fmt.Sprintf("%s|%d", kind, days)
+}
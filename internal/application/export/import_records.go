@@ -0,0 +1,213 @@
+package export
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/analysis"
+	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+	"github.com/health-hub-bot-api/internal/domain/engagement"
+	"github.com/health-hub-bot-api/internal/domain/export"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/symptom"
+)
+
+// Преобразование записей архива в доменные сущности профиля userID.
+// Каждая сущность получает новый ID; ссылки на другие записи архива
+// переводятся через idMap.
+
+// idMap сопоставляет ID записей архива с ID сущностей профиля
+type idMap map[uuid.UUID]uuid.UUID
+
+// ref возвращает ID сущности профиля для необязательной ссылки; nil, если запись не перенесена
+func (m idMap) ref(id *uuid.UUID) *uuid.UUID {
+	if id == nil {
+		return nil
+	}
+	mapped, ok := m[*id]
+	if !ok {
+		return nil
+	}
+	return &mapped
+}
+
+// refs переводит список ссылок, отбрасывая неперенесённые записи
+func (m idMap) refs(ids []uuid.UUID) []uuid.UUID {
+	result := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if mapped, ok := m[id]; ok {
+			result = append(result, mapped)
+		}
+	}
+	return result
+}
+
+// symptomFromRecord преобразует запись архива в запись дневника
+func symptomFromRecord(userID uuid.UUID, r export.SymptomRecord) (*symptom.SymptomEntry, error) {
+	if r.WellbeingScale < 1 || r.WellbeingScale > 10 {
+		return nil, symptom.ErrInvalidWellbeingScale
+	}
+	return &symptom.SymptomEntry{
+		ID:                     uuid.New(),
+		UserID:                 userID,
+		DateTime:               r.DateTime,
+		Description:            r.Description,
+		WellbeingScale:         r.WellbeingScale,
+		Temperature:            r.Temperature,
+		BloodPressureSystolic:  r.BloodPressureSystolic,
+		BloodPressureDiastolic: r.BloodPressureDiastolic,
+		Pulse:                  r.Pulse,
+		CreatedAt:              r.CreatedAt,
+		UpdatedAt:              time.Now(),
+	}, nil
+}
+
+// analysisFromRecord преобразует запись архива в анализ; файл восстанавливается отдельно
+func analysisFromRecord(userID uuid.UUID, r export.AnalysisRecord) *analysis.Analysis {
+	return &analysis.Analysis{
+		ID:               uuid.New(),
+		UserID:           userID,
+		Type:             analysis.Type(r.Type),
+		Name:             r.Name,
+		DateTaken:        r.DateTaken,
+		FileType:         analysis.FileType(r.FileType),
+		NextReminderDate: r.NextReminderDate,
+		CreatedAt:        r.CreatedAt,
+		UpdatedAt:        time.Now(),
+	}
+}
+
+// medicationFromRecord преобразует запись архива в лекарство
+func medicationFromRecord(userID uuid.UUID, r export.MedicationRecord) (*medication.Medication, error) {
+	med := &medication.Medication{
+		ID:             uuid.New(),
+		UserID:         userID,
+		Name:           r.Name,
+		Dosage:         r.Dosage,
+		ScheduleType:   medication.ScheduleType(r.ScheduleType),
+		StartDate:      r.StartDate,
+		EndDate:        r.EndDate,
+		IsActive:       r.IsActive,
+		MaxDosesPer24h: r.MaxDosesPer24h,
+		StockQuantity:  r.StockQuantity,
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      time.Now(),
+		ScheduleDetails: medication.ScheduleDetails{
+			Times:         r.Schedule.Times,
+			Days:          r.Schedule.Days,
+			IntervalHours: r.Schedule.IntervalHours,
+			EveryNDays:    r.Schedule.EveryNDays,
+		},
+	}
+	if r.DosageAmount != nil && r.DosageUnit != nil && r.DosageForm != nil {
+		med.DosageDetails = &medication.DosageDetails{
+			Amount: *r.DosageAmount,
+			Unit:   medication.DosageUnit(*r.DosageUnit),
+			Form:   medication.DosageForm(*r.DosageForm),
+		}
+	}
+	if r.MinDoseIntervalMinutes != nil {
+		interval := time.Duration(*r.MinDoseIntervalMinutes) * time.Minute
+		med.MinDoseInterval = &interval
+	}
+	if r.Schedule.CycleDaysOn > 0 || r.Schedule.CycleDaysOff > 0 {
+		med.ScheduleDetails.Cycle = &medication.ScheduleCycle{DaysOn: r.Schedule.CycleDaysOn, DaysOff: r.Schedule.CycleDaysOff}
+	}
+	for _, step := range r.Schedule.DoseSteps {
+		med.ScheduleDetails.DoseSteps = append(med.ScheduleDetails.DoseSteps, medication.DoseStep{Days: step.Days, Dose: step.Dose})
+	}
+
+	if err := med.Validate(); err != nil {
+		return nil, err
+	}
+	return med, nil
+}
+
+// intakeFromRecord преобразует запись архива в приём лекарства medicationID
+func intakeFromRecord(medicationID uuid.UUID, r export.IntakeRecord) (*medication.MedicationIntake, error) {
+	status := medication.IntakeStatus(r.Status)
+	if !status.IsValid() {
+		return nil, medication.ErrInvalidIntakeStatus
+	}
+	return &medication.MedicationIntake{
+		ID:            uuid.New(),
+		MedicationID:  medicationID,
+		ScheduledTime: r.ScheduledTime,
+		Status:        status,
+		PlannedDose:   r.PlannedDose,
+		TakenAt:       r.TakenAt,
+		MissedAt:      r.MissedAt,
+		SkipReason:    r.SkipReason,
+		ActualDose:    r.ActualDose,
+		IsAsNeeded:    r.IsAsNeeded,
+		Reason:        r.Reason,
+		Notes:         r.Notes,
+		CreatedAt:     r.CreatedAt,
+	}, nil
+}
+
+// courseFromRecord преобразует запись архива в завершённый курс.
+// История курсов переживает удаление лекарства, поэтому ссылка на лекарство,
+// которого нет в архиве, получает новый ID и сохраняется в idMap.
+func courseFromRecord(userID uuid.UUID, r export.CourseRecord, ids idMap) (*medication.Course, error) {
+	if r.Reason == nil || !medication.CourseEndReason(*r.Reason).IsValid() || r.EndDate == nil {
+		return nil, medication.ErrInvalidCourseEnd
+	}
+	reason := medication.CourseEndReason(*r.Reason)
+
+	medicationID, ok := ids[r.MedicationID]
+	if !ok {
+		medicationID = uuid.New()
+		ids[r.MedicationID] = medicationID
+	}
+
+	return &medication.Course{
+		ID:             uuid.New(),
+		UserID:         userID,
+		MedicationID:   medicationID,
+		MedicationName: r.MedicationName,
+		Dosage:         r.Dosage,
+		StartDate:      r.StartDate,
+		EndDate:        r.EndDate,
+		Reason:         &reason,
+		ReplacedByID:   ids.ref(r.ReplacedByID),
+		CreatedAt:      r.CreatedAt,
+	}, nil
+}
+
+// doctorVisitFromRecord преобразует запись архива в визит
+func doctorVisitFromRecord(userID uuid.UUID, r export.DoctorVisitRecord) *doctorvisit.DoctorVisit {
+	return &doctorvisit.DoctorVisit{
+		ID:         uuid.New(),
+		UserID:     userID,
+		VisitDate:  r.VisitDate,
+		DoctorName: r.DoctorName,
+		Specialty:  r.Specialty,
+		Questions:  r.Questions,
+		CreatedAt:  r.CreatedAt,
+		UpdatedAt:  time.Now(),
+	}
+}
+
+// applyReportSnapshot восстанавливает снимок отчёта в визите со ссылками на перенесённые записи
+func applyReportSnapshot(visit *doctorvisit.DoctorVisit, r export.ReportSnapshotRecord, ids idMap) {
+	generatedAt := r.GeneratedAt
+	visit.ReportGeneratedAt = &generatedAt
+	visit.ReportData = &doctorvisit.ReportData{
+		Period:        doctorvisit.DateRange{StartDate: r.PeriodStart, EndDate: r.PeriodEnd},
+		SymptomIDs:    ids.refs(r.SymptomIDs),
+		AnalysisIDs:   ids.refs(r.AnalysisIDs),
+		MedicationIDs: ids.refs(r.MedicationIDs),
+	}
+}
+
+// milestoneFromRecord преобразует запись архива в веху
+func milestoneFromRecord(userID uuid.UUID, r export.MilestoneRecord) *engagement.Milestone {
+	return &engagement.Milestone{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Kind:       engagement.StreakKind(r.Kind),
+		Days:       r.Days,
+		AchievedAt: r.AchievedAt,
+	}
+}
@@ -0,0 +1,173 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/export"
+	"github.com/health-hub-bot-api/internal/domain/filestorage"
+	"github.com/health-hub-bot-api/internal/domain/notification"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+const (
+	// batchSize — сколько выгрузок собирается за один запуск задачи
+	batchSize = 5
+	// staleTimeout — через сколько сборка, не дошедшая до конца, считается прерванной
+	// (процесс остановился или упал): такая выгрузка завершается ошибкой, чтобы
+	// пользователь мог запросить новую
+	staleTimeout = time.Hour
+)
+
+// failureReason — причина ошибки, которую видит пользователь; подробности пишутся в лог
+const failureReason = "не удалось собрать архив, попробуйте запросить выгрузку ещё раз"
+
+// DownloadLinks выдаёт временные ссылки на скачивание готовых архивов
+type DownloadLinks interface {
+	ExportURL(jobID uuid.UUID, expiresAt time.Time) string
+}
+
+// Processor собирает архивы выгрузок из очереди, сообщает о готовности
+// в чат и удаляет архивы, срок скачивания которых истёк
+type Processor struct {
+	jobRepo  export.JobRepository
+	userRepo user.Repository
+	builder  *ArchiveBuilder
+	files    filestorage.Storage
+	links    DownloadLinks
+	notifier notification.Notifier
+	linkTTL  time.Duration
+}
+
+// NewProcessor создаёт новую задачу сборки выгрузок
+func NewProcessor(
+	jobRepo export.JobRepository,
+	userRepo user.Repository,
+	builder *ArchiveBuilder,
+	files filestorage.Storage,
+	links DownloadLinks,
+	notifier notification.Notifier,
+	linkTTL time.Duration,
+) *Processor {
+	return &Processor{
+		jobRepo:  jobRepo,
+		userRepo: userRepo,
+		builder:  builder,
+		files:    files,
+		links:    links,
+		notifier: notifier,
+		linkTTL:  linkTTL,
+	}
+}
+
+// Name возвращает имя задачи для планировщика
+func (p *Processor) Name() string {
+	return "data-export"
+}
+
+// Run удаляет просроченные архивы, закрывает прерванные сборки и собирает
+// очередную партию выгрузок
+func (p *Processor) Run(ctx context.Context) error {
+	if err := p.expire(ctx); err != nil {
+		return err
+	}
+	if err := p.failStale(ctx); err != nil {
+		return err
+	}
+
+	jobs, err := p.jobRepo.FindPending(ctx, batchSize)
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		if err := p.process(ctx, job); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// expire удаляет архивы, которые больше нельзя скачать
+func (p *Processor) expire(ctx context.Context) error {
+	jobs, err := p.jobRepo.FindExpired(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		if job.ArchiveURL != nil {
+			if err := p.files.Delete(ctx, *job.ArchiveURL); err != nil {
+				return err
+			}
+		}
+		job.Expire()
+		if err := p.jobRepo.Update(ctx, job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// failStale завершает ошибкой выгрузки, сборка которых была прервана
+func (p *Processor) failStale(ctx context.Context) error {
+	jobs, err := p.jobRepo.FindStale(ctx, time.Now().Add(-staleTimeout))
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		log.Printf("data export %s: build was interrupted", job.ID)
+		job.Fail(failureReason)
+		if err := p.jobRepo.Update(ctx, job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// process собирает архив одной выгрузки и уведомляет владельца профиля
+func (p *Processor) process(ctx context.Context, job *export.Job) error {
+	job.Start()
+	if err := p.jobRepo.Update(ctx, job); err != nil {
+		return err
+	}
+
+	archiveURL, size, err := p.builder.Build(ctx, job.UserID, "exports/"+job.ID.String()+".zip")
+	if err != nil {
+		log.Printf("data export %s failed: %v", job.ID, err)
+		job.Fail(failureReason)
+		return p.jobRepo.Update(ctx, job)
+	}
+
+	job.Complete(archiveURL, size, time.Now().Add(p.linkTTL))
+	if err := p.jobRepo.Update(ctx, job); err != nil {
+		return err
+	}
+
+	// Ошибка доставки сообщения не отменяет готовую выгрузку: ссылка доступна в API
+	if err := p.notify(ctx, job); err != nil {
+		log.Printf("data export %s: failed to notify: %v", job.ID, err)
+	}
+	return nil
+}
+
+// notify отправляет ссылку на готовый архив в чат
+func (p *Processor) notify(ctx context.Context, job *export.Job) error {
+	u, err := p.userRepo.GetByID(ctx, job.UserID)
+	if err != nil || u == nil {
+		return err
+	}
+	recipient, err := user.Recipient(ctx, p.userRepo, u)
+	if err != nil || recipient == nil {
+		return err
+	}
+
+	text := fmt.Sprintf(
+		"📦 Архив с вашими данными готов.\nСкачать: %s\nСсылка действует до %s.",
+		p.links.ExportURL(job.ID, *job.ExpiresAt),
+		job.ExpiresAt.In(recipient.Location()).Format("02.01.2006 15:04"),
+	)
+	return p.notifier.Send(ctx, recipient.TelegramUserID, notification.Message{Text: u.LabelMessage(text)})
+}
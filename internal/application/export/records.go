@@ -0,0 +1,202 @@
+package export
+
+import (
+	"time"
+
+	"github.com/health-hub-bot-api/internal/domain/analysis"
+	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+	"github.com/health-hub-bot-api/internal/domain/engagement"
+	"github.com/health-hub-bot-api/internal/domain/export"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/symptom"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+// profileRecord преобразует профиль в запись архива
+func profileRecord(u *user.User) export.ProfileRecord {
+	prefs := u.NotificationPreferences
+	record := export.ProfileRecord{
+		ID:              u.ID,
+		Name:            u.Name,
+		Age:             u.Age,
+		Timezone:        u.Timezone,
+		CheckInTimes:    prefs.CheckInTimes,
+		Channels:        make([]string, len(prefs.Channels)),
+		QuietHoursStart: prefs.QuietHoursStart,
+		QuietHoursEnd:   prefs.QuietHoursEnd,
+		CheckInDays:     prefs.Days,
+		CreatedAt:       u.CreatedAt,
+	}
+	if u.Gender != nil {
+		gender := string(*u.Gender)
+		record.Gender = &gender
+	}
+	for i, channel := range prefs.Channels {
+		record.Channels[i] = string(channel)
+	}
+	return record
+}
+
+// symptomRecord преобразует запись дневника в запись архива
+func symptomRecord(s *symptom.SymptomEntry) export.SymptomRecord {
+	return export.SymptomRecord{
+		ID:                     s.ID,
+		DateTime:               s.DateTime,
+		Description:            s.Description,
+		WellbeingScale:         s.WellbeingScale,
+		Temperature:            s.Temperature,
+		BloodPressureSystolic:  s.BloodPressureSystolic,
+		BloodPressureDiastolic: s.BloodPressureDiastolic,
+		Pulse:                  s.Pulse,
+		PhotoURL:               s.PhotoURL,
+		CreatedAt:              s.CreatedAt,
+	}
+}
+
+// vitalRecord возвращает показатели из записи дневника; false, если показателей нет
+func vitalRecord(s *symptom.SymptomEntry) (export.VitalRecord, bool) {
+	if s.Temperature == nil && s.BloodPressureSystolic == nil && s.BloodPressureDiastolic == nil && s.Pulse == nil {
+		return export.VitalRecord{}, false
+	}
+	return export.VitalRecord{
+		SymptomID:              s.ID,
+		DateTime:               s.DateTime,
+		Temperature:            s.Temperature,
+		BloodPressureSystolic:  s.BloodPressureSystolic,
+		BloodPressureDiastolic: s.BloodPressureDiastolic,
+		Pulse:                  s.Pulse,
+	}, true
+}
+
+// analysisRecord преобразует анализ в запись архива; file — путь файла в архиве
+func analysisRecord(a *analysis.Analysis, file string) export.AnalysisRecord {
+	return export.AnalysisRecord{
+		ID:                 a.ID,
+		Type:               string(a.Type),
+		Name:               a.Name,
+		DateTaken:          a.DateTaken,
+		FileType:           string(a.FileType),
+		File:               file,
+		NextReminderDate:   a.NextReminderDate,
+		FollowUpAnalysisID: a.FollowUpAnalysisID,
+		CreatedAt:          a.CreatedAt,
+	}
+}
+
+// medicationRecord преобразует лекарство в запись архива
+func medicationRecord(m *medication.Medication) export.MedicationRecord {
+	details := m.ScheduleDetails
+	record := export.MedicationRecord{
+		ID:             m.ID,
+		Name:           m.Name,
+		Dosage:         m.Dosage,
+		ScheduleType:   string(m.ScheduleType),
+		StartDate:      m.StartDate,
+		EndDate:        m.EndDate,
+		IsActive:       m.IsActive,
+		MaxDosesPer24h: m.MaxDosesPer24h,
+		StockQuantity:  m.StockQuantity,
+		CreatedAt:      m.CreatedAt,
+		Schedule: export.ScheduleRecord{
+			Times:         details.Times,
+			Days:          details.Days,
+			IntervalHours: details.IntervalHours,
+			EveryNDays:    details.EveryNDays,
+		},
+	}
+	if m.DosageDetails != nil {
+		unit := string(m.DosageDetails.Unit)
+		form := string(m.DosageDetails.Form)
+		record.DosageAmount = &m.DosageDetails.Amount
+		record.DosageUnit = &unit
+		record.DosageForm = &form
+	}
+	if m.MinDoseInterval != nil {
+		minutes := int(*m.MinDoseInterval / time.Minute)
+		record.MinDoseIntervalMinutes = &minutes
+	}
+	if details.Cycle != nil {
+		record.Schedule.CycleDaysOn = details.Cycle.DaysOn
+		record.Schedule.CycleDaysOff = details.Cycle.DaysOff
+	}
+	for _, step := range details.DoseSteps {
+		record.Schedule.DoseSteps = append(record.Schedule.DoseSteps, export.DoseStepRecord{Days: step.Days, Dose: step.Dose})
+	}
+	return record
+}
+
+// intakeRecord преобразует приём лекарства в запись архива
+func intakeRecord(i *medication.MedicationIntake) export.IntakeRecord {
+	return export.IntakeRecord{
+		ID:            i.ID,
+		MedicationID:  i.MedicationID,
+		ScheduledTime: i.ScheduledTime,
+		Status:        string(i.Status),
+		PlannedDose:   i.PlannedDose,
+		TakenAt:       i.TakenAt,
+		MissedAt:      i.MissedAt,
+		SkipReason:    i.SkipReason,
+		ActualDose:    i.ActualDose,
+		IsAsNeeded:    i.IsAsNeeded,
+		Reason:        i.Reason,
+		Notes:         i.Notes,
+		CreatedAt:     i.CreatedAt,
+	}
+}
+
+// courseRecord преобразует завершённый курс в запись архива
+func courseRecord(c *medication.Course) export.CourseRecord {
+	record := export.CourseRecord{
+		ID:             c.ID,
+		MedicationID:   c.MedicationID,
+		MedicationName: c.MedicationName,
+		Dosage:         c.Dosage,
+		StartDate:      c.StartDate,
+		EndDate:        c.EndDate,
+		ReplacedByID:   c.ReplacedByID,
+		CreatedAt:      c.CreatedAt,
+	}
+	if c.Reason != nil {
+		reason := string(*c.Reason)
+		record.Reason = &reason
+	}
+	return record
+}
+
+// doctorVisitRecord преобразует визит в запись архива
+func doctorVisitRecord(v *doctorvisit.DoctorVisit) export.DoctorVisitRecord {
+	return export.DoctorVisitRecord{
+		ID:         v.ID,
+		VisitDate:  v.VisitDate,
+		DoctorName: v.DoctorName,
+		Specialty:  v.Specialty,
+		Questions:  v.Questions,
+		CreatedAt:  v.CreatedAt,
+	}
+}
+
+// reportSnapshotRecord возвращает последний отчёт к визиту; false, если отчёт не формировался
+func reportSnapshotRecord(v *doctorvisit.DoctorVisit) (export.ReportSnapshotRecord, bool) {
+	if v.ReportData == nil || v.ReportGeneratedAt == nil {
+		return export.ReportSnapshotRecord{}, false
+	}
+	return export.ReportSnapshotRecord{
+		VisitID:       v.ID,
+		GeneratedAt:   *v.ReportGeneratedAt,
+		PeriodStart:   v.ReportData.Period.StartDate,
+		PeriodEnd:     v.ReportData.Period.EndDate,
+		SymptomIDs:    v.ReportData.SymptomIDs,
+		AnalysisIDs:   v.ReportData.AnalysisIDs,
+		MedicationIDs: v.ReportData.MedicationIDs,
+	}, true
+}
+
+// milestoneRecord преобразует веху в запись архива
+func milestoneRecord(m *engagement.Milestone) export.MilestoneRecord {
+	return export.MilestoneRecord{
+		ID:         m.ID,
+		Kind:       string(m.Kind),
+		Days:       m.Days,
+		AchievedAt: m.AchievedAt,
+	}
+}
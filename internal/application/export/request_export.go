@@ -0,0 +1,41 @@
+package export
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/export"
+)
+
+// RequestExportUseCase представляет use case для запроса выгрузки данных профиля
+type RequestExportUseCase struct {
+	jobRepo export.JobRepository
+}
+
+// NewRequestExportUseCase создаёт новый use case
+func NewRequestExportUseCase(jobRepo export.JobRepository) *RequestExportUseCase {
+	return &RequestExportUseCase{
+		jobRepo: jobRepo,
+	}
+}
+
+// Execute ставит выгрузку в очередь; архив собирает фоновая задача Processor.
+// Одновременно у профиля может быть только одна незавершённая выгрузка.
+func (uc *RequestExportUseCase) Execute(ctx context.Context, userID uuid.UUID) (*export.Job, error) {
+	jobs, err := uc.jobRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		if job.IsInProgress() {
+			return nil, export.ErrExportInProgress
+		}
+	}
+
+	job := export.NewJob(userID)
+	if err := uc.jobRepo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
@@ -0,0 +1,72 @@
+package medication
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/interaction"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+)
+
+// CheckInteractionsUseCase представляет use case для проверки сочетаний лекарств
+type CheckInteractionsUseCase struct {
+	medicationRepo medication.Repository
+	checker        *interaction.Checker
+}
+
+// NewCheckInteractionsUseCase создаёт новый use case
+func NewCheckInteractionsUseCase(
+	medicationRepo medication.Repository,
+	source interaction.Source,
+) *CheckInteractionsUseCase {
+	return &CheckInteractionsUseCase{
+		medicationRepo: medicationRepo,
+		checker:        interaction.NewChecker(source),
+	}
+}
+
+// Execute сопоставляет лекарство с остальными активными лекарствами пользователя.
+// visible ограничивает лекарства, которые попадают в предупреждения (например,
+// областью доступа к данным); nil — все активные лекарства.
+// Предупреждения носят информационный характер и не мешают сохранению лекарства.
+func (uc *CheckInteractionsUseCase) Execute(ctx context.Context, med *medication.Medication, visible func(*medication.Medication) bool) ([]interaction.Warning, error) {
+	if !med.IsActive {
+		return []interaction.Warning{}, nil
+	}
+
+	active, err := uc.medicationRepo.FindByUserID(ctx, med.UserID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.checker.Check(interaction.Medication{ID: med.ID, Name: med.Name}, interactionMedications(active, visible)), nil
+}
+
+// ExecuteForUser проверяет все активные лекарства пользователя одним запросом
+// к репозиторию и возвращает предупреждения по ID лекарства. visible ограничивает
+// проверяемые лекарства так же, как в Execute
+func (uc *CheckInteractionsUseCase) ExecuteForUser(ctx context.Context, userID uuid.UUID, visible func(*medication.Medication) bool) (map[uuid.UUID][]interaction.Warning, error) {
+	active, err := uc.medicationRepo.FindByUserID(ctx, userID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	others := interactionMedications(active, visible)
+	warnings := make(map[uuid.UUID][]interaction.Warning, len(active))
+	for _, target := range others {
+		warnings[target.ID] = uc.checker.Check(target, others)
+	}
+	return warnings, nil
+}
+
+// interactionMedications преобразует видимые лекарства для проверки сочетаний
+func interactionMedications(medications []*medication.Medication, visible func(*medication.Medication) bool) []interaction.Medication {
+	result := make([]interaction.Medication, 0, len(medications))
+	for _, m := range medications {
+		if visible != nil && !visible(m) {
+			continue
+		}
+		result = append(result, interaction.Medication{ID: m.ID, Name: m.Name})
+	}
+	return result
+}
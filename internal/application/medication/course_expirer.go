@@ -0,0 +1,73 @@
+package medication
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+// CourseExpirer деактивирует лекарства, срок приёма которых истёк, и сохраняет
+// их курсы в историю как завершённые
+type CourseExpirer struct {
+	medicationRepo medication.Repository
+	userRepo       user.Repository
+	endCourse      *EndCourseUseCase
+}
+
+// NewCourseExpirer создаёт новую задачу завершения курсов
+func NewCourseExpirer(
+	medicationRepo medication.Repository,
+	userRepo user.Repository,
+	endCourse *EndCourseUseCase,
+) *CourseExpirer {
+	return &CourseExpirer{
+		medicationRepo: medicationRepo,
+		userRepo:       userRepo,
+		endCourse:      endCourse,
+	}
+}
+
+// Name возвращает имя задачи для планировщика
+func (e *CourseExpirer) Name() string {
+	return "course-expirer"
+}
+
+// Run завершает курсы, день окончания которых прошёл в часовом поясе пользователя
+func (e *CourseExpirer) Run(ctx context.Context) error {
+	meds, err := e.medicationRepo.FindActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	users := make(map[uuid.UUID]*user.User)
+	for _, med := range meds {
+		if med.EndDate == nil {
+			continue
+		}
+
+		u, ok := users[med.UserID]
+		if !ok {
+			u, err = e.userRepo.GetByID(ctx, med.UserID)
+			if err != nil {
+				return err
+			}
+			users[med.UserID] = u
+		}
+		if u == nil || u.DeletedAt != nil {
+			continue
+		}
+
+		if !med.IsExpired(now, u.Location()) {
+			continue
+		}
+		if err := e.endCourse.End(ctx, med, medication.CourseEndCompleted, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
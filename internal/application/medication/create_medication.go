@@ -0,0 +1,60 @@
+package medication
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+)
+
+// CreateMedicationUseCase представляет use case для добавления лекарства
+type CreateMedicationUseCase struct {
+	medicationRepo medication.Repository
+}
+
+// NewCreateMedicationUseCase создаёт новый use case
+func NewCreateMedicationUseCase(medicationRepo medication.Repository) *CreateMedicationUseCase {
+	return &CreateMedicationUseCase{
+		medicationRepo: medicationRepo,
+	}
+}
+
+// CreateMedicationInput представляет входные данные для добавления лекарства
+type CreateMedicationInput struct {
+	UserID          uuid.UUID
+	Name            string
+	Dosage          string
+	DosageDetails   *medication.DosageDetails
+	StockQuantity   *float64
+	ScheduleType    medication.ScheduleType
+	ScheduleDetails medication.ScheduleDetails
+	StartDate       time.Time
+	EndDate         *time.Time
+}
+
+// Execute проверяет расписание и сохраняет лекарство.
+// Приёмы по расписанию создаёт фоновый планировщик.
+func (uc *CreateMedicationUseCase) Execute(ctx context.Context, input CreateMedicationInput) (*medication.Medication, error) {
+	med := medication.NewMedication(
+		input.UserID,
+		input.Name,
+		input.Dosage,
+		input.ScheduleType,
+		input.ScheduleDetails,
+		input.StartDate,
+	)
+	med.EndDate = input.EndDate
+	med.DosageDetails = input.DosageDetails
+	med.StockQuantity = input.StockQuantity
+
+	if err := med.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.medicationRepo.Create(ctx, med); err != nil {
+		return nil, err
+	}
+
+	return med, nil
+}
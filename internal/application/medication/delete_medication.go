@@ -0,0 +1,63 @@
+package medication
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/reminder"
+)
+
+// DeleteMedicationUseCase представляет use case для удаления лекарства.
+// Приёмы удаляются каскадно, завершённые курсы остаются в истории.
+type DeleteMedicationUseCase struct {
+	medicationRepo medication.Repository
+	intakeRepo     medication.IntakeRepository
+	reminderRepo   reminder.Repository
+}
+
+// NewDeleteMedicationUseCase создаёт новый use case
+func NewDeleteMedicationUseCase(
+	medicationRepo medication.Repository,
+	intakeRepo medication.IntakeRepository,
+	reminderRepo reminder.Repository,
+) *DeleteMedicationUseCase {
+	return &DeleteMedicationUseCase{
+		medicationRepo: medicationRepo,
+		intakeRepo:     intakeRepo,
+		reminderRepo:   reminderRepo,
+	}
+}
+
+// Execute удаляет лекарство пользователя и неотправленные напоминания о нём
+func (uc *DeleteMedicationUseCase) Execute(ctx context.Context, userID, medicationID uuid.UUID) error {
+	med, err := uc.medicationRepo.GetByID(ctx, medicationID)
+	if err != nil {
+		return err
+	}
+	if med == nil {
+		return medication.ErrMedicationNotFound
+	}
+	if med.UserID != userID {
+		return medication.ErrUnauthorized
+	}
+
+	// Напоминания ссылаются на приёмы без внешнего ключа: отложенные и повторные
+	// напоминания бывают только у недавних и ближайших приёмов
+	now := time.Now()
+	intakes, err := uc.intakeRepo.FindByMedicationAndPeriod(ctx, med.ID, now.AddDate(0, 0, -1), now.AddDate(0, 0, 7))
+	if err != nil {
+		return err
+	}
+	for _, intake := range intakes {
+		if err := uc.reminderRepo.DeletePendingByRelated(ctx, reminder.TypeMedication, intake.ID); err != nil {
+			return err
+		}
+	}
+	if err := uc.reminderRepo.DeletePendingByRelated(ctx, reminder.TypeRefill, med.ID); err != nil {
+		return err
+	}
+
+	return uc.medicationRepo.Delete(ctx, med.ID)
+}
@@ -0,0 +1,155 @@
+package medication
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/reminder"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+// EndCourseUseCase представляет use case для завершения курса лекарства:
+// деактивирует лекарство, отменяет будущие приёмы и напоминания и сохраняет курс в историю
+type EndCourseUseCase struct {
+	medicationRepo medication.Repository
+	intakeRepo     medication.IntakeRepository
+	reminderRepo   reminder.Repository
+	courseRepo     medication.CourseRepository
+	userRepo       user.Repository
+}
+
+// NewEndCourseUseCase создаёт новый use case
+func NewEndCourseUseCase(
+	medicationRepo medication.Repository,
+	intakeRepo medication.IntakeRepository,
+	reminderRepo reminder.Repository,
+	courseRepo medication.CourseRepository,
+	userRepo user.Repository,
+) *EndCourseUseCase {
+	return &EndCourseUseCase{
+		medicationRepo: medicationRepo,
+		intakeRepo:     intakeRepo,
+		reminderRepo:   reminderRepo,
+		courseRepo:     courseRepo,
+		userRepo:       userRepo,
+	}
+}
+
+// EndCourseInput представляет входные данные для завершения курса пользователем
+type EndCourseInput struct {
+	UserID       uuid.UUID
+	MedicationID uuid.UUID
+	Reason       medication.CourseEndReason
+	ReplacedByID *uuid.UUID // лекарство, которым заменён курс (только для replaced)
+}
+
+// Execute завершает курс лекарства пользователя
+func (uc *EndCourseUseCase) Execute(ctx context.Context, input EndCourseInput) (*medication.Medication, error) {
+	if !input.Reason.IsValid() || (input.Reason == medication.CourseEndReplaced) != (input.ReplacedByID != nil) {
+		return nil, medication.ErrInvalidCourseEnd
+	}
+
+	med, err := uc.getOwnedMedication(ctx, input.UserID, input.MedicationID)
+	if err != nil {
+		return nil, err
+	}
+	if !med.IsActive {
+		return nil, medication.ErrMedicationInactive
+	}
+
+	if input.ReplacedByID != nil {
+		if *input.ReplacedByID == med.ID {
+			return nil, medication.ErrInvalidCourseEnd
+		}
+		if _, err := uc.getOwnedMedication(ctx, input.UserID, *input.ReplacedByID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.End(ctx, med, input.Reason, input.ReplacedByID); err != nil {
+		return nil, err
+	}
+	return med, nil
+}
+
+// End деактивирует лекарство и завершает его курс. Курс, завершённый по дате,
+// заканчивается датой окончания лекарства, остальные — сегодняшним днём пользователя.
+func (uc *EndCourseUseCase) End(ctx context.Context, med *medication.Medication, reason medication.CourseEndReason, replacedByID *uuid.UUID) error {
+	now := time.Now()
+	endDate, err := uc.today(ctx, med.UserID, now)
+	if err != nil {
+		return err
+	}
+	if reason == medication.CourseEndCompleted && med.EndDate != nil {
+		endDate = *med.EndDate
+	}
+	if med.EndDate == nil || med.EndDate.After(endDate) {
+		med.EndDate = &endDate
+	}
+
+	// Будущие приёмы и напоминания отменяются до деактивации: при ошибке лекарство
+	// остаётся активным и завершение можно повторить
+	if err := uc.cancelFutureIntakes(ctx, med.ID, now); err != nil {
+		return err
+	}
+	if err := uc.reminderRepo.DeletePendingByRelated(ctx, reminder.TypeRefill, med.ID); err != nil {
+		return err
+	}
+
+	med.Deactivate()
+	return uc.courseRepo.End(ctx, med, medication.NewEndedCourse(med, endDate, reason, replacedByID))
+}
+
+// cancelFutureIntakes удаляет запланированные будущие приёмы и напоминания о них
+func (uc *EndCourseUseCase) cancelFutureIntakes(ctx context.Context, medicationID uuid.UUID, now time.Time) error {
+	// Планировщик создаёт приёмы не дальше чем на завтра, неделя покрывает запас по часовым поясам
+	intakes, err := uc.intakeRepo.FindByMedicationAndPeriod(ctx, medicationID, now, now.AddDate(0, 0, 7))
+	if err != nil {
+		return err
+	}
+
+	for _, intake := range intakes {
+		if !intake.IsPlanned() {
+			continue
+		}
+		if err := uc.reminderRepo.DeletePendingByRelated(ctx, reminder.TypeMedication, intake.ID); err != nil {
+			return err
+		}
+		if err := uc.intakeRepo.Delete(ctx, intake.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// today возвращает сегодняшнюю дату пользователя в виде значения колонки DATE (полночь UTC)
+func (uc *EndCourseUseCase) today(ctx context.Context, userID uuid.UUID, now time.Time) (time.Time, error) {
+	loc := time.UTC
+	u, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if u != nil {
+		loc = u.Location()
+	}
+	local := now.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// getOwnedMedication загружает лекарство и проверяет, что оно принадлежит пользователю
+func (uc *EndCourseUseCase) getOwnedMedication(ctx context.Context, userID, medicationID uuid.UUID) (*medication.Medication, error) {
+	med, err := uc.medicationRepo.GetByID(ctx, medicationID)
+	if err != nil {
+		return nil, err
+	}
+	if med == nil {
+		return nil, medication.ErrMedicationNotFound
+	}
+	if med.UserID != userID {
+		return nil, medication.ErrUnauthorized
+	}
+	return med, nil
+}
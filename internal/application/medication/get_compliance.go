@@ -0,0 +1,63 @@
+package medication
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+)
+
+// defaultCompliancePeriod — период статистики, если начало не задано
+const defaultCompliancePeriod = 30 * 24 * time.Hour
+
+// GetComplianceUseCase представляет use case для статистики соблюдения режима приёма
+type GetComplianceUseCase struct {
+	medicationRepo medication.Repository
+	intakeRepo     medication.IntakeRepository
+}
+
+// NewGetComplianceUseCase создаёт новый use case
+func NewGetComplianceUseCase(
+	medicationRepo medication.Repository,
+	intakeRepo medication.IntakeRepository,
+) *GetComplianceUseCase {
+	return &GetComplianceUseCase{
+		medicationRepo: medicationRepo,
+		intakeRepo:     intakeRepo,
+	}
+}
+
+// GetComplianceInput представляет входные данные
+type GetComplianceInput struct {
+	UserID       uuid.UUID
+	MedicationID uuid.UUID
+	StartDate    *time.Time
+	EndDate      *time.Time
+}
+
+// Execute возвращает количество приёмов по статусам за период.
+// По умолчанию период — последние 30 дней до текущего момента.
+func (uc *GetComplianceUseCase) Execute(ctx context.Context, input GetComplianceInput) (*medication.ComplianceStats, error) {
+	med, err := uc.medicationRepo.GetByID(ctx, input.MedicationID)
+	if err != nil {
+		return nil, err
+	}
+	if med == nil {
+		return nil, medication.ErrMedicationNotFound
+	}
+	if med.UserID != input.UserID {
+		return nil, medication.ErrUnauthorized
+	}
+
+	endDate := time.Now()
+	if input.EndDate != nil {
+		endDate = *input.EndDate
+	}
+	startDate := endDate.Add(-defaultCompliancePeriod)
+	if input.StartDate != nil {
+		startDate = *input.StartDate
+	}
+
+	return uc.intakeRepo.GetComplianceStats(ctx, med.ID, startDate, endDate)
+}
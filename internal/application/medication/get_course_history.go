@@ -0,0 +1,60 @@
+package medication
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+)
+
+// GetCourseHistoryUseCase представляет use case для истории курсов лекарств
+type GetCourseHistoryUseCase struct {
+	medicationRepo medication.Repository
+	courseRepo     medication.CourseRepository
+}
+
+// NewGetCourseHistoryUseCase создаёт новый use case
+func NewGetCourseHistoryUseCase(
+	medicationRepo medication.Repository,
+	courseRepo medication.CourseRepository,
+) *GetCourseHistoryUseCase {
+	return &GetCourseHistoryUseCase{
+		medicationRepo: medicationRepo,
+		courseRepo:     courseRepo,
+	}
+}
+
+// GetCourseHistoryInput представляет входные данные; Name ограничивает историю одним лекарством
+type GetCourseHistoryInput struct {
+	UserID uuid.UUID
+	Name   *string
+}
+
+// Execute возвращает завершённые и текущие курсы, сгруппированные по названию лекарства
+func (uc *GetCourseHistoryUseCase) Execute(ctx context.Context, input GetCourseHistoryInput) ([]*medication.CourseTimeline, error) {
+	courses, err := uc.courseRepo.FindByUserID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := uc.medicationRepo.FindByUserID(ctx, input.UserID, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, med := range active {
+		courses = append(courses, medication.CurrentCourse(med))
+	}
+
+	if input.Name != nil {
+		name := medication.NormalizeName(*input.Name)
+		filtered := courses[:0]
+		for _, course := range courses {
+			if medication.NormalizeName(course.MedicationName) == name {
+				filtered = append(filtered, course)
+			}
+		}
+		courses = filtered
+	}
+
+	return medication.GroupCourses(courses), nil
+}
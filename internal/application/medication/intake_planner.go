@@ -0,0 +1,150 @@
+package medication
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+// planningDays — на сколько календарных дней вперёд (включая сегодня) создаются приёмы
+const planningDays = 2
+
+// IntakePlanner разворачивает расписание активных лекарств в записи о приёмах
+type IntakePlanner struct {
+	medicationRepo medication.Repository
+	intakeRepo     medication.IntakeRepository
+	userRepo       user.Repository
+}
+
+// NewIntakePlanner создаёт новый планировщик приёмов
+func NewIntakePlanner(
+	medicationRepo medication.Repository,
+	intakeRepo medication.IntakeRepository,
+	userRepo user.Repository,
+) *IntakePlanner {
+	return &IntakePlanner{
+		medicationRepo: medicationRepo,
+		intakeRepo:     intakeRepo,
+		userRepo:       userRepo,
+	}
+}
+
+// Name возвращает имя задачи для планировщика
+func (p *IntakePlanner) Name() string {
+	return "intake-planner"
+}
+
+// Run создаёт недостающие приёмы на сегодня и завтра в часовом поясе пользователя
+// и удаляет будущие неотмеченные приёмы, которых больше нет в расписании
+func (p *IntakePlanner) Run(ctx context.Context) error {
+	meds, err := p.medicationRepo.FindActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	users := make(map[uuid.UUID]*user.User)
+	for _, med := range meds {
+		u, ok := users[med.UserID]
+		if !ok {
+			u, err = p.userRepo.GetByID(ctx, med.UserID)
+			if err != nil {
+				return err
+			}
+			users[med.UserID] = u
+		}
+		if u == nil || u.DeletedAt != nil {
+			continue
+		}
+
+		loc := u.Location()
+		local := now.In(loc)
+		today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+		for i := 0; i < planningDays; i++ {
+			if err := p.planDay(ctx, med, today.AddDate(0, 0, i), loc, now); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// planDay синхронизирует приёмы лекарства за один день с расписанием
+func (p *IntakePlanner) planDay(ctx context.Context, med *medication.Medication, day time.Time, loc *time.Location, now time.Time) error {
+	planned := med.PlannedTimes(day, loc)
+	existing, err := p.intakeRepo.FindByMedicationAndPeriod(ctx, med.ID, day, day.AddDate(0, 0, 1).Add(-time.Nanosecond))
+	if err != nil {
+		return err
+	}
+
+	// Доза дня задаётся этапами курса; пустая строка — назначенная доза лекарства
+	var dose *string
+	if d := med.DoseOn(day, loc); d != "" {
+		dose = &d
+	}
+
+	for _, at := range planned {
+		if findIntakeAt(existing, at) != nil {
+			continue
+		}
+		intake := medication.NewMedicationIntake(med.ID, at)
+		intake.PlannedDose = dose
+		if err := p.intakeRepo.Create(ctx, intake); err != nil {
+			return err
+		}
+	}
+
+	for _, intake := range existing {
+		if !intake.IsPlanned() || !intake.ScheduledTime.After(now) {
+			continue
+		}
+		if !containsTime(planned, intake.ScheduledTime) {
+			if err := p.intakeRepo.Delete(ctx, intake.ID); err != nil {
+				return err
+			}
+			continue
+		}
+		// Этапы дозировки могли измениться после правки расписания
+		if !equalDose(intake.PlannedDose, dose) {
+			intake.PlannedDose = dose
+			if err := p.intakeRepo.Update(ctx, intake); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// findIntakeAt возвращает плановый приём на указанное время или nil.
+// Приёмы по необходимости в расписание не входят, даже если совпали по времени
+func findIntakeAt(intakes []*medication.MedicationIntake, at time.Time) *medication.MedicationIntake {
+	for _, intake := range intakes {
+		if !intake.IsAsNeeded && intake.ScheduledTime.Equal(at) {
+			return intake
+		}
+	}
+	return nil
+}
+
+// equalDose сравнивает необязательные дозы
+func equalDose(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// containsTime проверяет, есть ли момент в списке
+func containsTime(times []time.Time, at time.Time) bool {
+	for _, t := range times {
+		if t.Equal(at) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package medication
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+)
+
+// LogAsNeededIntakeUseCase представляет use case для записи приёма лекарства по необходимости
+type LogAsNeededIntakeUseCase struct {
+	medicationRepo medication.Repository
+	intakeRepo     medication.IntakeRepository
+}
+
+// NewLogAsNeededIntakeUseCase создаёт новый use case
+func NewLogAsNeededIntakeUseCase(
+	medicationRepo medication.Repository,
+	intakeRepo medication.IntakeRepository,
+) *LogAsNeededIntakeUseCase {
+	return &LogAsNeededIntakeUseCase{
+		medicationRepo: medicationRepo,
+		intakeRepo:     intakeRepo,
+	}
+}
+
+// LogAsNeededIntakeInput представляет входные данные
+type LogAsNeededIntakeInput struct {
+	UserID       uuid.UUID
+	MedicationID uuid.UUID
+	TakenAt      *time.Time // по умолчанию текущий момент
+	Dose         *string
+	Reason       *string
+}
+
+// LogAsNeededIntakeResult представляет результат записи приёма
+type LogAsNeededIntakeResult struct {
+	Intake   *medication.MedicationIntake
+	Warnings []medication.DoseWarning
+}
+
+// Execute записывает приём и возвращает предупреждения, если он превышает
+// ограничения, заданные пользователем. Приём записывается в любом случае:
+// это факт, а не запрос разрешения.
+func (uc *LogAsNeededIntakeUseCase) Execute(ctx context.Context, input LogAsNeededIntakeInput) (*LogAsNeededIntakeResult, error) {
+	med, err := uc.medicationRepo.GetByID(ctx, input.MedicationID)
+	if err != nil {
+		return nil, err
+	}
+	if med == nil {
+		return nil, medication.ErrMedicationNotFound
+	}
+	if med.UserID != input.UserID {
+		return nil, medication.ErrUnauthorized
+	}
+	if med.ScheduleType != medication.ScheduleTypeAsNeeded {
+		return nil, medication.ErrNotAsNeeded
+	}
+
+	takenAt := time.Now()
+	if input.TakenAt != nil {
+		takenAt = *input.TakenAt
+	}
+
+	// Соседние приёмы в пределах суток нужны для проверки ограничений
+	others, err := uc.intakeRepo.FindByMedicationAndPeriod(ctx, med.ID, takenAt.Add(-24*time.Hour), takenAt.Add(24*time.Hour))
+	if err != nil {
+		return nil, err
+	}
+	warnings := med.CheckAsNeededDose(takenAt, others)
+
+	intake := medication.NewAsNeededIntake(med.ID, takenAt, input.Dose, input.Reason)
+	if err := uc.intakeRepo.Create(ctx, intake); err != nil {
+		return nil, err
+	}
+
+	if med.TracksStock() {
+		stock, err := uc.medicationRepo.AdjustStock(ctx, med.ID, -med.DosageDetails.Amount)
+		if err != nil {
+			return nil, err
+		}
+		med.StockQuantity = stock
+	}
+
+	return &LogAsNeededIntakeResult{
+		Intake:   intake,
+		Warnings: warnings,
+	}, nil
+}
@@ -0,0 +1,170 @@
+package medication
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/reminder"
+)
+
+// MarkIntakeUseCase представляет use case для отметки приёма лекарства
+type MarkIntakeUseCase struct {
+	medicationRepo medication.Repository
+	intakeRepo     medication.IntakeRepository
+	reminderRepo   reminder.Repository
+}
+
+// NewMarkIntakeUseCase создаёт новый use case
+func NewMarkIntakeUseCase(
+	medicationRepo medication.Repository,
+	intakeRepo medication.IntakeRepository,
+	reminderRepo reminder.Repository,
+) *MarkIntakeUseCase {
+	return &MarkIntakeUseCase{
+		medicationRepo: medicationRepo,
+		intakeRepo:     intakeRepo,
+		reminderRepo:   reminderRepo,
+	}
+}
+
+// MarkIntakeInput представляет входные данные для отметки приёма
+type MarkIntakeInput struct {
+	UserID        uuid.UUID
+	MedicationID  uuid.UUID
+	ScheduledTime time.Time
+	Status        medication.IntakeStatus // planned (сброс отметки), taken или skipped
+	SkipReason    *string
+	ActualDose    *string
+	Notes         *string
+}
+
+// Execute отмечает приём по лекарству и запланированному времени.
+// Если записи о приёме ещё нет, она создаётся.
+func (uc *MarkIntakeUseCase) Execute(ctx context.Context, input MarkIntakeInput) (*medication.MedicationIntake, error) {
+	switch input.Status {
+	case medication.IntakeStatusPlanned, medication.IntakeStatusTaken, medication.IntakeStatusSkipped:
+	default:
+		return nil, medication.ErrInvalidIntakeStatus
+	}
+
+	med, err := uc.getOwnedMedication(ctx, input.UserID, input.MedicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	intakes, err := uc.intakeRepo.FindByMedicationAndPeriod(ctx, med.ID, input.ScheduledTime, input.ScheduledTime)
+	if err != nil {
+		return nil, err
+	}
+
+	intake := findIntakeAt(intakes, input.ScheduledTime)
+	if intake == nil {
+		intake = medication.NewMedicationIntake(med.ID, input.ScheduledTime)
+		if err := uc.intakeRepo.Create(ctx, intake); err != nil {
+			return nil, err
+		}
+	}
+
+	return uc.save(ctx, med, intake, func(intake *medication.MedicationIntake) {
+		switch input.Status {
+		case medication.IntakeStatusTaken:
+			intake.MarkTaken(input.ActualDose, input.Notes)
+		case medication.IntakeStatusSkipped:
+			intake.Skip(input.SkipReason, input.Notes)
+		default:
+			intake.ResetToPlanned()
+			if input.Notes != nil {
+				intake.Notes = input.Notes
+			}
+		}
+	})
+}
+
+// MarkTakenByID отмечает принятым приём по его ID (например, из кнопки напоминания)
+func (uc *MarkIntakeUseCase) MarkTakenByID(ctx context.Context, userID, intakeID uuid.UUID) (*medication.MedicationIntake, error) {
+	intake, err := uc.intakeRepo.GetByID(ctx, intakeID)
+	if err != nil {
+		return nil, err
+	}
+	if intake == nil {
+		return nil, medication.ErrIntakeNotFound
+	}
+	med, err := uc.getOwnedMedication(ctx, userID, intake.MedicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.save(ctx, med, intake, func(intake *medication.MedicationIntake) {
+		intake.MarkTaken(nil, nil)
+	})
+}
+
+// maxMarkAttempts ограничивает число повторов отметки, статус которой успели сменить параллельно
+const maxMarkAttempts = 3
+
+// save применяет mark к приёму и сохраняет его, пока статус не сменился параллельно,
+// вместе со списанием или возвратом дозы в запас. После смены статуса приём
+// перечитывается и отметка применяется заново. Оставшиеся напоминания снимаются,
+// если приём больше не ожидает отметки
+func (uc *MarkIntakeUseCase) save(ctx context.Context, med *medication.Medication, intake *medication.MedicationIntake, mark func(*medication.MedicationIntake)) (*medication.MedicationIntake, error) {
+	for attempt := 1; ; attempt++ {
+		from := intake.Status
+		wasTaken := intake.IsTaken()
+		mark(intake)
+
+		var delta float64
+		if wasTaken != intake.IsTaken() && med.TracksStock() {
+			delta = med.DosageDetails.Amount
+			if intake.IsTaken() {
+				delta = -delta
+			}
+		}
+
+		applied, stock, err := uc.intakeRepo.UpdateMark(ctx, intake, from, delta)
+		if err != nil {
+			return nil, err
+		}
+		if applied {
+			if delta != 0 {
+				med.StockQuantity = stock
+			}
+			break
+		}
+		if attempt == maxMarkAttempts {
+			return nil, medication.ErrIntakeMarkConflict
+		}
+
+		intake, err = uc.intakeRepo.GetByID(ctx, intake.ID)
+		if err != nil {
+			return nil, err
+		}
+		if intake == nil {
+			return nil, medication.ErrIntakeNotFound
+		}
+	}
+
+	if !intake.IsPlanned() {
+		if err := uc.reminderRepo.DeletePendingByRelated(ctx, reminder.TypeMedication, intake.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return intake, nil
+}
+
+// getOwnedMedication загружает лекарство и проверяет, что оно принадлежит пользователю
+func (uc *MarkIntakeUseCase) getOwnedMedication(ctx context.Context, userID, medicationID uuid.UUID) (*medication.Medication, error) {
+	med, err := uc.medicationRepo.GetByID(ctx, medicationID)
+	if err != nil {
+		return nil, err
+	}
+	if med == nil {
+		return nil, medication.ErrMedicationNotFound
+	}
+	if med.UserID != userID {
+		return nil, medication.ErrUnauthorized
+	}
+	return med, nil
+}
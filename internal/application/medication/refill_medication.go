@@ -0,0 +1,63 @@
+package medication
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/reminder"
+)
+
+// RefillMedicationUseCase представляет use case для пополнения запаса лекарства
+type RefillMedicationUseCase struct {
+	medicationRepo medication.Repository
+	reminderRepo   reminder.Repository
+}
+
+// NewRefillMedicationUseCase создаёт новый use case
+func NewRefillMedicationUseCase(
+	medicationRepo medication.Repository,
+	reminderRepo reminder.Repository,
+) *RefillMedicationUseCase {
+	return &RefillMedicationUseCase{
+		medicationRepo: medicationRepo,
+		reminderRepo:   reminderRepo,
+	}
+}
+
+// RefillMedicationInput представляет входные данные; Quantity — в единицах дозы
+type RefillMedicationInput struct {
+	UserID       uuid.UUID
+	MedicationID uuid.UUID
+	Quantity     float64
+}
+
+// Execute пополняет запас и снимает неотправленное напоминание о пополнении
+func (uc *RefillMedicationUseCase) Execute(ctx context.Context, input RefillMedicationInput) (*medication.Medication, error) {
+	med, err := uc.medicationRepo.GetByID(ctx, input.MedicationID)
+	if err != nil {
+		return nil, err
+	}
+	if med == nil {
+		return nil, medication.ErrMedicationNotFound
+	}
+	if med.UserID != input.UserID {
+		return nil, medication.ErrUnauthorized
+	}
+
+	if err := med.Refill(input.Quantity); err != nil {
+		return nil, err
+	}
+
+	stock, err := uc.medicationRepo.Refill(ctx, med.ID, input.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	med.StockQuantity = stock
+
+	if err := uc.reminderRepo.DeletePendingByRelated(ctx, reminder.TypeRefill, med.ID); err != nil {
+		return nil, err
+	}
+
+	return med, nil
+}
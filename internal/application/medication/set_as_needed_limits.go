@@ -0,0 +1,56 @@
+package medication
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+)
+
+// SetAsNeededLimitsUseCase представляет use case для настройки ограничений приёма по необходимости
+type SetAsNeededLimitsUseCase struct {
+	medicationRepo medication.Repository
+}
+
+// NewSetAsNeededLimitsUseCase создаёт новый use case
+func NewSetAsNeededLimitsUseCase(medicationRepo medication.Repository) *SetAsNeededLimitsUseCase {
+	return &SetAsNeededLimitsUseCase{
+		medicationRepo: medicationRepo,
+	}
+}
+
+// SetAsNeededLimitsInput представляет входные данные; nil снимает ограничение
+type SetAsNeededLimitsInput struct {
+	UserID         uuid.UUID
+	MedicationID   uuid.UUID
+	MaxDosesPer24h *int
+	MinInterval    *time.Duration
+}
+
+// Execute сохраняет ограничения для лекарства пользователя
+func (uc *SetAsNeededLimitsUseCase) Execute(ctx context.Context, input SetAsNeededLimitsInput) (*medication.Medication, error) {
+	med, err := uc.medicationRepo.GetByID(ctx, input.MedicationID)
+	if err != nil {
+		return nil, err
+	}
+	if med == nil {
+		return nil, medication.ErrMedicationNotFound
+	}
+	if med.UserID != input.UserID {
+		return nil, medication.ErrUnauthorized
+	}
+	if med.ScheduleType != medication.ScheduleTypeAsNeeded {
+		return nil, medication.ErrNotAsNeeded
+	}
+
+	if err := med.SetAsNeededLimits(input.MaxDosesPer24h, input.MinInterval); err != nil {
+		return nil, err
+	}
+
+	if err := uc.medicationRepo.Update(ctx, med); err != nil {
+		return nil, err
+	}
+
+	return med, nil
+}
@@ -0,0 +1,109 @@
+package medication
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+)
+
+// UpdateMedicationUseCase представляет use case для изменения лекарства
+type UpdateMedicationUseCase struct {
+	medicationRepo medication.Repository
+	endCourse      *EndCourseUseCase
+}
+
+// NewUpdateMedicationUseCase создаёт новый use case
+func NewUpdateMedicationUseCase(medicationRepo medication.Repository, endCourse *EndCourseUseCase) *UpdateMedicationUseCase {
+	return &UpdateMedicationUseCase{
+		medicationRepo: medicationRepo,
+		endCourse:      endCourse,
+	}
+}
+
+// UpdateMedicationInput представляет входные данные; nil-поля не меняются
+type UpdateMedicationInput struct {
+	UserID          uuid.UUID
+	MedicationID    uuid.UUID
+	Name            *string
+	Dosage          *string
+	DosageDetails   *medication.DosageDetails
+	StockQuantity   *float64 // задаёт остаток; для пополнения используется RefillMedicationUseCase
+	ScheduleType    *medication.ScheduleType
+	ScheduleDetails *medication.ScheduleDetails
+	StartDate       *time.Time
+	EndDate         *time.Time
+	IsActive        *bool
+}
+
+// Execute применяет изменения и проверяет итоговое расписание.
+// Будущие приёмы приводит в соответствие с расписанием фоновый планировщик.
+// Отключение лекарства завершает курс как прекращённый, повторное включение
+// без новых дат начинает новый курс с сегодняшнего дня.
+func (uc *UpdateMedicationUseCase) Execute(ctx context.Context, input UpdateMedicationInput) (*medication.Medication, error) {
+	med, err := uc.medicationRepo.GetByID(ctx, input.MedicationID)
+	if err != nil {
+		return nil, err
+	}
+	if med == nil {
+		return nil, medication.ErrMedicationNotFound
+	}
+	if med.UserID != input.UserID {
+		return nil, medication.ErrUnauthorized
+	}
+
+	wasActive := med.IsActive
+	med.Update(
+		input.Name,
+		input.Dosage,
+		input.ScheduleType,
+		input.ScheduleDetails,
+		input.StartDate,
+		input.EndDate,
+		input.IsActive,
+	)
+	if input.DosageDetails != nil {
+		med.DosageDetails = input.DosageDetails
+	}
+	if input.StockQuantity != nil {
+		if err := med.SetStock(input.StockQuantity); err != nil {
+			return nil, err
+		}
+	}
+
+	if !wasActive && med.IsActive && input.StartDate == nil {
+		today, err := uc.endCourse.today(ctx, med.UserID, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		med.StartDate = today
+		if input.EndDate == nil && med.EndDate != nil && med.EndDate.Before(today) {
+			med.EndDate = nil
+		}
+	}
+
+	if err := med.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Остаток не входит в Update, поэтому заданный пользователем запас сохраняется отдельно
+	if input.StockQuantity != nil {
+		if err := uc.medicationRepo.SetStock(ctx, med.ID, med.StockQuantity); err != nil {
+			return nil, err
+		}
+	}
+
+	if wasActive && !med.IsActive {
+		if err := uc.endCourse.End(ctx, med, medication.CourseEndStopped, nil); err != nil {
+			return nil, err
+		}
+		return med, nil
+	}
+
+	if err := uc.medicationRepo.Update(ctx, med); err != nil {
+		return nil, err
+	}
+
+	return med, nil
+}
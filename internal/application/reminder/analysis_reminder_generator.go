@@ -0,0 +1,206 @@
+package reminder
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/analysis"
+	"github.com/health-hub-bot-api/internal/domain/reminder"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+// AnalysisReminderGenerator создаёт напоминания о повторных анализах по NextReminderDate
+// и удаляет неактуальные, если дата изменилась или анализ удалён
+type AnalysisReminderGenerator struct {
+	analysisRepo analysis.Repository
+	userRepo     user.Repository
+	reminderRepo reminder.Repository
+	leadDays     []int
+}
+
+// NewAnalysisReminderGenerator создаёт новый генератор напоминаний об анализах
+func NewAnalysisReminderGenerator(
+	analysisRepo analysis.Repository,
+	userRepo user.Repository,
+	reminderRepo reminder.Repository,
+	leadDays []int,
+) *AnalysisReminderGenerator {
+	// Сортировка по убыванию: от самого раннего напоминания к самому позднему
+	sorted := append([]int(nil), leadDays...)
+	sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+
+	return &AnalysisReminderGenerator{
+		analysisRepo: analysisRepo,
+		userRepo:     userRepo,
+		reminderRepo: reminderRepo,
+		leadDays:     sorted,
+	}
+}
+
+// Name возвращает имя задачи для планировщика
+func (g *AnalysisReminderGenerator) Name() string {
+	return "analysis-reminder-generator"
+}
+
+// Run создаёт недостающие напоминания и удаляет устаревшие
+func (g *AnalysisReminderGenerator) Run(ctx context.Context) error {
+	if len(g.leadDays) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	// Запас в сутки покрывает разницу часовых поясов
+	horizon := now.AddDate(0, 0, g.leadDays[0]+1)
+	analyses, err := g.analysisRepo.FindWithReminderBefore(ctx, horizon)
+	if err != nil {
+		return err
+	}
+
+	users := make(map[uuid.UUID]*user.User)
+	byID := make(map[uuid.UUID]*analysis.Analysis, len(analyses))
+	for _, a := range analyses {
+		byID[a.ID] = a
+		u, err := g.loadUser(ctx, users, a.UserID)
+		if err != nil {
+			return err
+		}
+		if u == nil {
+			continue
+		}
+		if err := g.generate(ctx, a, u, now); err != nil {
+			return err
+		}
+	}
+
+	return g.cleanup(ctx, byID, users)
+}
+
+// generate создаёт напоминания одного анализа. Будущие напоминания создаются заранее;
+// из уже наступивших создаётся только последнее, чтобы не слать пачку сообщений сразу.
+func (g *AnalysisReminderGenerator) generate(ctx context.Context, a *analysis.Analysis, u *user.User, now time.Time) error {
+	schedule := g.schedule(a, u)
+	if len(schedule) == 0 {
+		return nil
+	}
+
+	// Дата повторного анализа уже прошла — догонять напоминаниями поздно
+	loc := u.Location()
+	if calendarDay(*a.NextReminderDate, loc).Before(startOfDay(now, loc)) {
+		return nil
+	}
+
+	latestDue := -1
+	for i, item := range schedule {
+		if !item.at.After(now) {
+			latestDue = i
+		}
+	}
+
+	for i, item := range schedule {
+		if !item.at.After(now) && i != latestDue {
+			continue
+		}
+
+		exists, err := g.reminderRepo.Exists(ctx, a.UserID, reminder.TypeAnalysis, &a.ID, item.at)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
+		rem := reminder.NewReminder(a.UserID, reminder.TypeAnalysis, &a.ID, item.at, a.ReminderMessage(item.leadDays))
+		if err := g.reminderRepo.Create(ctx, rem); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cleanup удаляет неотправленные напоминания об удалённых анализах,
+// закрытых напоминаниях и изменившихся датах. Напоминание создаётся только
+// для анализа в горизонте планирования, а горизонт со временем лишь сдвигается
+// вперёд, поэтому анализ с актуальным напоминанием всегда есть среди
+// загруженных в этом проходе и дополнительные запросы не нужны.
+func (g *AnalysisReminderGenerator) cleanup(ctx context.Context, analyses map[uuid.UUID]*analysis.Analysis, users map[uuid.UUID]*user.User) error {
+	pending, err := g.reminderRepo.FindPendingByType(ctx, reminder.TypeAnalysis)
+	if err != nil {
+		return err
+	}
+
+	for _, rem := range pending {
+		if rem.RelatedID == nil || !g.isStale(analyses, users, rem) {
+			continue
+		}
+		if err := g.reminderRepo.Delete(ctx, rem.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isStale проверяет, соответствует ли напоминание текущей дате повторного анализа
+func (g *AnalysisReminderGenerator) isStale(analyses map[uuid.UUID]*analysis.Analysis, users map[uuid.UUID]*user.User, rem *reminder.Reminder) bool {
+	a, ok := analyses[*rem.RelatedID]
+	if !ok {
+		return true
+	}
+	u := users[a.UserID]
+	if u == nil {
+		return true
+	}
+
+	for _, item := range g.schedule(a, u) {
+		if item.at.Equal(rem.ScheduledTime) {
+			return false
+		}
+	}
+	return true
+}
+
+// scheduledAnalysisReminder представляет плановое напоминание об анализе
+type scheduledAnalysisReminder struct {
+	leadDays int
+	at       time.Time
+}
+
+// schedule возвращает плановые напоминания анализа по возрастанию времени
+func (g *AnalysisReminderGenerator) schedule(a *analysis.Analysis, u *user.User) []scheduledAnalysisReminder {
+	if a.NextReminderDate == nil {
+		return nil
+	}
+
+	loc := u.Location()
+	prefs := u.NotificationPreferences
+	day := calendarDay(*a.NextReminderDate, loc)
+
+	items := make([]scheduledAnalysisReminder, 0, len(g.leadDays))
+	for _, lead := range g.leadDays {
+		at := day.AddDate(0, 0, -lead).Add(reminderClock)
+		items = append(items, scheduledAnalysisReminder{
+			leadDays: lead,
+			at:       prefs.NextAllowedTime(at, loc),
+		})
+	}
+	return items
+}
+
+// loadUser возвращает пользователя из кэша прохода или репозитория
+func (g *AnalysisReminderGenerator) loadUser(ctx context.Context, users map[uuid.UUID]*user.User, id uuid.UUID) (*user.User, error) {
+	if u, ok := users[id]; ok {
+		return u, nil
+	}
+	u, err := g.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if u != nil && u.DeletedAt != nil {
+		u = nil
+	}
+	users[id] = u
+	return u, nil
+}
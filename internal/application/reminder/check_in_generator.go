@@ -0,0 +1,110 @@
+package reminder
+
+import (
+	"context"
+	"time"
+
+	"github.com/health-hub-bot-api/internal/domain/reminder"
+	"github.com/health-hub-bot-api/internal/domain/symptom"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+// CheckInMessage — текст ежедневного вопроса о самочувствии
+const CheckInMessage = "Как ты себя чувствуешь сегодня?"
+
+// CheckInGenerator создаёт напоминания symptom_check по настройкам пользователей
+type CheckInGenerator struct {
+	userRepo     user.Repository
+	symptomRepo  symptom.Repository
+	reminderRepo reminder.Repository
+}
+
+// NewCheckInGenerator создаёт новый генератор check-in напоминаний
+func NewCheckInGenerator(
+	userRepo user.Repository,
+	symptomRepo symptom.Repository,
+	reminderRepo reminder.Repository,
+) *CheckInGenerator {
+	return &CheckInGenerator{
+		userRepo:     userRepo,
+		symptomRepo:  symptomRepo,
+		reminderRepo: reminderRepo,
+	}
+}
+
+// Name возвращает имя задачи для планировщика
+func (g *CheckInGenerator) Name() string {
+	return "check-in-generator"
+}
+
+// Run создаёт напоминания для наступивших сегодня check-in слотов.
+// День пропускается, если пользователь уже сделал запись.
+func (g *CheckInGenerator) Run(ctx context.Context) error {
+	users, err := g.userRepo.FindWithCheckIns(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, u := range users {
+		if err := g.generateForUser(ctx, u, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generateForUser создаёт напоминания одного пользователя
+func (g *CheckInGenerator) generateForUser(ctx context.Context, u *user.User, now time.Time) error {
+	prefs := u.NotificationPreferences
+	loc := u.Location()
+	local := now.In(loc)
+	if !prefs.IsActiveDay(local) {
+		return nil
+	}
+
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	var entryChecked, hasEntry bool
+
+	for _, clock := range prefs.CheckInTimes {
+		offset, err := user.ParseClock(clock)
+		if err != nil {
+			continue
+		}
+		slot := today.Add(offset)
+		if slot.After(now) || now.Sub(slot) > catchUpWindow {
+			continue
+		}
+
+		// Запись проверяется один раз и только когда есть наступивший слот
+		if !entryChecked {
+			entries, err := g.symptomRepo.GetEntryTimes(ctx, u.ID, today)
+			if err != nil {
+				return err
+			}
+			hasEntry = len(entries) > 0
+			entryChecked = true
+		}
+		if hasEntry {
+			return nil
+		}
+
+		// Check-in внутри тихих часов переносится на их окончание
+		scheduled := prefs.NextAllowedTime(slot, loc)
+		exists, err := g.reminderRepo.Exists(ctx, u.ID, reminder.TypeSymptomCheck, nil, scheduled)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
+		rem := reminder.NewReminder(u.ID, reminder.TypeSymptomCheck, nil, scheduled, CheckInMessage)
+		if err := g.reminderRepo.Create(ctx, rem); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
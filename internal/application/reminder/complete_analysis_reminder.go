@@ -0,0 +1,74 @@
+package reminder
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/analysis"
+	"github.com/health-hub-bot-api/internal/domain/reminder"
+)
+
+// CompleteAnalysisReminderUseCase представляет use case для отметки «анализ сдан»
+type CompleteAnalysisReminderUseCase struct {
+	analysisRepo analysis.Repository
+	reminderRepo reminder.Repository
+}
+
+// NewCompleteAnalysisReminderUseCase создаёт новый use case
+func NewCompleteAnalysisReminderUseCase(analysisRepo analysis.Repository, reminderRepo reminder.Repository) *CompleteAnalysisReminderUseCase {
+	return &CompleteAnalysisReminderUseCase{
+		analysisRepo: analysisRepo,
+		reminderRepo: reminderRepo,
+	}
+}
+
+// CompleteAnalysisReminderInput представляет входные данные
+type CompleteAnalysisReminderInput struct {
+	UserID        uuid.UUID
+	AnalysisID    uuid.UUID
+	NewAnalysisID *uuid.UUID // повторный анализ, если он уже загружен
+}
+
+// Execute закрывает напоминание о повторном анализе, удаляет
+// неотправленные уведомления и связывает анализ с новым результатом
+func (uc *CompleteAnalysisReminderUseCase) Execute(ctx context.Context, input CompleteAnalysisReminderInput) (*analysis.Analysis, error) {
+	a, err := uc.getOwned(ctx, input.UserID, input.AnalysisID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.NewAnalysisID != nil {
+		if *input.NewAnalysisID == a.ID {
+			return nil, analysis.ErrInvalidFollowUp
+		}
+		if _, err := uc.getOwned(ctx, input.UserID, *input.NewAnalysisID); err != nil {
+			return nil, err
+		}
+	}
+
+	a.CompleteReminder(input.NewAnalysisID)
+	if err := uc.analysisRepo.Update(ctx, a); err != nil {
+		return nil, err
+	}
+
+	if err := uc.reminderRepo.DeletePendingByRelated(ctx, reminder.TypeAnalysis, a.ID); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// getOwned загружает анализ и проверяет, что он принадлежит пользователю
+func (uc *CompleteAnalysisReminderUseCase) getOwned(ctx context.Context, userID, analysisID uuid.UUID) (*analysis.Analysis, error) {
+	a, err := uc.analysisRepo.GetByID(ctx, analysisID)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil {
+		return nil, analysis.ErrAnalysisNotFound
+	}
+	if a.UserID != userID {
+		return nil, analysis.ErrUnauthorized
+	}
+	return a, nil
+}
@@ -0,0 +1,144 @@
+package reminder
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/notification"
+	"github.com/health-hub-bot-api/internal/domain/reminder"
+	"github.com/health-hub-bot-api/internal/domain/symptom"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+// dispatchBatchSize — сколько напоминаний отправляется за один проход
+const dispatchBatchSize = 100
+
+// Dispatcher отправляет наступившие напоминания с учётом настроек пользователя
+type Dispatcher struct {
+	userRepo     user.Repository
+	reminderRepo reminder.Repository
+	symptomRepo  symptom.Repository
+	notifier     notification.Notifier
+}
+
+// NewDispatcher создаёт новый диспетчер напоминаний
+func NewDispatcher(
+	userRepo user.Repository,
+	reminderRepo reminder.Repository,
+	symptomRepo symptom.Repository,
+	notifier notification.Notifier,
+) *Dispatcher {
+	return &Dispatcher{
+		userRepo:     userRepo,
+		reminderRepo: reminderRepo,
+		symptomRepo:  symptomRepo,
+		notifier:     notifier,
+	}
+}
+
+// Name возвращает имя задачи для планировщика
+func (d *Dispatcher) Name() string {
+	return "reminder-dispatcher"
+}
+
+// Run отправляет наступившие напоминания. Напоминания, попавшие в тихие часы,
+// переносятся на их окончание; ошибка доставки одного напоминания не останавливает проход.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	now := time.Now()
+	due, err := d.reminderRepo.FindDue(ctx, now, dispatchBatchSize)
+	if err != nil {
+		return err
+	}
+
+	users := make(map[uuid.UUID]*user.User)
+	for _, rem := range due {
+		u, err := d.cachedUser(ctx, users, rem.UserID)
+		if err != nil {
+			return err
+		}
+
+		// Напоминания подопечного профиля получает владелец аккаунта
+		recipient := u
+		if u != nil && u.IsDependent() {
+			recipient, err = d.cachedUser(ctx, users, *u.OwnerID)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := d.dispatch(ctx, u, recipient, rem, now); err != nil {
+			log.Printf("failed to dispatch reminder %s: %v", rem.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// cachedUser загружает пользователя один раз за проход
+func (d *Dispatcher) cachedUser(ctx context.Context, users map[uuid.UUID]*user.User, id uuid.UUID) (*user.User, error) {
+	if u, ok := users[id]; ok {
+		return u, nil
+	}
+	u, err := d.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	users[id] = u
+	return u, nil
+}
+
+// dispatch доставляет одно напоминание профиля u получателю recipient
+// и отмечает его отправленным. Часовой пояс, тихие часы и каналы берутся
+// у самого профиля, у получателя — только чат для доставки.
+func (d *Dispatcher) dispatch(ctx context.Context, u, recipient *user.User, rem *reminder.Reminder, now time.Time) error {
+	// Профиль или владелец аккаунта удалён — доставлять некуда
+	if u == nil || u.DeletedAt != nil || recipient == nil || recipient.DeletedAt != nil {
+		rem.MarkSent()
+		return d.reminderRepo.Update(ctx, rem)
+	}
+
+	prefs := u.NotificationPreferences
+	loc := u.Location()
+
+	// Запись могла появиться после создания check-in — тогда напоминание уже не нужно
+	if rem.Type == reminder.TypeSymptomCheck {
+		entries, err := d.symptomRepo.GetEntryTimes(ctx, u.ID, startOfDay(now, loc))
+		if err != nil {
+			return err
+		}
+		if len(entries) > 0 {
+			return d.reminderRepo.Delete(ctx, rem.ID)
+		}
+	}
+
+	if rem.RespectsQuietHours() && prefs.InQuietHours(now, loc) {
+		rem.Postpone(prefs.NextAllowedTime(now, loc))
+		return d.reminderRepo.Update(ctx, rem)
+	}
+
+	// Без канала Telegram напоминание остаётся только в WebApp
+	if prefs.HasChannel(user.NotificationChannelTelegram) {
+		message := buildMessage(rem)
+		message.Text = u.LabelMessage(message.Text)
+		if err := d.notifier.Send(ctx, recipient.TelegramUserID, message); err != nil {
+			return err
+		}
+	}
+
+	rem.MarkSent()
+	return d.reminderRepo.Update(ctx, rem)
+}
+
+// buildMessage формирует сообщение с кнопками действий напоминания
+func buildMessage(rem *reminder.Reminder) notification.Message {
+	message := notification.Message{Text: rem.Message}
+	for _, action := range rem.Actions() {
+		message.Buttons = append(message.Buttons, notification.Button{
+			Text:         action.Label,
+			CallbackData: rem.CallbackData(action.Action),
+		})
+	}
+	return message
+}
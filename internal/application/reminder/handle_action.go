@@ -0,0 +1,123 @@
+package reminder
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	doctorvisitapp "github.com/health-hub-bot-api/internal/application/doctorvisit"
+	medicationapp "github.com/health-hub-bot-api/internal/application/medication"
+	"github.com/health-hub-bot-api/internal/domain/reminder"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+// HandleActionUseCase выполняет действие, выбранное кнопкой под напоминанием
+type HandleActionUseCase struct {
+	reminderRepo     reminder.Repository
+	userRepo         user.Repository
+	snoozeReminder   *SnoozeReminderUseCase
+	completeAnalysis *CompleteAnalysisReminderUseCase
+	sendVisitReport  *doctorvisitapp.SendReportUseCase
+	markIntake       *medicationapp.MarkIntakeUseCase
+}
+
+// NewHandleActionUseCase создаёт новый use case
+func NewHandleActionUseCase(
+	reminderRepo reminder.Repository,
+	userRepo user.Repository,
+	snoozeReminder *SnoozeReminderUseCase,
+	completeAnalysis *CompleteAnalysisReminderUseCase,
+	sendVisitReport *doctorvisitapp.SendReportUseCase,
+	markIntake *medicationapp.MarkIntakeUseCase,
+) *HandleActionUseCase {
+	return &HandleActionUseCase{
+		reminderRepo:     reminderRepo,
+		userRepo:         userRepo,
+		snoozeReminder:   snoozeReminder,
+		completeAnalysis: completeAnalysis,
+		sendVisitReport:  sendVisitReport,
+		markIntake:       markIntake,
+	}
+}
+
+// HandleActionInput представляет входные данные действия; UserID — владелец
+// аккаунта, нажавший кнопку, напоминание может относиться к его подопечному
+type HandleActionInput struct {
+	UserID     uuid.UUID
+	ReminderID uuid.UUID
+	Action     reminder.Action
+}
+
+// Execute выполняет действие и возвращает короткий ответ для пользователя
+func (uc *HandleActionUseCase) Execute(ctx context.Context, input HandleActionInput) (string, error) {
+	rem, err := uc.reminderRepo.GetByID(ctx, input.ReminderID)
+	if err != nil {
+		return "", err
+	}
+	if err := uc.authorize(ctx, rem, input.UserID); err != nil {
+		return "", err
+	}
+	if !supportsAction(rem, input.Action) {
+		return "", reminder.ErrUnsupportedAction
+	}
+
+	// Действие выполняется от имени профиля, к которому относится напоминание
+	profileID := rem.UserID
+
+	switch input.Action {
+	case reminder.ActionSnooze:
+		if _, err := uc.snoozeReminder.Execute(ctx, SnoozeReminderInput{UserID: profileID, ReminderID: rem.ID}); err != nil {
+			return "", err
+		}
+		return "Хорошо, напомню позже", nil
+
+	case reminder.ActionDone:
+		if _, err := uc.completeAnalysis.Execute(ctx, CompleteAnalysisReminderInput{UserID: profileID, AnalysisID: *rem.RelatedID}); err != nil {
+			return "", err
+		}
+		return "Отлично! Не забудь загрузить результаты", nil
+
+	case reminder.ActionPrepareReport:
+		if err := uc.sendVisitReport.Execute(ctx, doctorvisitapp.SendReportInput{UserID: profileID, VisitID: *rem.RelatedID}); err != nil {
+			return "", err
+		}
+		return "Отчёт готов", nil
+
+	case reminder.ActionTake:
+		if _, err := uc.markIntake.MarkTakenByID(ctx, profileID, *rem.RelatedID); err != nil {
+			return "", err
+		}
+		return "Приём отмечен", nil
+
+	default:
+		// Запись самочувствия открывается на стороне клиента
+		return "", nil
+	}
+}
+
+// authorize проверяет, что напоминание относится к аккаунту или его подопечному
+func (uc *HandleActionUseCase) authorize(ctx context.Context, rem *reminder.Reminder, accountID uuid.UUID) error {
+	if rem.UserID == accountID {
+		return nil
+	}
+	profile, err := uc.userRepo.GetByID(ctx, rem.UserID)
+	if err != nil {
+		return err
+	}
+	if profile == nil || !profile.IsManagedBy(accountID) {
+		return reminder.ErrUnauthorized
+	}
+	return nil
+}
+
+// supportsAction проверяет, что действие доступно для напоминания
+func supportsAction(rem *reminder.Reminder, action reminder.Action) bool {
+	if rem.RelatedID == nil && action != reminder.ActionSnooze && action != reminder.ActionLogSymptom {
+		return false
+	}
+	for _, button := range rem.Actions() {
+		if button.Action == action {
+			return true
+		}
+	}
+	return false
+}
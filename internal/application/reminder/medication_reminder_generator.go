@@ -0,0 +1,150 @@
+package reminder
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/reminder"
+)
+
+// missedLookback ограничивает глубину поиска неотмеченных приёмов для отметки «пропущен»
+const missedLookback = 7 * 24 * time.Hour
+
+// MedicationReminderGenerator напоминает о запланированных приёмах, присылает одно
+// повторное напоминание и отмечает приём пропущенным после окна ожидания
+type MedicationReminderGenerator struct {
+	medicationRepo medication.Repository
+	intakeRepo     medication.IntakeRepository
+	reminderRepo   reminder.Repository
+	gracePeriod    time.Duration
+	followUpDelay  time.Duration
+}
+
+// NewMedicationReminderGenerator создаёт новый генератор напоминаний о приёмах.
+// followUpDelay = 0 отключает повторное напоминание.
+func NewMedicationReminderGenerator(
+	medicationRepo medication.Repository,
+	intakeRepo medication.IntakeRepository,
+	reminderRepo reminder.Repository,
+	gracePeriod time.Duration,
+	followUpDelay time.Duration,
+) *MedicationReminderGenerator {
+	return &MedicationReminderGenerator{
+		medicationRepo: medicationRepo,
+		intakeRepo:     intakeRepo,
+		reminderRepo:   reminderRepo,
+		gracePeriod:    gracePeriod,
+		followUpDelay:  followUpDelay,
+	}
+}
+
+// Name возвращает имя задачи для планировщика
+func (g *MedicationReminderGenerator) Name() string {
+	return "medication-reminder-generator"
+}
+
+// Run отмечает просроченные приёмы и создаёт напоминания о наступивших
+func (g *MedicationReminderGenerator) Run(ctx context.Context) error {
+	now := time.Now()
+	if err := g.markMissed(ctx, now); err != nil {
+		return err
+	}
+
+	intakes, err := g.intakeRepo.FindUnmarked(ctx, now.Add(-g.gracePeriod), now)
+	if err != nil {
+		return err
+	}
+
+	meds := make(map[uuid.UUID]*medication.Medication)
+	for _, intake := range intakes {
+		med, ok := meds[intake.MedicationID]
+		if !ok {
+			med, err = g.medicationRepo.GetByID(ctx, intake.MedicationID)
+			if err != nil {
+				return err
+			}
+			meds[intake.MedicationID] = med
+		}
+		if med == nil || !med.IsActive {
+			continue
+		}
+
+		if err := g.remind(ctx, med, intake, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// markMissed отмечает пропущенными приёмы, не отмеченные за окно ожидания,
+// и снимает оставшиеся по ним напоминания
+func (g *MedicationReminderGenerator) markMissed(ctx context.Context, now time.Time) error {
+	deadline := now.Add(-g.gracePeriod)
+	intakes, err := g.intakeRepo.FindUnmarked(ctx, deadline.Add(-missedLookback), deadline)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]uuid.UUID, len(intakes))
+	for i, intake := range intakes {
+		ids[i] = intake.ID
+	}
+	// Статус проверяется в самом UPDATE: приём, отмеченный после выборки, остаётся как есть
+	missed, err := g.intakeRepo.MarkMissed(ctx, ids, now)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range missed {
+		if err := g.reminderRepo.DeletePendingByRelated(ctx, reminder.TypeMedication, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// remind создаёт основное и повторное напоминания о приёме
+func (g *MedicationReminderGenerator) remind(ctx context.Context, med *medication.Medication, intake *medication.MedicationIntake, now time.Time) error {
+	// Основное напоминание: не догоняем приёмы, пропущенные, например, при простое сервера
+	if now.Sub(intake.ScheduledTime) <= catchUpWindow {
+		if err := g.createOnce(ctx, med.UserID, intake.ID, intake.ScheduledTime, med.IntakeReminderMessage(intake)); err != nil {
+			return err
+		}
+	}
+
+	if g.followUpDelay <= 0 || g.followUpDelay >= g.gracePeriod {
+		return nil
+	}
+	followUpAt := intake.ScheduledTime.Add(g.followUpDelay)
+	if followUpAt.After(now) {
+		return nil
+	}
+
+	// Пока отложенное напоминание ждёт доставки, повторное не нужно
+	pending, err := g.reminderRepo.FindPendingByRelated(ctx, reminder.TypeMedication, intake.ID)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return nil
+	}
+
+	return g.createOnce(ctx, med.UserID, intake.ID, followUpAt, med.IntakeFollowUpMessage())
+}
+
+// createOnce создаёт напоминание о приёме, если на это время его ещё не было
+func (g *MedicationReminderGenerator) createOnce(ctx context.Context, userID, intakeID uuid.UUID, at time.Time, message string) error {
+	exists, err := g.reminderRepo.Exists(ctx, userID, reminder.TypeMedication, &intakeID, at)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	return g.reminderRepo.Create(ctx, reminder.NewReminder(userID, reminder.TypeMedication, &intakeID, at, message))
+}
@@ -0,0 +1,96 @@
+package reminder
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/reminder"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+// RefillReminderGenerator напоминает о пополнении запаса лекарства за leadDays
+// дней до прогнозируемого окончания
+type RefillReminderGenerator struct {
+	medicationRepo medication.Repository
+	userRepo       user.Repository
+	reminderRepo   reminder.Repository
+	leadDays       int
+}
+
+// NewRefillReminderGenerator создаёт новый генератор напоминаний о пополнении запаса
+func NewRefillReminderGenerator(
+	medicationRepo medication.Repository,
+	userRepo user.Repository,
+	reminderRepo reminder.Repository,
+	leadDays int,
+) *RefillReminderGenerator {
+	return &RefillReminderGenerator{
+		medicationRepo: medicationRepo,
+		userRepo:       userRepo,
+		reminderRepo:   reminderRepo,
+		leadDays:       leadDays,
+	}
+}
+
+// Name возвращает имя задачи для планировщика
+func (g *RefillReminderGenerator) Name() string {
+	return "refill-reminder-generator"
+}
+
+// Run создаёт по одному напоминанию на каждый запас, который скоро закончится.
+// Повторно о том же запасе не напоминает: отметка сбрасывается при пополнении.
+func (g *RefillReminderGenerator) Run(ctx context.Context) error {
+	meds, err := g.medicationRepo.FindActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	users := make(map[uuid.UUID]*user.User)
+	for _, med := range meds {
+		if !med.TracksStock() || med.RefillRemindedAt != nil {
+			continue
+		}
+
+		u, ok := users[med.UserID]
+		if !ok {
+			u, err = g.userRepo.GetByID(ctx, med.UserID)
+			if err != nil {
+				return err
+			}
+			users[med.UserID] = u
+		}
+		if u == nil || u.DeletedAt != nil {
+			continue
+		}
+
+		loc := u.Location()
+		runsOut := med.RunsOutOn(now, loc)
+		if runsOut == nil {
+			continue
+		}
+		today := startOfDay(now, loc)
+		if runsOut.AddDate(0, 0, -g.leadDays).After(today) {
+			continue
+		}
+
+		// Напоминание приходит в обычное время дня, а если оно уже прошло — сразу
+		at := today.Add(reminderClock)
+		if at.Before(now) {
+			at = now
+		}
+		rem := reminder.NewReminder(med.UserID, reminder.TypeRefill, &med.ID, at, med.RefillReminderMessage(*runsOut))
+		if err := g.reminderRepo.Create(ctx, rem); err != nil {
+			return err
+		}
+
+		med.MarkRefillReminded()
+		if err := g.medicationRepo.MarkRefillReminded(ctx, med.ID, *med.RefillRemindedAt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
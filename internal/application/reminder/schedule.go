@@ -0,0 +1,29 @@
+package reminder
+
+import "time"
+
+// reminderClock — время суток (в часовом поясе пользователя), когда приходят
+// напоминания, привязанные к календарной дате (анализы, визиты)
+const reminderClock = 10 * time.Hour
+
+// catchUpWindow — насколько поздно после наступления времени ещё создаётся
+// напоминание о событии (например, после перезапуска сервера)
+const catchUpWindow = time.Hour
+
+// calendarDay возвращает начало календарного дня date в часовом поясе loc.
+// Колонки типа DATE читаются как полночь UTC, поэтому берутся их год, месяц и день.
+func calendarDay(date time.Time, loc *time.Location) time.Time {
+	date = date.UTC()
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+}
+
+// startOfDay возвращает начало дня t в часовом поясе loc
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+}
+
+// daysBetween возвращает число календарных дней от from до to
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours()+12) / 24
+}
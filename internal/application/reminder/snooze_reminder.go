@@ -0,0 +1,59 @@
+package reminder
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/reminder"
+)
+
+// SnoozeReminderUseCase представляет use case для откладывания напоминания
+type SnoozeReminderUseCase struct {
+	reminderRepo reminder.Repository
+	durations    map[reminder.Type]time.Duration
+}
+
+// NewSnoozeReminderUseCase создаёт новый use case. durations переопределяет
+// сроки откладывания по умолчанию для отдельных типов напоминаний.
+func NewSnoozeReminderUseCase(reminderRepo reminder.Repository, durations map[reminder.Type]time.Duration) *SnoozeReminderUseCase {
+	return &SnoozeReminderUseCase{
+		reminderRepo: reminderRepo,
+		durations:    durations,
+	}
+}
+
+// SnoozeReminderInput представляет входные данные для откладывания напоминания
+type SnoozeReminderInput struct {
+	UserID     uuid.UUID
+	ReminderID uuid.UUID
+	Duration   *time.Duration // если не задан, используется срок по умолчанию для типа
+}
+
+// Execute откладывает напоминание; уже отправленное будет доставлено повторно
+func (uc *SnoozeReminderUseCase) Execute(ctx context.Context, input SnoozeReminderInput) (*reminder.Reminder, error) {
+	rem, err := uc.reminderRepo.GetByID(ctx, input.ReminderID)
+	if err != nil {
+		return nil, err
+	}
+	if rem.UserID != input.UserID {
+		return nil, reminder.ErrUnauthorized
+	}
+
+	duration := reminder.DefaultSnoozeDuration(rem.Type)
+	if configured, ok := uc.durations[rem.Type]; ok && configured > 0 {
+		duration = configured
+	}
+	if input.Duration != nil {
+		duration = *input.Duration
+	}
+	if err := rem.Snooze(duration); err != nil {
+		return nil, err
+	}
+
+	if err := uc.reminderRepo.Update(ctx, rem); err != nil {
+		return nil, err
+	}
+
+	return rem, nil
+}
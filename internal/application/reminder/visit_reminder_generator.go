@@ -0,0 +1,152 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+	"github.com/health-hub-bot-api/internal/domain/reminder"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+// VisitReminderGenerator создаёт напоминания о визитах к врачу и переносит их вслед за датой визита
+type VisitReminderGenerator struct {
+	doctorVisitRepo doctorvisit.Repository
+	userRepo        user.Repository
+	reminderRepo    reminder.Repository
+	leadDays        int
+}
+
+// NewVisitReminderGenerator создаёт новый генератор напоминаний о визитах
+func NewVisitReminderGenerator(
+	doctorVisitRepo doctorvisit.Repository,
+	userRepo user.Repository,
+	reminderRepo reminder.Repository,
+	leadDays int,
+) *VisitReminderGenerator {
+	return &VisitReminderGenerator{
+		doctorVisitRepo: doctorVisitRepo,
+		userRepo:        userRepo,
+		reminderRepo:    reminderRepo,
+		leadDays:        leadDays,
+	}
+}
+
+// Name возвращает имя задачи для планировщика
+func (g *VisitReminderGenerator) Name() string {
+	return "visit-reminder-generator"
+}
+
+// Run синхронизирует напоминания для ближайших визитов и для визитов,
+// у которых уже есть неотправленные напоминания (дата могла измениться или визит удалён)
+func (g *VisitReminderGenerator) Run(ctx context.Context) error {
+	now := time.Now()
+	// Запас в сутки с обеих сторон покрывает разницу часовых поясов
+	visits, err := g.doctorVisitRepo.FindBetween(ctx, now.AddDate(0, 0, -1), now.AddDate(0, 0, g.leadDays+1))
+	if err != nil {
+		return err
+	}
+
+	synced := make(map[uuid.UUID]bool, len(visits))
+	for _, visit := range visits {
+		if err := g.SyncVisit(ctx, visit); err != nil {
+			return err
+		}
+		synced[visit.ID] = true
+	}
+
+	pending, err := g.reminderRepo.FindPendingByType(ctx, reminder.TypeDoctorVisit)
+	if err != nil {
+		return err
+	}
+	for _, rem := range pending {
+		if rem.RelatedID == nil || synced[*rem.RelatedID] {
+			continue
+		}
+
+		visit, err := g.doctorVisitRepo.GetByID(ctx, *rem.RelatedID)
+		if errors.Is(err, doctorvisit.ErrVisitNotFound) {
+			if err := g.reminderRepo.Delete(ctx, rem.ID); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := g.SyncVisit(ctx, visit); err != nil {
+			return err
+		}
+		synced[visit.ID] = true
+	}
+
+	return nil
+}
+
+// SyncVisit создаёт, переносит или удаляет напоминание о визите в соответствии с его датой
+func (g *VisitReminderGenerator) SyncVisit(ctx context.Context, visit *doctorvisit.DoctorVisit) error {
+	u, err := g.userRepo.GetByID(ctx, visit.UserID)
+	if err != nil {
+		return err
+	}
+	if u == nil || u.DeletedAt != nil {
+		return g.reminderRepo.DeletePendingByRelated(ctx, reminder.TypeDoctorVisit, visit.ID)
+	}
+
+	now := time.Now()
+	loc := u.Location()
+	today := startOfDay(now, loc)
+	visitDay := calendarDay(visit.VisitDate, loc)
+
+	// Визит уже прошёл
+	if visitDay.Before(today) {
+		return g.reminderRepo.DeletePendingByRelated(ctx, reminder.TypeDoctorVisit, visit.ID)
+	}
+
+	at := u.NotificationPreferences.NextAllowedTime(visitDay.AddDate(0, 0, -g.leadDays).Add(reminderClock), loc)
+	// Если визит добавлен позже плановой даты напоминания, оно уйдёт сразу
+	deliveryDay := startOfDay(at, loc)
+	if at.Before(now) {
+		deliveryDay = today
+	}
+	message := visit.ReminderMessage(daysBetween(deliveryDay, visitDay))
+
+	pending, err := g.reminderRepo.FindPendingByRelated(ctx, reminder.TypeDoctorVisit, visit.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(pending) > 0 {
+		for _, extra := range pending[1:] {
+			if err := g.reminderRepo.Delete(ctx, extra.ID); err != nil {
+				return err
+			}
+		}
+
+		rem := pending[0]
+		if rem.ScheduledTime.Equal(at) && rem.Message == message {
+			return nil
+		}
+		// Смена одной специальности меняет только текст, время напоминания сохраняется
+		if !rem.ScheduledTime.Equal(at) {
+			rem.Reschedule(at)
+		}
+		rem.Message = message
+		return g.reminderRepo.Update(ctx, rem)
+	}
+
+	// Напоминание на это время уже отправлено
+	exists, err := g.reminderRepo.Exists(ctx, visit.UserID, reminder.TypeDoctorVisit, &visit.ID, at)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	rem := reminder.NewReminder(visit.UserID, reminder.TypeDoctorVisit, &visit.ID, at, message)
+	return g.reminderRepo.Create(ctx, rem)
+}
@@ -0,0 +1,102 @@
+package sharing
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/sharing"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+// AccessUseCase представляет use case для обращения получателя к данным по доступу
+type AccessUseCase struct {
+	grantRepo     sharing.GrantRepository
+	accessLogRepo sharing.AccessLogRepository
+	userRepo      user.Repository
+}
+
+// NewAccessUseCase создаёт новый use case
+func NewAccessUseCase(
+	grantRepo sharing.GrantRepository,
+	accessLogRepo sharing.AccessLogRepository,
+	userRepo user.Repository,
+) *AccessUseCase {
+	return &AccessUseCase{
+		grantRepo:     grantRepo,
+		accessLogRepo: accessLogRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// ByToken возвращает действующий доступ по токену анонимной ссылки
+func (uc *AccessUseCase) ByToken(ctx context.Context, token string) (*sharing.Grant, error) {
+	grant, err := uc.grantRepo.GetByTokenHash(ctx, sharing.HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if !grant.IsActive(time.Now()) {
+		return nil, sharing.ErrGrantInactive
+	}
+	return grant, nil
+}
+
+// ByGrantee возвращает действующий доступ, выданный пользователю Telegram аккаунта accountID
+func (uc *AccessUseCase) ByGrantee(ctx context.Context, accountID, grantID uuid.UUID) (*sharing.Grant, error) {
+	account, err := uc.account(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	grant, err := uc.grantRepo.GetByID(ctx, grantID)
+	if err != nil {
+		return nil, err
+	}
+	// Чужой доступ неотличим от несуществующего
+	if grant.GranteeTelegramUserID == nil || *grant.GranteeTelegramUserID != account.TelegramUserID {
+		return nil, sharing.ErrGrantNotFound
+	}
+	if !grant.IsActive(time.Now()) {
+		return nil, sharing.ErrGrantInactive
+	}
+	return grant, nil
+}
+
+// SharedWith возвращает действующие доступы, выданные пользователю Telegram аккаунта accountID
+func (uc *AccessUseCase) SharedWith(ctx context.Context, accountID uuid.UUID) ([]*sharing.Grant, error) {
+	account, err := uc.account(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	grants, err := uc.grantRepo.FindByGrantee(ctx, account.TelegramUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	active := make([]*sharing.Grant, 0, len(grants))
+	for _, grant := range grants {
+		if grant.IsActive(now) {
+			active = append(active, grant)
+		}
+	}
+	return active, nil
+}
+
+// Record записывает обращение получателя в журнал владельца
+func (uc *AccessUseCase) Record(ctx context.Context, grant *sharing.Grant, granteeUserID *uuid.UUID, field string) error {
+	return uc.accessLogRepo.Create(ctx, sharing.NewAccessLogEntry(grant, granteeUserID, field))
+}
+
+// account загружает аккаунт получателя
+func (uc *AccessUseCase) account(ctx context.Context, accountID uuid.UUID) (*user.User, error) {
+	account, err := uc.userRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, user.ErrUserNotFound
+	}
+	return account, nil
+}
@@ -0,0 +1,67 @@
+package sharing
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/sharing"
+)
+
+// CreateGrantUseCase представляет use case для выдачи доступа к данным
+type CreateGrantUseCase struct {
+	grantRepo sharing.GrantRepository
+}
+
+// NewCreateGrantUseCase создаёт новый use case
+func NewCreateGrantUseCase(grantRepo sharing.GrantRepository) *CreateGrantUseCase {
+	return &CreateGrantUseCase{
+		grantRepo: grantRepo,
+	}
+}
+
+// CreateGrantInput представляет входные данные для выдачи доступа.
+// Без GranteeTelegramUserID создаётся анонимная ссылка.
+type CreateGrantInput struct {
+	OwnerID               uuid.UUID
+	GranteeTelegramUserID *int64
+	Scopes                []sharing.Scope
+	DataStartDate         *time.Time
+	DataEndDate           *time.Time
+	ExpiresAt             time.Time
+}
+
+// CreateGrantResult представляет выданный доступ; Token задан только у ссылки
+// и возвращается один раз
+type CreateGrantResult struct {
+	Grant *sharing.Grant
+	Token *string
+}
+
+// Execute создаёт доступ только для чтения
+func (uc *CreateGrantUseCase) Execute(ctx context.Context, input CreateGrantInput) (*CreateGrantResult, error) {
+	result := &CreateGrantResult{}
+
+	if input.GranteeTelegramUserID != nil {
+		grant, err := sharing.NewUserGrant(input.OwnerID, *input.GranteeTelegramUserID,
+			input.Scopes, input.DataStartDate, input.DataEndDate, input.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+		result.Grant = grant
+	} else {
+		grant, token, err := sharing.NewLinkGrant(input.OwnerID,
+			input.Scopes, input.DataStartDate, input.DataEndDate, input.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+		result.Grant = grant
+		result.Token = &token
+	}
+
+	if err := uc.grantRepo.Create(ctx, result.Grant); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,92 @@
+package sharing
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	doctorvisitapp "github.com/health-hub-bot-api/internal/application/doctorvisit"
+	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+	"github.com/health-hub-bot-api/internal/domain/sharing"
+)
+
+// CreateReportLinkUseCase представляет use case для создания ссылки на веб-страницу отчёта
+type CreateReportLinkUseCase struct {
+	reportLinkRepo  sharing.ReportLinkRepository
+	doctorVisitRepo doctorvisit.Repository
+	defaultTTL      time.Duration
+	defaultMaxViews int
+}
+
+// NewCreateReportLinkUseCase создаёт новый use case
+func NewCreateReportLinkUseCase(
+	reportLinkRepo sharing.ReportLinkRepository,
+	doctorVisitRepo doctorvisit.Repository,
+	defaultTTL time.Duration,
+	defaultMaxViews int,
+) *CreateReportLinkUseCase {
+	return &CreateReportLinkUseCase{
+		reportLinkRepo:  reportLinkRepo,
+		doctorVisitRepo: doctorVisitRepo,
+		defaultTTL:      defaultTTL,
+		defaultMaxViews: defaultMaxViews,
+	}
+}
+
+// CreateReportLinkInput представляет входные данные для создания ссылки.
+// Без периода отчёт строится за DefaultReportPeriod до текущего момента.
+type CreateReportLinkInput struct {
+	OwnerID   uuid.UUID
+	VisitID   uuid.UUID
+	StartDate *time.Time
+	EndDate   *time.Time
+	TTL       *time.Duration
+	MaxViews  *int
+	PIN       *string
+}
+
+// CreateReportLinkResult представляет созданную ссылку; Token возвращается один раз
+type CreateReportLinkResult struct {
+	Link  *sharing.ReportLink
+	Token string
+}
+
+// Execute создаёт ссылку на отчёт к визиту владельца
+func (uc *CreateReportLinkUseCase) Execute(ctx context.Context, input CreateReportLinkInput) (*CreateReportLinkResult, error) {
+	visit, err := uc.doctorVisitRepo.GetByID(ctx, input.VisitID)
+	if err != nil {
+		return nil, err
+	}
+	if visit.UserID != input.OwnerID {
+		return nil, doctorvisit.ErrUnauthorized
+	}
+
+	endDate := time.Now()
+	if input.EndDate != nil {
+		endDate = *input.EndDate
+	}
+	startDate := endDate.Add(-doctorvisitapp.DefaultReportPeriod)
+	if input.StartDate != nil {
+		startDate = *input.StartDate
+	}
+
+	ttl := uc.defaultTTL
+	if input.TTL != nil {
+		ttl = *input.TTL
+	}
+	maxViews := uc.defaultMaxViews
+	if input.MaxViews != nil {
+		maxViews = *input.MaxViews
+	}
+
+	link, token, err := sharing.NewReportLink(input.OwnerID, visit.ID, startDate, endDate, ttl, maxViews, input.PIN)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.reportLinkRepo.Create(ctx, link); err != nil {
+		return nil, err
+	}
+
+	return &CreateReportLinkResult{Link: link, Token: token}, nil
+}
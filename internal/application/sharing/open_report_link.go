@@ -0,0 +1,90 @@
+package sharing
+
+import (
+	"context"
+	"time"
+
+	doctorvisitapp "github.com/health-hub-bot-api/internal/application/doctorvisit"
+	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+	"github.com/health-hub-bot-api/internal/domain/sharing"
+)
+
+// OpenReportLinkUseCase представляет use case для просмотра отчёта по ссылке
+type OpenReportLinkUseCase struct {
+	reportLinkRepo sharing.ReportLinkRepository
+	generateReport *doctorvisitapp.GenerateReportUseCase
+}
+
+// NewOpenReportLinkUseCase создаёт новый use case
+func NewOpenReportLinkUseCase(
+	reportLinkRepo sharing.ReportLinkRepository,
+	generateReport *doctorvisitapp.GenerateReportUseCase,
+) *OpenReportLinkUseCase {
+	return &OpenReportLinkUseCase{
+		reportLinkRepo: reportLinkRepo,
+		generateReport: generateReport,
+	}
+}
+
+// active возвращает действующую ссылку по токену без учёта просмотра
+func (uc *OpenReportLinkUseCase) active(ctx context.Context, token string) (*sharing.ReportLink, error) {
+	link, err := uc.reportLinkRepo.GetByTokenHash(ctx, sharing.HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if !link.IsActive(time.Now()) {
+		return nil, sharing.ErrReportLinkInactive
+	}
+	return link, nil
+}
+
+// Execute проверяет PIN, учитывает просмотр и строит отчёт за период ссылки
+func (uc *OpenReportLinkUseCase) Execute(ctx context.Context, token string, pin *string) (*sharing.ReportLink, *doctorvisit.Report, error) {
+	link, err := uc.active(ctx, token)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if link.RequiresPIN() {
+		if pin == nil || *pin == "" {
+			return link, nil, sharing.ErrPINRequired
+		}
+		// Попытка учитывается до проверки PIN: иначе параллельные запросы,
+		// прочитавшие один и тот же счётчик, перебирают PIN в обход блокировки
+		reserved, err := uc.reportLinkRepo.ReservePINAttempt(ctx, link.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !reserved {
+			return nil, nil, sharing.ErrReportLinkInactive
+		}
+		if !link.CheckPIN(*pin) {
+			// Учтённая попытка отражается в ссылке, чтобы страница показала блокировку
+			link.FailedPINAttempts++
+			return link, nil, sharing.ErrInvalidPIN
+		}
+		if err := uc.reportLinkRepo.ReleasePINAttempt(ctx, link.ID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	registered, err := uc.reportLinkRepo.RegisterView(ctx, link.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !registered {
+		return nil, nil, sharing.ErrReportLinkInactive
+	}
+
+	report, err := uc.generateReport.Execute(ctx, doctorvisitapp.GenerateReportInput{
+		VisitID:   link.VisitID,
+		UserID:    link.OwnerID,
+		StartDate: link.PeriodStart,
+		EndDate:   link.PeriodEnd,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return link, report, nil
+}
@@ -0,0 +1,39 @@
+package sharing
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/sharing"
+)
+
+// RevokeGrantUseCase представляет use case для отзыва доступа
+type RevokeGrantUseCase struct {
+	grantRepo sharing.GrantRepository
+}
+
+// NewRevokeGrantUseCase создаёт новый use case
+func NewRevokeGrantUseCase(grantRepo sharing.GrantRepository) *RevokeGrantUseCase {
+	return &RevokeGrantUseCase{
+		grantRepo: grantRepo,
+	}
+}
+
+// Execute отзывает доступ. Проверка доступа выполняется на каждый запрос,
+// поэтому отзыв действует сразу.
+func (uc *RevokeGrantUseCase) Execute(ctx context.Context, ownerID, grantID uuid.UUID) (*sharing.Grant, error) {
+	grant, err := uc.grantRepo.GetByID(ctx, grantID)
+	if err != nil {
+		return nil, err
+	}
+	if grant.OwnerID != ownerID {
+		return nil, sharing.ErrUnauthorized
+	}
+
+	grant.Revoke()
+	if err := uc.grantRepo.Update(ctx, grant); err != nil {
+		return nil, err
+	}
+
+	return grant, nil
+}
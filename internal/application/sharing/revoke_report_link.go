@@ -0,0 +1,39 @@
+package sharing
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/sharing"
+)
+
+// RevokeReportLinkUseCase представляет use case для отзыва ссылки на отчёт
+type RevokeReportLinkUseCase struct {
+	reportLinkRepo sharing.ReportLinkRepository
+}
+
+// NewRevokeReportLinkUseCase создаёт новый use case
+func NewRevokeReportLinkUseCase(reportLinkRepo sharing.ReportLinkRepository) *RevokeReportLinkUseCase {
+	return &RevokeReportLinkUseCase{
+		reportLinkRepo: reportLinkRepo,
+	}
+}
+
+// Execute отзывает ссылку; страница проверяет ссылку на каждый просмотр,
+// поэтому отзыв действует сразу
+func (uc *RevokeReportLinkUseCase) Execute(ctx context.Context, ownerID, linkID uuid.UUID) (*sharing.ReportLink, error) {
+	link, err := uc.reportLinkRepo.GetByID(ctx, linkID)
+	if err != nil {
+		return nil, err
+	}
+	if link.OwnerID != ownerID {
+		return nil, sharing.ErrUnauthorized
+	}
+
+	link.Revoke()
+	if err := uc.reportLinkRepo.Revoke(ctx, link); err != nil {
+		return nil, err
+	}
+
+	return link, nil
+}
@@ -2,22 +2,31 @@ package symptom
 
 import (
 	"context"
+	"log"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/engagement"
 	"github.com/health-hub-bot-api/internal/domain/symptom"
 )
 
+// MilestoneChecker отмечает вехи серий после новой записи
+type MilestoneChecker interface {
+	CheckMilestones(ctx context.Context, userID uuid.UUID) ([]*engagement.Milestone, error)
+}
+
 // CreateSymptomUseCase представляет use case для создания записи симптома
 type CreateSymptomUseCase struct {
-	symptomRepo symptom.Repository
+	symptomRepo      symptom.Repository
+	milestoneChecker MilestoneChecker
 	// fileStorage для загрузки фото (будет добавлен позже)
 }
 
 // NewCreateSymptomUseCase создаёт новый use case
-func NewCreateSymptomUseCase(symptomRepo symptom.Repository) *CreateSymptomUseCase {
+func NewCreateSymptomUseCase(symptomRepo symptom.Repository, milestoneChecker MilestoneChecker) *CreateSymptomUseCase {
 	return &CreateSymptomUseCase{
-		symptomRepo: symptomRepo,
+		symptomRepo:      symptomRepo,
+		milestoneChecker: milestoneChecker,
 	}
 }
 
@@ -75,5 +84,12 @@ func (uc *CreateSymptomUseCase) Execute(ctx context.Context, input CreateSymptom
 		return nil, err
 	}
 
+	// Поздравление с вехой не должно мешать сохранению записи
+	if uc.milestoneChecker != nil {
+		if _, err := uc.milestoneChecker.CheckMilestones(ctx, entry.UserID); err != nil {
+			log.Printf("failed to check milestones for user %s: %v", entry.UserID, err)
+		}
+	}
+
 	return entry, nil
 }
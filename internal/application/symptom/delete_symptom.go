@@ -0,0 +1,33 @@
+package symptom
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/symptom"
+)
+
+// DeleteSymptomUseCase представляет use case для удаления записи симптома
+type DeleteSymptomUseCase struct {
+	symptomRepo symptom.Repository
+}
+
+// NewDeleteSymptomUseCase создаёт новый use case
+func NewDeleteSymptomUseCase(symptomRepo symptom.Repository) *DeleteSymptomUseCase {
+	return &DeleteSymptomUseCase{
+		symptomRepo: symptomRepo,
+	}
+}
+
+// Execute удаляет запись симптома пользователя
+func (uc *DeleteSymptomUseCase) Execute(ctx context.Context, userID, entryID uuid.UUID) error {
+	entry, err := uc.symptomRepo.GetByID(ctx, entryID)
+	if err != nil {
+		return err
+	}
+	if entry.UserID != userID {
+		return symptom.ErrUnauthorized
+	}
+
+	return uc.symptomRepo.Delete(ctx, entry.ID)
+}
@@ -0,0 +1,63 @@
+package symptom
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/symptom"
+)
+
+// UpdateSymptomUseCase представляет use case для изменения записи симптома
+type UpdateSymptomUseCase struct {
+	symptomRepo symptom.Repository
+}
+
+// NewUpdateSymptomUseCase создаёт новый use case
+func NewUpdateSymptomUseCase(symptomRepo symptom.Repository) *UpdateSymptomUseCase {
+	return &UpdateSymptomUseCase{
+		symptomRepo: symptomRepo,
+	}
+}
+
+// UpdateSymptomInput представляет входные данные; nil-поля не меняются
+type UpdateSymptomInput struct {
+	UserID                 uuid.UUID
+	EntryID                uuid.UUID
+	DateTime               *time.Time
+	Description            *string
+	WellbeingScale         *int
+	Temperature            *float64
+	BloodPressureSystolic  *int
+	BloodPressureDiastolic *int
+	Pulse                  *int
+}
+
+// Execute изменяет запись симптома пользователя
+func (uc *UpdateSymptomUseCase) Execute(ctx context.Context, input UpdateSymptomInput) (*symptom.SymptomEntry, error) {
+	entry, err := uc.symptomRepo.GetByID(ctx, input.EntryID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.UserID != input.UserID {
+		return nil, symptom.ErrUnauthorized
+	}
+
+	if err := entry.Update(
+		input.DateTime,
+		input.Description,
+		input.WellbeingScale,
+		input.Temperature,
+		input.BloodPressureSystolic,
+		input.BloodPressureDiastolic,
+		input.Pulse,
+		nil,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := uc.symptomRepo.Update(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
@@ -0,0 +1,88 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+// AccountDeletion представляет запланированное удаление аккаунта
+type AccountDeletion struct {
+	RequestedAt time.Time
+	PurgeAt     time.Time // после этого момента данные удаляются безвозвратно
+}
+
+// AccountDeletionUseCase представляет use case для удаления аккаунта со сроком восстановления
+type AccountDeletionUseCase struct {
+	userRepo user.Repository
+	grace    time.Duration
+}
+
+// NewAccountDeletionUseCase создаёт новый use case; grace — срок, в течение
+// которого удалённый аккаунт можно восстановить
+func NewAccountDeletionUseCase(userRepo user.Repository, grace time.Duration) *AccountDeletionUseCase {
+	return &AccountDeletionUseCase{
+		userRepo: userRepo,
+		grace:    grace,
+	}
+}
+
+// Delete помечает аккаунт владельца к удалению вместе с подопечными профилями.
+// Данные стираются задачей AccountPurger по истечении срока восстановления.
+func (uc *AccountDeletionUseCase) Delete(ctx context.Context, accountID uuid.UUID) (*AccountDeletion, error) {
+	account, err := uc.userRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, user.ErrUserNotFound
+	}
+
+	if err := account.RequestDeletion(); err != nil {
+		return nil, err
+	}
+	if err := uc.userRepo.Delete(ctx, account.ID); err != nil {
+		return nil, err
+	}
+
+	return uc.deletion(account), nil
+}
+
+// Restore отменяет удаление аккаунта, пока не истёк срок восстановления
+func (uc *AccountDeletionUseCase) Restore(ctx context.Context, accountID uuid.UUID) (*user.User, error) {
+	account, err := uc.userRepo.GetDeletedByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, user.ErrAccountNotDeleted
+	}
+
+	if err := account.Restore(time.Now(), uc.grace); err != nil {
+		return nil, err
+	}
+	if err := uc.userRepo.Restore(ctx, account.ID); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// Status возвращает запланированное удаление аккаунта или nil, если аккаунт не удалялся
+func (uc *AccountDeletionUseCase) Status(ctx context.Context, accountID uuid.UUID) (*AccountDeletion, error) {
+	account, err := uc.userRepo.GetDeletedByID(ctx, accountID)
+	if err != nil || account == nil {
+		return nil, err
+	}
+	return uc.deletion(account), nil
+}
+
+// deletion возвращает сроки удаления аккаунта
+func (uc *AccountDeletionUseCase) deletion(account *user.User) *AccountDeletion {
+	return &AccountDeletion{
+		RequestedAt: *account.DeletedAt,
+		PurgeAt:     account.PurgeAt(uc.grace),
+	}
+}
@@ -0,0 +1,187 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/analysis"
+	"github.com/health-hub-bot-api/internal/domain/export"
+	"github.com/health-hub-bot-api/internal/domain/filestorage"
+	"github.com/health-hub-bot-api/internal/domain/notification"
+	"github.com/health-hub-bot-api/internal/domain/symptom"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+// purgePageSize — размер страницы при поиске файлов профиля
+const purgePageSize = 500
+
+// AccountPurger безвозвратно удаляет аккаунты и профили, срок восстановления
+// которых истёк: сначала файлы из хранилища, затем строки пользователя — все
+// связанные данные удаляются каскадно. Владельцу аккаунта отправляется
+// подписанная квитанция об удалении.
+type AccountPurger struct {
+	userRepo      user.Repository
+	symptomRepo   symptom.Repository
+	analysisRepo  analysis.Repository
+	exportJobRepo export.JobRepository
+	files         filestorage.Storage
+	notifier      notification.Notifier
+	grace         time.Duration
+	receiptKey    string
+}
+
+// NewAccountPurger создаёт новую задачу удаления аккаунтов; receiptKey — секрет подписи квитанций
+func NewAccountPurger(
+	userRepo user.Repository,
+	symptomRepo symptom.Repository,
+	analysisRepo analysis.Repository,
+	exportJobRepo export.JobRepository,
+	files filestorage.Storage,
+	notifier notification.Notifier,
+	grace time.Duration,
+	receiptKey string,
+) *AccountPurger {
+	return &AccountPurger{
+		userRepo:      userRepo,
+		symptomRepo:   symptomRepo,
+		analysisRepo:  analysisRepo,
+		exportJobRepo: exportJobRepo,
+		files:         files,
+		notifier:      notifier,
+		grace:         grace,
+		receiptKey:    receiptKey,
+	}
+}
+
+// Name возвращает имя задачи для планировщика
+func (p *AccountPurger) Name() string {
+	return "account-purger"
+}
+
+// Run удаляет аккаунты, срок восстановления которых истёк
+func (p *AccountPurger) Run(ctx context.Context) error {
+	users, err := p.userRepo.FindDeletedBefore(ctx, time.Now().Add(-p.grace))
+	if err != nil {
+		return err
+	}
+
+	for _, u := range users {
+		if err := p.purge(ctx, u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// purge удаляет профиль вместе с подопечными и отправляет квитанцию владельцу аккаунта
+func (p *AccountPurger) purge(ctx context.Context, u *user.User) error {
+	profileIDs := []uuid.UUID{u.ID}
+	if !u.IsDependent() {
+		dependentIDs, err := p.userRepo.FindDependentIDs(ctx, u.ID)
+		if err != nil {
+			return err
+		}
+		profileIDs = append(profileIDs, dependentIDs...)
+	}
+
+	// Файлы удаляются до строк: после каскадного удаления ссылок на них не останется
+	files := 0
+	for _, profileID := range profileIDs {
+		fileURLs, err := p.storedFiles(ctx, profileID)
+		if err != nil {
+			return err
+		}
+		for _, fileURL := range fileURLs {
+			if err := p.files.Delete(ctx, fileURL); err != nil {
+				return err
+			}
+		}
+		files += len(fileURLs)
+	}
+
+	if err := p.userRepo.Purge(ctx, u.ID); err != nil {
+		return err
+	}
+	if u.IsDependent() {
+		return nil
+	}
+
+	// Данные уже удалены, поэтому ошибка доставки квитанции не повторяется
+	receipt := user.NewDeletionReceipt(u, len(profileIDs), files)
+	if err := p.notifier.Send(ctx, u.TelegramUserID, notification.Message{Text: p.receiptText(receipt)}); err != nil {
+		log.Printf("account purger: failed to send deletion receipt %s: %v", receipt.ID, err)
+	}
+	return nil
+}
+
+// storedFiles возвращает файлы профиля в хранилище: фото симптомов, файлы анализов и архивы выгрузок
+func (p *AccountPurger) storedFiles(ctx context.Context, profileID uuid.UUID) ([]string, error) {
+	var fileURLs []string
+
+	for offset := 0; ; offset += purgePageSize {
+		entries, total, err := p.symptomRepo.FindByFilter(ctx, symptom.Filter{UserID: profileID}, purgePageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if entry.PhotoURL != nil && *entry.PhotoURL != "" {
+				fileURLs = append(fileURLs, *entry.PhotoURL)
+			}
+		}
+		if len(entries) == 0 || offset+len(entries) >= total {
+			break
+		}
+	}
+
+	for offset := 0; ; offset += purgePageSize {
+		analyses, total, err := p.analysisRepo.FindByFilter(ctx, analysis.Filter{UserID: profileID}, purgePageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range analyses {
+			if a.FileURL != "" {
+				fileURLs = append(fileURLs, a.FileURL)
+			}
+		}
+		if len(analyses) == 0 || offset+len(analyses) >= total {
+			break
+		}
+	}
+
+	jobs, err := p.exportJobRepo.FindByUserID(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		if job.ArchiveURL != nil {
+			fileURLs = append(fileURLs, *job.ArchiveURL)
+		}
+	}
+
+	return fileURLs, nil
+}
+
+// receiptText форматирует квитанцию об удалении для отправки в чат
+func (p *AccountPurger) receiptText(receipt *user.DeletionReceipt) string {
+	return fmt.Sprintf(
+		"🧾 Квитанция об удалении аккаунта\n\n"+
+			"Номер: %s\n"+
+			"Аккаунт: %s (Telegram ID %d)\n"+
+			"Удаление запрошено: %s\n"+
+			"Данные удалены: %s\n"+
+			"Профилей: %d, файлов: %d\n\n"+
+			"Все ваши записи, анализы, лекарства и файлы удалены безвозвратно.\n"+
+			"Подпись: %s",
+		receipt.ID,
+		receipt.AccountID,
+		receipt.TelegramUserID,
+		receipt.RequestedAt.Format(time.RFC3339),
+		receipt.PurgedAt.Format(time.RFC3339),
+		receipt.Profiles,
+		receipt.Files,
+		receipt.Sign(p.receiptKey),
+	)
+}
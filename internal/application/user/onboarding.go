@@ -0,0 +1,119 @@
+package user
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/analysis"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/symptom"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+// ConsentChecker проверяет, что владелец аккаунта дал согласие на текущую версию политики
+type ConsentChecker interface {
+	Check(ctx context.Context, accountID uuid.UUID) error
+}
+
+// OnboardingUseCase представляет use case для прохождения онбординга.
+// Переходы проверяются на сервере, поэтому бот и WebApp показывают один и тот же шаг.
+type OnboardingUseCase struct {
+	userRepo       user.Repository
+	consents       ConsentChecker
+	symptomRepo    symptom.Repository
+	analysisRepo   analysis.Repository
+	medicationRepo medication.Repository
+}
+
+// NewOnboardingUseCase создаёт новый use case
+func NewOnboardingUseCase(
+	userRepo user.Repository,
+	consents ConsentChecker,
+	symptomRepo symptom.Repository,
+	analysisRepo analysis.Repository,
+	medicationRepo medication.Repository,
+) *OnboardingUseCase {
+	return &OnboardingUseCase{
+		userRepo:       userRepo,
+		consents:       consents,
+		symptomRepo:    symptomRepo,
+		analysisRepo:   analysisRepo,
+		medicationRepo: medicationRepo,
+	}
+}
+
+// Execute выполняет или пропускает (skip) текущий шаг онбординга владельца аккаунта.
+// Выполнить шаг можно, только если выполнены его условия: дано согласие,
+// заполнены возраст и пол, добавлена первая запись.
+func (uc *OnboardingUseCase) Execute(ctx context.Context, accountID uuid.UUID, step user.OnboardingStep, skip bool) (*user.User, error) {
+	account, err := uc.userRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, user.ErrUserNotFound
+	}
+	if account.IsDependent() {
+		return nil, user.ErrOnboardingNotApplicable
+	}
+
+	if skip {
+		err = account.Onboarding.Skip(step)
+	} else {
+		// Условия проверяются только для текущего шага; порядок проверяет Complete
+		if current := account.Onboarding.CurrentStep(); current != nil && *current == step {
+			if err := uc.checkStep(ctx, account, step); err != nil {
+				return nil, err
+			}
+		}
+		err = account.Onboarding.Complete(step)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.userRepo.UpdateOnboarding(ctx, account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// checkStep проверяет условия выполнения шага
+func (uc *OnboardingUseCase) checkStep(ctx context.Context, account *user.User, step user.OnboardingStep) error {
+	switch step {
+	case user.OnboardingStepConsent:
+		return uc.consents.Check(ctx, account.ID)
+	case user.OnboardingStepProfile:
+		if account.Age == nil || account.Gender == nil {
+			return user.ErrOnboardingStepIncomplete
+		}
+	case user.OnboardingStepFirstAction:
+		hasRecords, err := uc.hasRecords(ctx, account.ID)
+		if err != nil {
+			return err
+		}
+		if !hasRecords {
+			return user.ErrOnboardingStepIncomplete
+		}
+	}
+	return nil
+}
+
+// hasRecords проверяет, что у профиля есть хотя бы один симптом, анализ или лекарство
+func (uc *OnboardingUseCase) hasRecords(ctx context.Context, userID uuid.UUID) (bool, error) {
+	_, symptoms, err := uc.symptomRepo.FindByFilter(ctx, symptom.Filter{UserID: userID}, 1, 0)
+	if err != nil || symptoms > 0 {
+		return symptoms > 0, err
+	}
+
+	_, analyses, err := uc.analysisRepo.FindByFilter(ctx, analysis.Filter{UserID: userID}, 1, 0)
+	if err != nil || analyses > 0 {
+		return analyses > 0, err
+	}
+
+	medications, err := uc.medicationRepo.FindByUserID(ctx, userID, false)
+	if err != nil {
+		return false, err
+	}
+	return len(medications) > 0, nil
+}
@@ -0,0 +1,103 @@
+package user
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+// ProfilesUseCase представляет use case для управления подопечными профилями аккаунта
+type ProfilesUseCase struct {
+	userRepo user.Repository
+}
+
+// NewProfilesUseCase создаёт новый use case
+func NewProfilesUseCase(userRepo user.Repository) *ProfilesUseCase {
+	return &ProfilesUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// CreateDependentInput представляет входные данные для создания подопечного профиля
+type CreateDependentInput struct {
+	AccountID uuid.UUID
+	Name      string
+	Age       *int
+	Gender    *user.Gender
+}
+
+// List возвращает профиль владельца аккаунта и его подопечных
+func (uc *ProfilesUseCase) List(ctx context.Context, accountID uuid.UUID) ([]*user.User, error) {
+	account, err := uc.account(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	dependents, err := uc.userRepo.FindDependents(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]*user.User{account}, dependents...), nil
+}
+
+// CreateDependent создаёт подопечный профиль владельца аккаунта
+func (uc *ProfilesUseCase) CreateDependent(ctx context.Context, input CreateDependentInput) (*user.User, error) {
+	account, err := uc.account(ctx, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	dependent, err := user.NewDependent(account, input.Name, input.Age, input.Gender)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.userRepo.Create(ctx, dependent); err != nil {
+		return nil, err
+	}
+
+	return dependent, nil
+}
+
+// DeleteDependent удаляет подопечный профиль. Его напоминания перестают
+// доставляться, как и у любого удалённого пользователя.
+func (uc *ProfilesUseCase) DeleteDependent(ctx context.Context, accountID, profileID uuid.UUID) error {
+	profile, err := uc.Resolve(ctx, accountID, profileID)
+	if err != nil {
+		return err
+	}
+	if !profile.IsDependent() {
+		return user.ErrNotAccountHolder
+	}
+
+	return uc.userRepo.Delete(ctx, profile.ID)
+}
+
+// Resolve возвращает профиль, с которым может работать владелец аккаунта
+func (uc *ProfilesUseCase) Resolve(ctx context.Context, accountID, profileID uuid.UUID) (*user.User, error) {
+	profile, err := uc.userRepo.GetByID(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil || !profile.IsManagedBy(accountID) {
+		return nil, user.ErrProfileNotFound
+	}
+	return profile, nil
+}
+
+// account загружает профиль владельца аккаунта
+func (uc *ProfilesUseCase) account(ctx context.Context, accountID uuid.UUID) (*user.User, error) {
+	account, err := uc.userRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, user.ErrUserNotFound
+	}
+	if account.IsDependent() {
+		return nil, user.ErrNotAccountHolder
+	}
+	return account, nil
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"gorm.io/gorm/logger"
 )
@@ -21,6 +23,33 @@ type Config struct {
 
 	// Storage
 	Storage StorageConfig
+
+	// Scheduler
+	Scheduler SchedulerConfig
+
+	// Reminders
+	Reminders RemindersConfig
+
+	// Interactions
+	Interactions InteractionsConfig
+
+	// ReportLinks
+	ReportLinks ReportLinksConfig
+
+	// Export
+	Export ExportConfig
+
+	// Account
+	Account AccountConfig
+
+	// Encryption
+	Encryption EncryptionConfig
+
+	// Audit
+	Audit AuditConfig
+
+	// Consent
+	Consent ConsentConfig
 }
 
 // DatabaseConfig представляет конфигурацию базы данных
@@ -45,13 +74,17 @@ type DatabaseConfig struct {
 
 // ServerConfig представляет конфигурацию сервера
 type ServerConfig struct {
-	Port string
-	Host string
+	Port      string
+	Host      string
+	PublicURL string // внешний адрес сервера для ссылок, которые открываются вне WebApp
 }
 
 // TelegramConfig представляет конфигурацию Telegram
 type TelegramConfig struct {
 	BotToken string
+	// WebhookSecret проверяется в заголовке X-Telegram-Bot-Api-Secret-Token;
+	// пока он не задан, приём обновлений бота выключен
+	WebhookSecret string
 }
 
 // StorageConfig представляет конфигурацию хранилища файлов
@@ -64,6 +97,64 @@ type StorageConfig struct {
 	S3SecretAccessKey string
 	S3Region          string
 	S3Bucket          string
+
+	SigningKey string // секрет для подписи временных ссылок на файлы
+}
+
+// SchedulerConfig представляет конфигурацию фоновых задач
+type SchedulerConfig struct {
+	Enabled  bool          // запускать ли фоновые задачи в этом процессе
+	Interval time.Duration // интервал проверки напоминаний
+}
+
+// RemindersConfig представляет настройки автоматических напоминаний
+type RemindersConfig struct {
+	AnalysisLeadDays    []int // за сколько дней до повторного анализа напоминать
+	DoctorVisitLeadDays int   // за сколько дней до визита к врачу напоминать
+
+	MedicationMissedGrace   time.Duration // через сколько неотмеченный приём считается пропущенным
+	MedicationFollowUpDelay time.Duration // через сколько после приёма прислать повторное напоминание
+	MedicationSnooze        time.Duration // на сколько откладывается напоминание о приёме
+	RefillLeadDays          int           // за сколько дней до окончания запаса напоминать о пополнении
+}
+
+// InteractionsConfig представляет настройки проверки сочетаний лекарств
+type InteractionsConfig struct {
+	DatasetPath string // путь к локальному JSON-файлу с составом лекарств и взаимодействиями
+}
+
+// ReportLinksConfig представляет настройки ссылок на веб-страницу отчёта для врача
+type ReportLinksConfig struct {
+	TTL        time.Duration // срок действия ссылки по умолчанию
+	MaxViews   int           // число просмотров ссылки по умолчанию
+	FileURLTTL time.Duration // срок действия ссылок на файлы анализов со страницы отчёта
+}
+
+// ExportConfig представляет настройки выгрузки данных профиля
+type ExportConfig struct {
+	LinkTTL time.Duration // сколько готовый архив доступен для скачивания
+}
+
+// AccountConfig представляет настройки удаления аккаунта
+type AccountConfig struct {
+	DeletionGrace     time.Duration // сколько удалённый аккаунт можно восстановить до безвозвратного удаления
+	ReceiptSigningKey string        // секрет для подписи квитанций об удалении
+}
+
+// EncryptionConfig представляет настройки шифрования чувствительных данных
+type EncryptionConfig struct {
+	MasterKeys      map[string]string // мастер-ключи AES-256 в base64 по ID; пусто — шифрование выключено
+	ActiveMasterKey string            // ID мастер-ключа, которым шифруются новые ключи данных и файлов
+}
+
+// AuditConfig представляет настройки журнала аудита
+type AuditConfig struct {
+	Retention time.Duration // сколько хранятся записи журнала
+}
+
+// ConsentConfig представляет настройки согласия на обработку персональных данных
+type ConsentConfig struct {
+	PolicyVersion string // действующая версия политики; после смены согласие запрашивается заново
 }
 
 // Load загружает конфигурацию из переменных окружения
@@ -122,13 +213,15 @@ func Load() (*Config, error) {
 
 	// Server
 	cfg.Server = ServerConfig{
-		Port: getEnv("PORT", "8080"),
-		Host: getEnv("HOST", ""),
+		Port:      getEnv("PORT", "8080"),
+		Host:      getEnv("HOST", ""),
+		PublicURL: strings.TrimSuffix(os.Getenv("PUBLIC_URL"), "/"),
 	}
 
 	// Telegram
 	cfg.Telegram = TelegramConfig{
-		BotToken: os.Getenv("TELEGRAM_BOT_TOKEN"),
+		BotToken:      os.Getenv("TELEGRAM_BOT_TOKEN"),
+		WebhookSecret: os.Getenv("TELEGRAM_WEBHOOK_SECRET"),
 	}
 
 	// Storage
@@ -140,6 +233,69 @@ func Load() (*Config, error) {
 		S3SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
 		S3Region:          os.Getenv("AWS_REGION"),
 		S3Bucket:          os.Getenv("S3_BUCKET"),
+		// Без отдельного ключа подпись производится от токена бота
+		SigningKey: getEnv("FILE_URL_SIGNING_KEY", cfg.Telegram.BotToken),
+	}
+
+	// Scheduler
+	cfg.Scheduler = SchedulerConfig{
+		Enabled:  getEnvBool("SCHEDULER_ENABLED", true),
+		Interval: getEnvDuration("SCHEDULER_INTERVAL", time.Minute),
+	}
+
+	// Reminders
+	cfg.Reminders = RemindersConfig{
+		AnalysisLeadDays:    getEnvIntList("ANALYSIS_REMINDER_LEAD_DAYS", []int{7, 1}),
+		DoctorVisitLeadDays: getEnvInt("DOCTOR_VISIT_REMINDER_LEAD_DAYS", 1),
+
+		MedicationMissedGrace:   getEnvDuration("MEDICATION_MISSED_GRACE", 2*time.Hour),
+		MedicationFollowUpDelay: getEnvDuration("MEDICATION_FOLLOW_UP_DELAY", 30*time.Minute),
+		MedicationSnooze:        getEnvDuration("MEDICATION_SNOOZE", 15*time.Minute),
+		RefillLeadDays:          getEnvInt("MEDICATION_REFILL_LEAD_DAYS", 5),
+	}
+
+	// Interactions
+	cfg.Interactions = InteractionsConfig{
+		DatasetPath: getEnv("INTERACTIONS_DATASET_PATH", "./data/interactions.json"),
+	}
+
+	// ReportLinks
+	cfg.ReportLinks = ReportLinksConfig{
+		TTL:        getEnvDuration("REPORT_LINK_TTL", 24*time.Hour),
+		MaxViews:   getEnvInt("REPORT_LINK_MAX_VIEWS", 3),
+		FileURLTTL: getEnvDuration("REPORT_FILE_URL_TTL", time.Hour),
+	}
+
+	// Export
+	cfg.Export = ExportConfig{
+		LinkTTL: getEnvDuration("EXPORT_LINK_TTL", 72*time.Hour),
+	}
+
+	// Account
+	cfg.Account = AccountConfig{
+		DeletionGrace: getEnvDuration("ACCOUNT_DELETION_GRACE", 30*24*time.Hour),
+		// Без отдельного ключа подпись производится от токена бота
+		ReceiptSigningKey: getEnv("DELETION_RECEIPT_SIGNING_KEY", cfg.Telegram.BotToken),
+	}
+
+	// Encryption
+	masterKeys, err := getEnvKeyMap("ENCRYPTION_MASTER_KEYS")
+	if err != nil {
+		return nil, err
+	}
+	cfg.Encryption = EncryptionConfig{
+		MasterKeys:      masterKeys,
+		ActiveMasterKey: os.Getenv("ENCRYPTION_ACTIVE_MASTER_KEY"),
+	}
+
+	// Audit
+	cfg.Audit = AuditConfig{
+		Retention: getEnvDuration("AUDIT_LOG_RETENTION", 365*24*time.Hour),
+	}
+
+	// Consent
+	cfg.Consent = ConsentConfig{
+		PolicyVersion: getEnv("CONSENT_POLICY_VERSION", "1"),
 	}
 
 	return cfg, nil
@@ -163,3 +319,58 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvBool возвращает значение переменной окружения как bool или значение по умолчанию
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvDuration возвращает значение переменной окружения как time.Duration (например, "1m") или значение по умолчанию
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if duration, err := time.ParseDuration(value); err == nil && duration > 0 {
+			return duration
+		}
+	}
+	return defaultValue
+}
+
+// getEnvIntList возвращает значение переменной окружения как список int через запятую или значение по умолчанию
+func getEnvIntList(key string, defaultValue []int) []int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var result []int
+	for _, part := range strings.Split(value, ",") {
+		intValue, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || intValue < 0 {
+			return defaultValue
+		}
+		result = append(result, intValue)
+	}
+	return result
+}
+
+// getEnvKeyMap возвращает значение переменной окружения как список пар "ID:значение" через запятую
+func getEnvKeyMap(key string) (map[string]string, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	result := make(map[string]string)
+	for _, part := range strings.Split(value, ",") {
+		id, entry, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok || id == "" || entry == "" {
+			return nil, fmt.Errorf("%s must be a comma-separated list of id:value pairs", key)
+		}
+		result[id] = entry
+	}
+	return result, nil
+}
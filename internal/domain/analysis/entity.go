@@ -16,6 +16,8 @@ type Analysis struct {
 	FileURL         string
 	FileType        FileType
 	NextReminderDate *time.Time
+	// FollowUpAnalysisID — повторный анализ, сданный по напоминанию
+	FollowUpAnalysisID *uuid.UUID
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 }
@@ -104,3 +106,12 @@ func (a *Analysis) ClearReminder() {
 	a.UpdatedAt = time.Now()
 }
 
+// CompleteReminder закрывает напоминание о повторном анализе
+// и при наличии связывает анализ с новым результатом
+func (a *Analysis) CompleteReminder(followUpID *uuid.UUID) {
+	a.NextReminderDate = nil
+	if followUpID != nil {
+		a.FollowUpAnalysisID = followUpID
+	}
+	a.UpdatedAt = time.Now()
+}
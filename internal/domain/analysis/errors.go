@@ -0,0 +1,10 @@
+package analysis
+
+import "errors"
+
+var (
+	ErrAnalysisNotFound = errors.New("analysis not found")
+	ErrUnauthorized     = errors.New("unauthorized access to analysis")
+	ErrInvalidFollowUp  = errors.New("analysis cannot be its own follow-up")
+	ErrUnsupportedFile  = errors.New("analysis file must be a PDF, JPEG or PNG")
+)
@@ -0,0 +1,19 @@
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/health-hub-bot-api/internal/domain/plural"
+)
+
+// ReminderMessage возвращает текст напоминания о повторном анализе за leadDays дней до даты
+func (a *Analysis) ReminderMessage(leadDays int) string {
+	switch leadDays {
+	case 0:
+		return fmt.Sprintf("Сегодня пора сдать повторный анализ «%s».", a.Name)
+	case 1:
+		return fmt.Sprintf("Завтра пора сдать повторный анализ «%s».", a.Name)
+	default:
+		return fmt.Sprintf("Через %s пора сдать повторный анализ «%s».", plural.Days(leadDays), a.Name)
+	}
+}
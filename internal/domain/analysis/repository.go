@@ -37,5 +37,8 @@ type Repository interface {
 	
 	// GetUpcomingReminders возвращает анализы с предстоящими напоминаниями
 	GetUpcomingReminders(ctx context.Context, userID uuid.UUID, beforeDate time.Time) ([]*Analysis, error)
+	
+	// FindWithReminderBefore возвращает анализы всех пользователей с датой повторного анализа не позже beforeDate
+	FindWithReminderBefore(ctx context.Context, beforeDate time.Time) ([]*Analysis, error)
 }
 
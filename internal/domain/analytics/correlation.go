@@ -0,0 +1,61 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CorrelationDisclaimer сопровождает любую статистику по связи симптомов и лекарств
+const CorrelationDisclaimer = "Это описательная статистика, а не медицинский вывод. Интерпретацию оставим врачу."
+
+// SymptomMedicationCorrelation представляет сопоставление самочувствия с курсом лекарства.
+// Содержит только описательные показатели без выводов о причинах.
+type SymptomMedicationCorrelation struct {
+	MedicationID          uuid.UUID
+	MedicationName        string
+	StartDate             time.Time
+	EndDate               time.Time
+	BeforeCourse          WellbeingStats
+	DuringCourse          WellbeingStats
+	AfterCourse           WellbeingStats
+	DaysWithMissedDose    WellbeingStats
+	DaysWithAllDosesTaken WellbeingStats
+	AdherenceRate         *float64
+	Disclaimer            string
+}
+
+// WellbeingStats представляет статистику среднего дневного самочувствия по группе дней
+type WellbeingStats struct {
+	Days    int
+	Average *float64
+	Min     *float64
+	Max     *float64
+}
+
+// NewWellbeingStats считает статистику по средним значениям самочувствия за дни
+func NewWellbeingStats(dailyAverages []float64) WellbeingStats {
+	stats := WellbeingStats{Days: len(dailyAverages)}
+	if len(dailyAverages) == 0 {
+		return stats
+	}
+
+	sum := 0.0
+	min := dailyAverages[0]
+	max := dailyAverages[0]
+	for _, v := range dailyAverages {
+		sum += v
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+
+	average := sum / float64(len(dailyAverages))
+	stats.Average = &average
+	stats.Min = &min
+	stats.Max = &max
+	return stats
+}
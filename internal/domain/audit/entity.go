@@ -0,0 +1,101 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Action — действие с данными
+type Action string
+
+const (
+	ActionRead   Action = "read"
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// ActorKind — кто обратился к данным профиля
+type ActorKind string
+
+const (
+	// ActorOwner — сам профиль (владелец аккаунта Telegram)
+	ActorOwner ActorKind = "owner"
+	// ActorAccountHolder — владелец аккаунта, который ведёт подопечный профиль
+	ActorAccountHolder ActorKind = "account_holder"
+	// ActorGrantee — пользователь Telegram, которому выдан доступ
+	ActorGrantee ActorKind = "grantee"
+	// ActorShareLink — анонимная ссылка доступа
+	ActorShareLink ActorKind = "share_link"
+	// ActorReportLink — врач по одноразовой ссылке на отчёт
+	ActorReportLink ActorKind = "report_link"
+	// ActorSignedLink — скачивание файла по подписанной ссылке
+	ActorSignedLink ActorKind = "signed_link"
+)
+
+// Типы сущностей в журнале
+const (
+	EntityUser              = "user"
+	EntityConsent           = "consent"
+	EntityReminder          = "reminder"
+	EntityDashboard         = "dashboard"
+	EntitySymptomEntry      = "symptom_entry"
+	EntityAnalysis          = "analysis"
+	EntityMedication        = "medication"
+	EntityMedicationIntake  = "medication_intake"
+	EntityDoctorVisit       = "doctor_visit"
+	EntityDoctorVisitReport = "doctor_visit_report"
+	EntityShareGrant        = "share_grant"
+	EntityReportLink        = "report_link"
+	EntityDataExport        = "data_export"
+	EntityDataImport        = "data_import"
+)
+
+// Actor описывает того, кто обратился к данным
+type Actor struct {
+	Kind ActorKind
+	// UserID — аккаунт, от имени которого выполнен запрос; не задан для анонимных ссылок
+	UserID *uuid.UUID
+	// CredentialID — доступ или ссылка, по которой выполнен запрос
+	CredentialID *uuid.UUID
+}
+
+// IsOwner проверяет, что к данным обратился сам профиль
+func (a Actor) IsOwner() bool {
+	return a.Kind == ActorOwner
+}
+
+// Entry представляет запись журнала аудита. Журнал только пополняется:
+// записи не изменяются и удаляются лишь по сроку хранения или вместе с профилем.
+// Значения полей не записываются — только их имена, поэтому в журнал
+// не попадают медицинские данные.
+type Entry struct {
+	ID      uuid.UUID
+	OwnerID uuid.UUID // профиль, к данным которого обратились
+	Actor   Actor
+	Action  Action
+	// Operation — операция API, например "updateSymptomEntry" или "reportPage"
+	Operation  string
+	EntityType string
+	EntityID   *uuid.UUID
+	// Fields — имена изменённых полей и параметров операции
+	Fields    []string
+	RequestID string
+	CreatedAt time.Time
+}
+
+// NewEntry создаёт запись журнала
+func NewEntry(ownerID uuid.UUID, actor Actor, action Action, operation, entityType string, entityID *uuid.UUID, fields []string) *Entry {
+	return &Entry{
+		ID:         uuid.New(),
+		OwnerID:    ownerID,
+		Actor:      actor,
+		Action:     action,
+		Operation:  operation,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Fields:     fields,
+		CreatedAt:  time.Now(),
+	}
+}
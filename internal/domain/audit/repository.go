@@ -0,0 +1,26 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Filter задаёт выборку журнала профиля
+type Filter struct {
+	// OthersOnly оставляет обращения всех, кроме самого профиля
+	OthersOnly bool
+}
+
+// Repository определяет интерфейс журнала аудита
+type Repository interface {
+	// Create добавляет запись в журнал
+	Create(ctx context.Context, entry *Entry) error
+
+	// FindByOwnerID возвращает последние записи журнала профиля, новые первыми
+	FindByOwnerID(ctx context.Context, ownerID uuid.UUID, filter Filter, limit int) ([]*Entry, error)
+
+	// DeleteBefore удаляет записи старше before и возвращает их число
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,73 @@
+package consent
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Channel — откуда пользователь дал согласие
+type Channel string
+
+const (
+	ChannelWebApp Channel = "webapp" // экран онбординга в Telegram WebApp
+	ChannelBot    Channel = "bot"    // кнопка в чате с ботом
+)
+
+// IsValid проверяет, что канал известен
+func (c Channel) IsValid() bool {
+	return c == ChannelWebApp || c == ChannelBot
+}
+
+// Consent представляет согласие владельца аккаунта на обработку персональных
+// данных по определённой версии политики. Каждое принятие — новая запись,
+// отзыв отмечает запись, поэтому история согласий сохраняется.
+type Consent struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID // владелец аккаунта; согласие распространяется на подопечные профили
+	PolicyVersion string
+	Channel       Channel
+	// EvidenceHash — SHA-256 от IP-адреса и initData запроса, в котором дано согласие
+	EvidenceHash string
+	AcceptedAt   time.Time
+	WithdrawnAt  *time.Time
+}
+
+// NewConsent создаёт согласие на версию политики policyVersion
+func NewConsent(userID uuid.UUID, policyVersion string, channel Channel, evidenceHash string) (*Consent, error) {
+	if !channel.IsValid() {
+		return nil, ErrInvalidChannel
+	}
+	if policyVersion == "" {
+		return nil, ErrPolicyVersionMismatch
+	}
+
+	return &Consent{
+		ID:            uuid.New(),
+		UserID:        userID,
+		PolicyVersion: policyVersion,
+		Channel:       channel,
+		EvidenceHash:  evidenceHash,
+		AcceptedAt:    time.Now(),
+	}, nil
+}
+
+// IsActive проверяет, что согласие не отозвано
+func (c *Consent) IsActive() bool {
+	return c.WithdrawnAt == nil
+}
+
+// Covers проверяет, что согласие действует и дано на версию политики policyVersion
+func (c *Consent) Covers(policyVersion string) bool {
+	return c.IsActive() && c.PolicyVersion == policyVersion
+}
+
+// Withdraw отзывает согласие
+func (c *Consent) Withdraw() error {
+	if !c.IsActive() {
+		return ErrConsentNotGiven
+	}
+	now := time.Now()
+	c.WithdrawnAt = &now
+	return nil
+}
@@ -0,0 +1,10 @@
+package consent
+
+import "errors"
+
+var (
+	ErrConsentRequired       = errors.New("consent to the current data processing policy is required")
+	ErrConsentNotGiven       = errors.New("consent is not given or already withdrawn")
+	ErrPolicyVersionMismatch = errors.New("policy version does not match the current policy")
+	ErrInvalidChannel        = errors.New("unknown consent channel")
+)
@@ -0,0 +1,19 @@
+package consent
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository определяет интерфейс для работы с согласиями
+type Repository interface {
+	// Create сохраняет новое согласие
+	Create(ctx context.Context, consent *Consent) error
+
+	// GetLatest возвращает последнее согласие пользователя или nil, если его не было
+	GetLatest(ctx context.Context, userID uuid.UUID) (*Consent, error)
+
+	// Update обновляет согласие
+	Update(ctx context.Context, consent *Consent) error
+}
@@ -0,0 +1,27 @@
+package doctorvisit
+
+import (
+	"fmt"
+
+	"github.com/health-hub-bot-api/internal/domain/plural"
+)
+
+// ReminderMessage возвращает текст напоминания о визите за daysUntil дней до него
+func (d *DoctorVisit) ReminderMessage(daysUntil int) string {
+	doctor := "к врачу"
+	if d.Specialty != nil && *d.Specialty != "" {
+		doctor = fmt.Sprintf("к врачу (%s)", *d.Specialty)
+	}
+
+	var when string
+	switch daysUntil {
+	case 0:
+		when = "сегодня"
+	case 1:
+		when = "завтра"
+	default:
+		when = "через " + plural.Days(daysUntil)
+	}
+
+	return fmt.Sprintf("У тебя запись %s %s. Подготовить отчёт?", doctor, when)
+}
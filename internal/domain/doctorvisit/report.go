@@ -15,6 +15,7 @@ type Report struct {
 	WellbeingTrend WellbeingTrend
 	Analyses     []ReportAnalysis
 	Medications  []ReportMedication
+	EndedCourses []ReportCourse
 	Questions    *string
 	GeneratedAt  time.Time
 }
@@ -33,14 +34,25 @@ type ReportAnalysis struct {
 	Type      string
 	Name      string
 	DateTaken time.Time
+	FileType  string // image или pdf
 }
 
 // ReportMedication представляет лекарство в отчёте
 type ReportMedication struct {
-	ID       uuid.UUID
-	Name     string
-	Dosage   string
-	IsActive bool
+	ID            uuid.UUID
+	Name          string
+	Dosage        string
+	IsActive      bool
+	AsNeededCount int // число приёмов по необходимости за период отчёта
+}
+
+// ReportCourse представляет курс лекарства, завершённый в периоде отчёта
+type ReportCourse struct {
+	Name      string
+	Dosage    string
+	StartDate time.Time
+	EndDate   time.Time
+	Reason    string // completed, stopped или replaced
 }
 
 // WellbeingTrend представляет тренд самочувствия
@@ -64,13 +76,14 @@ func NewReport(
 	period DateRange,
 ) *Report {
 	return &Report{
-		VisitID:     visitID,
-		VisitDate:   visitDate,
-		Period:      period,
-		Symptoms:    []ReportSymptom{},
-		Analyses:    []ReportAnalysis{},
-		Medications: []ReportMedication{},
-		GeneratedAt: time.Now(),
+		VisitID:      visitID,
+		VisitDate:    visitDate,
+		Period:       period,
+		Symptoms:     []ReportSymptom{},
+		Analyses:     []ReportAnalysis{},
+		Medications:  []ReportMedication{},
+		EndedCourses: []ReportCourse{},
+		GeneratedAt:  time.Now(),
 	}
 }
 
@@ -89,6 +102,11 @@ func (r *Report) AddMedication(medication ReportMedication) {
 	r.Medications = append(r.Medications, medication)
 }
 
+// AddEndedCourse добавляет завершённый курс в отчёт
+func (r *Report) AddEndedCourse(course ReportCourse) {
+	r.EndedCourses = append(r.EndedCourses, course)
+}
+
 // SetWellbeingTrend устанавливает тренд самочувствия
 func (r *Report) SetWellbeingTrend(trend WellbeingTrend) {
 	r.WellbeingTrend = trend
@@ -7,6 +7,13 @@ import (
 	"github.com/google/uuid"
 )
 
+// Filter представляет фильтр для поиска визитов к врачу
+type Filter struct {
+	UserID    uuid.UUID
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
 // Repository определяет интерфейс для работы с визитами к врачу
 type Repository interface {
 	// Create создаёт новый визит
@@ -15,8 +22,8 @@ type Repository interface {
 	// GetByID возвращает визит по ID
 	GetByID(ctx context.Context, id uuid.UUID) (*DoctorVisit, error)
 	
-	// FindByUserID возвращает визиты пользователя
-	FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*DoctorVisit, int, error)
+	// FindByFilter возвращает визиты по фильтру
+	FindByFilter(ctx context.Context, filter Filter, limit, offset int) ([]*DoctorVisit, int, error)
 	
 	// Update обновляет визит
 	Update(ctx context.Context, visit *DoctorVisit) error
@@ -26,5 +33,8 @@ type Repository interface {
 	
 	// GetUpcomingVisits возвращает предстоящие визиты
 	GetUpcomingVisits(ctx context.Context, userID uuid.UUID, beforeDate time.Time) ([]*DoctorVisit, error)
+	
+	// FindBetween возвращает визиты всех пользователей с датой в диапазоне [from, to]
+	FindBetween(ctx context.Context, from, to time.Time) ([]*DoctorVisit, error)
 }
 
@@ -0,0 +1,40 @@
+package encryption
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DataKey представляет ключ данных профиля. Ключ шифрует чувствительные поля
+// профиля и хранится только в зашифрованном мастер-ключом виде (конвертное шифрование),
+// поэтому смена мастер-ключа не требует перешифровки самих данных.
+type DataKey struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	// MasterKeyID — мастер-ключ из конфигурации, которым зашифрован ключ
+	MasterKeyID string
+	WrappedKey  []byte
+	CreatedAt   time.Time
+	// RetiredAt задан у ключа, выведенного из оборота при ротации: им только
+	// расшифровываются старые значения, пока они не перешифрованы новым ключом
+	RetiredAt *time.Time
+}
+
+// IsActive проверяет, что ключом шифруются новые значения
+func (k *DataKey) IsActive() bool {
+	return k.RetiredAt == nil
+}
+
+// Retire выводит ключ из оборота
+func (k *DataKey) Retire(now time.Time) {
+	if k.RetiredAt == nil {
+		k.RetiredAt = &now
+	}
+}
+
+// Rewrap заменяет зашифрованный ключ после смены мастер-ключа
+func (k *DataKey) Rewrap(masterKeyID string, wrapped []byte) {
+	k.MasterKeyID = masterKeyID
+	k.WrappedKey = wrapped
+}
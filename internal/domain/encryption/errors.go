@@ -0,0 +1,11 @@
+package encryption
+
+import "errors"
+
+var (
+	ErrDataKeyNotFound      = errors.New("data key not found")
+	ErrUnknownMasterKey     = errors.New("unknown encryption master key")
+	ErrMalformedCiphertext  = errors.New("malformed encrypted value")
+	ErrEncryptionDisabled   = errors.New("value is encrypted but encryption keys are not configured")
+	ErrDataKeyAlreadyActive = errors.New("profile already has an active data key")
+)
@@ -0,0 +1,36 @@
+package encryption
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DataKeyRepository определяет интерфейс для работы с ключами данных
+type DataKeyRepository interface {
+	// Create сохраняет новый ключ; если у профиля уже есть действующий ключ,
+	// возвращает ErrDataKeyAlreadyActive
+	Create(ctx context.Context, key *DataKey) error
+
+	// GetByID возвращает ключ по ID
+	GetByID(ctx context.Context, id uuid.UUID) (*DataKey, error)
+
+	// GetActive возвращает действующий ключ профиля или nil
+	GetActive(ctx context.Context, userID uuid.UUID) (*DataKey, error)
+
+	// FindActive возвращает действующие ключи всех профилей
+	FindActive(ctx context.Context) ([]*DataKey, error)
+
+	// FindNotWrappedWith возвращает ключи, зашифрованные другим мастер-ключом
+	FindNotWrappedWith(ctx context.Context, masterKeyID string) ([]*DataKey, error)
+
+	// FindRetiredBefore возвращает ключи, выведенные из оборота до before
+	FindRetiredBefore(ctx context.Context, before time.Time) ([]*DataKey, error)
+
+	// Update обновляет ключ
+	Update(ctx context.Context, key *DataKey) error
+
+	// Delete удаляет ключ
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,53 @@
+package engagement
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/plural"
+)
+
+// MilestoneThresholds — длины серий, которые отмечаются поздравлением
+var MilestoneThresholds = []int{3, 7, 14, 30, 60, 100, 180, 365}
+
+// Milestone представляет достигнутую веху серии; каждая веха отмечается один раз
+type Milestone struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Kind       StreakKind
+	Days       int
+	AchievedAt time.Time
+}
+
+// NewMilestone создаёт новую веху
+func NewMilestone(userID uuid.UUID, kind StreakKind, days int) *Milestone {
+	return &Milestone{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Kind:       kind,
+		Days:       days,
+		AchievedAt: time.Now(),
+	}
+}
+
+// ReachedMilestones возвращает пороги, достигнутые текущей серией
+func ReachedMilestones(current int) []int {
+	var reached []int
+	for _, threshold := range MilestoneThresholds {
+		if current >= threshold {
+			reached = append(reached, threshold)
+		}
+	}
+	return reached
+}
+
+// Message возвращает мягкое поздравление с вехой
+func (m *Milestone) Message() string {
+	switch m.Kind {
+	case StreakKindMedicationAdherence:
+		return fmt.Sprintf("%s подряд без пропусков приёма лекарств. Это важно для эффективности!", plural.Days(m.Days))
+	default:
+		return fmt.Sprintf("Ты уже %s ведёшь дневник. Отличная привычка!", plural.Days(m.Days))
+	}
+}
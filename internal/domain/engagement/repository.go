@@ -0,0 +1,16 @@
+package engagement
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// MilestoneRepository определяет интерфейс для работы с вехами
+type MilestoneRepository interface {
+	// Record сохраняет веху, если она ещё не была отмечена; возвращает true для новой вехи
+	Record(ctx context.Context, milestone *Milestone) (bool, error)
+
+	// FindByUserID возвращает вехи пользователя
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*Milestone, error)
+}
@@ -0,0 +1,67 @@
+package engagement
+
+import "time"
+
+// StreakKind представляет вид серии
+type StreakKind string
+
+const (
+	StreakKindSymptomLogging      StreakKind = "symptom_logging"
+	StreakKindMedicationAdherence StreakKind = "medication_adherence"
+)
+
+// DayStatus представляет итог дня для подсчёта серии
+type DayStatus int
+
+const (
+	// DayNeutral — день не учитывается и не прерывает серию (например, нет запланированных приёмов)
+	DayNeutral DayStatus = iota
+	// DaySuccess — день засчитан
+	DaySuccess
+	// DayFailed — день прерывает серию
+	DayFailed
+)
+
+// Streak представляет текущую и самую длинную серию дней подряд
+type Streak struct {
+	Current int
+	Longest int
+}
+
+const dayLayout = "2006-01-02"
+
+// DayKey возвращает ключ календарного дня в часовом поясе loc
+func DayKey(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format(dayLayout)
+}
+
+// CalculateStreak считает серии по статусам дней с from по today включительно.
+// Отсутствующие в statuses дни получают статус missing. Незасчитанный сегодняшний
+// день серию не прерывает: пользователь ещё может сделать запись.
+func CalculateStreak(statuses map[string]DayStatus, missing DayStatus, from, today time.Time) Streak {
+	var streak Streak
+	todayKey := today.Format(dayLayout)
+
+	for day := from; day.Format(dayLayout) <= todayKey; day = day.AddDate(0, 0, 1) {
+		key := day.Format(dayLayout)
+		status, ok := statuses[key]
+		if !ok {
+			status = missing
+		}
+		if key == todayKey && status != DaySuccess {
+			status = DayNeutral
+		}
+
+		switch status {
+		case DaySuccess:
+			streak.Current++
+			if streak.Current > streak.Longest {
+				streak.Longest = streak.Current
+			}
+		case DayFailed:
+			streak.Current = 0
+		}
+	}
+
+	return streak
+}
@@ -0,0 +1,84 @@
+package export
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status представляет состояние задачи экспорта
+type Status string
+
+const (
+	StatusPending    Status = "pending"    // ожидает обработки фоновой задачей
+	StatusProcessing Status = "processing" // архив собирается
+	StatusCompleted  Status = "completed"  // архив готов к скачиванию
+	StatusFailed     Status = "failed"     // сборка архива завершилась ошибкой
+	StatusExpired    Status = "expired"    // срок скачивания истёк, архив удалён
+)
+
+// Job представляет асинхронную выгрузку всех данных профиля в архив
+type Job struct {
+	ID     uuid.UUID
+	UserID uuid.UUID // профиль, данные которого выгружаются
+	Status Status
+	// ArchiveURL — расположение готового архива в хранилище файлов
+	ArchiveURL  *string
+	SizeBytes   int64
+	Error       *string
+	ExpiresAt   *time.Time // до какого момента архив можно скачать
+	CreatedAt   time.Time
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+}
+
+// NewJob создаёт задачу экспорта в очереди
+func NewJob(userID uuid.UUID) *Job {
+	return &Job{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Status:    StatusPending,
+		CreatedAt: time.Now(),
+	}
+}
+
+// IsInProgress проверяет, что задача ещё не завершена
+func (j *Job) IsInProgress() bool {
+	return j.Status == StatusPending || j.Status == StatusProcessing
+}
+
+// IsDownloadable проверяет, что архив готов и срок скачивания не истёк
+func (j *Job) IsDownloadable(now time.Time) bool {
+	return j.Status == StatusCompleted && j.ArchiveURL != nil && j.ExpiresAt != nil && now.Before(*j.ExpiresAt)
+}
+
+// Start отмечает начало сборки архива
+func (j *Job) Start() {
+	now := time.Now()
+	j.Status = StatusProcessing
+	j.StartedAt = &now
+}
+
+// Complete отмечает готовность архива
+func (j *Job) Complete(archiveURL string, sizeBytes int64, expiresAt time.Time) {
+	now := time.Now()
+	j.Status = StatusCompleted
+	j.ArchiveURL = &archiveURL
+	j.SizeBytes = sizeBytes
+	j.ExpiresAt = &expiresAt
+	j.CompletedAt = &now
+}
+
+// Fail отмечает ошибку сборки архива
+func (j *Job) Fail(reason string) {
+	now := time.Now()
+	j.Status = StatusFailed
+	j.Error = &reason
+	j.CompletedAt = &now
+}
+
+// Expire отмечает, что архив удалён по истечении срока скачивания
+func (j *Job) Expire() {
+	j.Status = StatusExpired
+	j.ArchiveURL = nil
+}
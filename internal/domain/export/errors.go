@@ -0,0 +1,15 @@
+package export
+
+import "errors"
+
+var (
+	ErrJobNotFound      = errors.New("export job not found")
+	ErrExportInProgress = errors.New("data export is already in progress")
+	ErrArchiveNotReady  = errors.New("export archive is not ready or has expired")
+	ErrUnauthorized     = errors.New("unauthorized access to export job")
+
+	ErrInvalidArchive           = errors.New("file is not a valid data export archive")
+	ErrUnsupportedSchemaVersion = errors.New("unsupported export archive schema version")
+	ErrFileChecksumMismatch     = errors.New("analysis file checksum does not match manifest")
+	ErrFileSizeMismatch         = errors.New("analysis file size does not match manifest")
+)
@@ -0,0 +1,65 @@
+package export
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/health-hub-bot-api/internal/domain/analysis"
+	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+	"github.com/health-hub-bot-api/internal/domain/engagement"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/symptom"
+)
+
+// maxSummaryErrors ограничивает число ошибок, которые сохраняются в итогах по сущности
+const maxSummaryErrors = 10
+
+// ImportSummary представляет итоги импорта архива по сущностям
+type ImportSummary struct {
+	Entities []*EntitySummary
+}
+
+// EntitySummary представляет итоги импорта одной сущности.
+// Skipped — записи, которые уже есть у профиля или не переносятся;
+// Failed — записи, которые не удалось проверить или сохранить.
+type EntitySummary struct {
+	Name     string
+	Imported int
+	Skipped  int
+	Failed   int
+	Errors   []string
+}
+
+// Entity возвращает итоги по сущности, добавляя их при первом обращении
+func (s *ImportSummary) Entity(name string) *EntitySummary {
+	for _, entity := range s.Entities {
+		if entity.Name == name {
+			return entity
+		}
+	}
+	entity := &EntitySummary{Name: name}
+	s.Entities = append(s.Entities, entity)
+	return entity
+}
+
+// Fail учитывает запись row, которую не удалось импортировать
+func (e *EntitySummary) Fail(row int, err error) {
+	e.Failed++
+	if len(e.Errors) < maxSummaryErrors {
+		e.Errors = append(e.Errors, fmt.Sprintf("row %d: %v", row+1, err))
+	}
+}
+
+// ImportRepository сохраняет импортированные записи. Каждый метод записывает
+// записи одного типа в одной транзакции: запись, которую не удалось сохранить,
+// откатывается до точки сохранения и не отменяет остальные. Возвращает ошибку
+// по каждой записи (nil — запись сохранена) и ошибку самой транзакции.
+type ImportRepository interface {
+	ImportSymptoms(ctx context.Context, entries []*symptom.SymptomEntry) ([]error, error)
+	ImportAnalyses(ctx context.Context, analyses []*analysis.Analysis) ([]error, error)
+	ImportMedications(ctx context.Context, medications []*medication.Medication) ([]error, error)
+	ImportIntakes(ctx context.Context, intakes []*medication.MedicationIntake) ([]error, error)
+	ImportCourses(ctx context.Context, courses []*medication.Course) ([]error, error)
+	ImportDoctorVisits(ctx context.Context, visits []*doctorvisit.DoctorVisit) ([]error, error)
+	ImportMilestones(ctx context.Context, milestones []*engagement.Milestone) ([]error, error)
+}
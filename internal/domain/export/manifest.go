@@ -0,0 +1,90 @@
+package export
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// ArchiveFormat идентифицирует архив выгрузки HealthHub
+	ArchiveFormat = "healthhub-export"
+	// SchemaVersion — версия структуры архива; меняется при несовместимых изменениях записей
+	SchemaVersion = 1
+
+	// ManifestPath — путь манифеста внутри архива
+	ManifestPath = "manifest.json"
+	// DataDir — каталог с JSON и CSV по сущностям
+	DataDir = "data/"
+	// FilesDir — каталог с исходными файлами анализов
+	FilesDir = "files/"
+)
+
+// Имена сущностей в архиве; файлы данных называются <entity>.json и <entity>.csv
+const (
+	EntityProfile         = "profile"
+	EntitySymptoms        = "symptoms"
+	EntityVitals          = "vitals"
+	EntityAnalyses        = "analyses"
+	EntityMedications     = "medications"
+	EntityIntakes         = "medication_intakes"
+	EntityCourses         = "medication_courses"
+	EntityDoctorVisits    = "doctor_visits"
+	EntityReportSnapshots = "report_snapshots"
+	EntityMilestones      = "milestones"
+)
+
+// Manifest описывает содержимое архива выгрузки
+type Manifest struct {
+	Format        string           `json:"format"`
+	SchemaVersion int              `json:"schemaVersion"`
+	ExportedAt    time.Time        `json:"exportedAt"`
+	ProfileID     uuid.UUID        `json:"profileId"`
+	Entities      []ManifestEntity `json:"entities"`
+	Files         []ManifestFile   `json:"files"`
+}
+
+// ManifestEntity описывает выгруженную сущность
+type ManifestEntity struct {
+	Name string `json:"name"`
+	Rows int    `json:"rows"`
+	JSON string `json:"json"`
+	CSV  string `json:"csv"`
+}
+
+// ManifestFile описывает исходный файл анализа в архиве.
+// Если файл не удалось получить из хранилища, Path пуст, а Error содержит причину.
+type ManifestFile struct {
+	AnalysisID uuid.UUID `json:"analysisId"`
+	Path       string    `json:"path,omitempty"`
+	Size       int64     `json:"size,omitempty"`
+	SHA256     string    `json:"sha256,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// NewManifest создаёт манифест архива профиля
+func NewManifest(profileID uuid.UUID, exportedAt time.Time) *Manifest {
+	return &Manifest{
+		Format:        ArchiveFormat,
+		SchemaVersion: SchemaVersion,
+		ExportedAt:    exportedAt,
+		ProfileID:     profileID,
+		Entities:      []ManifestEntity{},
+		Files:         []ManifestFile{},
+	}
+}
+
+// AddEntity добавляет сущность в манифест
+func (m *Manifest) AddEntity(name string, rows int) {
+	m.Entities = append(m.Entities, ManifestEntity{
+		Name: name,
+		Rows: rows,
+		JSON: DataDir + name + ".json",
+		CSV:  DataDir + name + ".csv",
+	})
+}
+
+// AddFile добавляет файл анализа в манифест
+func (m *Manifest) AddFile(file ManifestFile) {
+	m.Files = append(m.Files, file)
+}
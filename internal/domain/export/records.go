@@ -0,0 +1,158 @@
+package export
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Записи архива — переносимое представление данных профиля. Поля именуются
+// в JSON так же, как в CSV; изменение записей требует новой SchemaVersion.
+
+// ProfileRecord представляет профиль пользователя
+type ProfileRecord struct {
+	ID              uuid.UUID `json:"id"`
+	Name            string    `json:"name"`
+	Age             *int      `json:"age"`
+	Gender          *string   `json:"gender"`
+	Timezone        string    `json:"timezone"`
+	CheckInTimes    []string  `json:"checkInTimes"`
+	Channels        []string  `json:"channels"`
+	QuietHoursStart *string   `json:"quietHoursStart"`
+	QuietHoursEnd   *string   `json:"quietHoursEnd"`
+	CheckInDays     []int     `json:"checkInDays"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+// SymptomRecord представляет запись дневника вместе с показателями
+type SymptomRecord struct {
+	ID                     uuid.UUID `json:"id"`
+	DateTime               time.Time `json:"dateTime"`
+	Description            string    `json:"description"`
+	WellbeingScale         int       `json:"wellbeingScale"`
+	Temperature            *float64  `json:"temperature"`
+	BloodPressureSystolic  *int      `json:"bloodPressureSystolic"`
+	BloodPressureDiastolic *int      `json:"bloodPressureDiastolic"`
+	Pulse                  *int      `json:"pulse"`
+	PhotoURL               *string   `json:"photoUrl"`
+	CreatedAt              time.Time `json:"createdAt"`
+}
+
+// VitalRecord представляет показатели из записи дневника. Это производная
+// таблица для удобства чтения: при импорте показатели берутся из SymptomRecord.
+type VitalRecord struct {
+	SymptomID              uuid.UUID `json:"symptomId"`
+	DateTime               time.Time `json:"dateTime"`
+	Temperature            *float64  `json:"temperature"`
+	BloodPressureSystolic  *int      `json:"bloodPressureSystolic"`
+	BloodPressureDiastolic *int      `json:"bloodPressureDiastolic"`
+	Pulse                  *int      `json:"pulse"`
+}
+
+// AnalysisRecord представляет анализ; File — путь исходного файла внутри архива
+type AnalysisRecord struct {
+	ID                 uuid.UUID  `json:"id"`
+	Type               string     `json:"type"`
+	Name               string     `json:"name"`
+	DateTaken          time.Time  `json:"dateTaken"`
+	FileType           string     `json:"fileType"`
+	File               string     `json:"file"`
+	NextReminderDate   *time.Time `json:"nextReminderDate"`
+	FollowUpAnalysisID *uuid.UUID `json:"followUpAnalysisId"`
+	CreatedAt          time.Time  `json:"createdAt"`
+}
+
+// MedicationRecord представляет лекарство с расписанием
+type MedicationRecord struct {
+	ID                     uuid.UUID      `json:"id"`
+	Name                   string         `json:"name"`
+	Dosage                 string         `json:"dosage"`
+	DosageAmount           *float64       `json:"dosageAmount"`
+	DosageUnit             *string        `json:"dosageUnit"`
+	DosageForm             *string        `json:"dosageForm"`
+	ScheduleType           string         `json:"scheduleType"`
+	Schedule               ScheduleRecord `json:"schedule"`
+	StartDate              time.Time      `json:"startDate"`
+	EndDate                *time.Time     `json:"endDate"`
+	IsActive               bool           `json:"isActive"`
+	MaxDosesPer24h         *int           `json:"maxDosesPer24h"`
+	MinDoseIntervalMinutes *int           `json:"minDoseIntervalMinutes"`
+	StockQuantity          *float64       `json:"stockQuantity"`
+	CreatedAt              time.Time      `json:"createdAt"`
+}
+
+// ScheduleRecord представляет расписание приёма лекарства
+type ScheduleRecord struct {
+	Times         []string         `json:"times"`
+	Days          []int            `json:"days"`
+	IntervalHours int              `json:"intervalHours,omitempty"`
+	EveryNDays    int              `json:"everyNDays,omitempty"`
+	CycleDaysOn   int              `json:"cycleDaysOn,omitempty"`
+	CycleDaysOff  int              `json:"cycleDaysOff,omitempty"`
+	DoseSteps     []DoseStepRecord `json:"doseSteps,omitempty"`
+}
+
+// DoseStepRecord представляет этап курса с изменением дозы
+type DoseStepRecord struct {
+	Days int    `json:"days"`
+	Dose string `json:"dose"`
+}
+
+// IntakeRecord представляет приём лекарства
+type IntakeRecord struct {
+	ID            uuid.UUID  `json:"id"`
+	MedicationID  uuid.UUID  `json:"medicationId"`
+	ScheduledTime time.Time  `json:"scheduledTime"`
+	Status        string     `json:"status"`
+	PlannedDose   *string    `json:"plannedDose"`
+	TakenAt       *time.Time `json:"takenAt"`
+	MissedAt      *time.Time `json:"missedAt"`
+	SkipReason    *string    `json:"skipReason"`
+	ActualDose    *string    `json:"actualDose"`
+	IsAsNeeded    bool       `json:"isAsNeeded"`
+	Reason        *string    `json:"reason"`
+	Notes         *string    `json:"notes"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+// CourseRecord представляет завершённый курс лекарства
+type CourseRecord struct {
+	ID             uuid.UUID  `json:"id"`
+	MedicationID   uuid.UUID  `json:"medicationId"`
+	MedicationName string     `json:"medicationName"`
+	Dosage         string     `json:"dosage"`
+	StartDate      time.Time  `json:"startDate"`
+	EndDate        *time.Time `json:"endDate"`
+	Reason         *string    `json:"reason"`
+	ReplacedByID   *uuid.UUID `json:"replacedById"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+// DoctorVisitRecord представляет визит к врачу
+type DoctorVisitRecord struct {
+	ID         uuid.UUID `json:"id"`
+	VisitDate  time.Time `json:"visitDate"`
+	DoctorName *string   `json:"doctorName"`
+	Specialty  *string   `json:"specialty"`
+	Questions  *string   `json:"questions"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// ReportSnapshotRecord представляет последний сформированный отчёт к визиту
+type ReportSnapshotRecord struct {
+	VisitID       uuid.UUID   `json:"visitId"`
+	GeneratedAt   time.Time   `json:"generatedAt"`
+	PeriodStart   time.Time   `json:"periodStart"`
+	PeriodEnd     time.Time   `json:"periodEnd"`
+	SymptomIDs    []uuid.UUID `json:"symptomIds"`
+	AnalysisIDs   []uuid.UUID `json:"analysisIds"`
+	MedicationIDs []uuid.UUID `json:"medicationIds"`
+}
+
+// MilestoneRecord представляет достигнутую веху серии
+type MilestoneRecord struct {
+	ID         uuid.UUID `json:"id"`
+	Kind       string    `json:"kind"`
+	Days       int       `json:"days"`
+	AchievedAt time.Time `json:"achievedAt"`
+}
@@ -0,0 +1,32 @@
+package export
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobRepository определяет интерфейс для работы с задачами экспорта
+type JobRepository interface {
+	// Create создаёт новую задачу
+	Create(ctx context.Context, job *Job) error
+
+	// GetByID возвращает задачу по ID
+	GetByID(ctx context.Context, id uuid.UUID) (*Job, error)
+
+	// FindByUserID возвращает задачи профиля, новые первыми
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*Job, error)
+
+	// FindPending возвращает задачи в очереди, старые первыми
+	FindPending(ctx context.Context, limit int) ([]*Job, error)
+
+	// FindStale возвращает задачи, сборка которых началась до startedBefore и не завершилась
+	FindStale(ctx context.Context, startedBefore time.Time) ([]*Job, error)
+
+	// FindExpired возвращает готовые задачи, срок скачивания которых истёк до before
+	FindExpired(ctx context.Context, before time.Time) ([]*Job, error)
+
+	// Update обновляет задачу
+	Update(ctx context.Context, job *Job) error
+}
@@ -0,0 +1,27 @@
+package filestorage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var (
+	ErrFileNotFound = errors.New("file not found")
+	ErrRemoteFile   = errors.New("file is stored at an external link and is not fetched by the server")
+)
+
+// Storage определяет интерфейс хранилища файлов (фото и PDF анализов, архивы экспорта).
+// Файл адресуется URL, который хранится в сущности: ключом в хранилище
+// или внешней http(s)-ссылкой. Внешние ссылки сервер не открывает (ErrRemoteFile),
+// чтобы по ссылке из данных пользователя нельзя было обратиться к внутренней сети.
+type Storage interface {
+	// Open открывает файл для чтения
+	Open(ctx context.Context, fileURL string) (io.ReadCloser, error)
+
+	// Save сохраняет файл под ключом key и возвращает его URL
+	Save(ctx context.Context, key string, r io.Reader) (string, error)
+
+	// Delete удаляет файл; внешние ссылки и отсутствующие файлы пропускаются
+	Delete(ctx context.Context, fileURL string) error
+}
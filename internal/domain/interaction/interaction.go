@@ -0,0 +1,147 @@
+package interaction
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Disclaimer сопровождает любые предупреждения о сочетании лекарств
+const Disclaimer = "Это справочная информация из локального набора данных, а не медицинская рекомендация. Обсудите сочетание лекарств с врачом."
+
+// WarningKind представляет тип предупреждения
+type WarningKind string
+
+const (
+	WarningKindDuplicateIngredient WarningKind = "duplicate_ingredient"
+	WarningKindInteraction         WarningKind = "interaction"
+)
+
+// Interaction представляет известное взаимодействие двух действующих веществ
+type Interaction struct {
+	IngredientA string
+	IngredientB string
+	Description string
+	Source      string // ссылка на источник сведений
+}
+
+// Source представляет источник данных о составе лекарств и взаимодействиях
+type Source interface {
+	// Ingredients возвращает действующие вещества лекарства по его названию
+	Ingredients(name string) []string
+	// Interaction возвращает известное взаимодействие пары веществ или nil
+	Interaction(a, b string) *Interaction
+}
+
+// Medication представляет лекарство пользователя для проверки
+type Medication struct {
+	ID   uuid.UUID
+	Name string
+}
+
+// Warning представляет информационное предупреждение о сочетании лекарств
+type Warning struct {
+	Kind                WarningKind
+	OtherMedicationID   uuid.UUID
+	OtherMedicationName string
+	Ingredients         []string
+	Message             string
+	Source              *string
+}
+
+// Checker сопоставляет лекарство с остальными лекарствами пользователя
+type Checker struct {
+	source Source
+}
+
+// NewChecker создаёт новый checker
+func NewChecker(source Source) *Checker {
+	return &Checker{source: source}
+}
+
+// Check возвращает предупреждения о совпадающих действующих веществах
+// и известных взаимодействиях target с каждым из others. Взаимодействие,
+// найденное для пары лекарств в обоих направлениях (A+B и B+A), выдаётся один раз
+func (c *Checker) Check(target Medication, others []Medication) []Warning {
+	warnings := []Warning{}
+	type ruleKey struct {
+		a, b  string
+		other uuid.UUID
+	}
+	seen := make(map[ruleKey]bool)
+	targetIngredients := c.source.Ingredients(target.Name)
+	if len(targetIngredients) == 0 {
+		return warnings
+	}
+
+	for _, other := range others {
+		if other.ID == target.ID {
+			continue
+		}
+		otherIngredients := c.source.Ingredients(other.Name)
+
+		if shared := intersect(targetIngredients, otherIngredients); len(shared) > 0 {
+			warnings = append(warnings, Warning{
+				Kind:                WarningKindDuplicateIngredient,
+				OtherMedicationID:   other.ID,
+				OtherMedicationName: other.Name,
+				Ingredients:         shared,
+				Message: fmt.Sprintf("«%s» и «%s» содержат одно и то же действующее вещество (%s). Обсудите с врачом, нужно ли принимать оба.",
+					target.Name, other.Name, strings.Join(shared, ", ")),
+			})
+		}
+
+		for _, a := range targetIngredients {
+			for _, b := range otherIngredients {
+				found := c.source.Interaction(a, b)
+				if found == nil {
+					continue
+				}
+				key := ruleKey{a: found.IngredientA, b: found.IngredientB, other: other.ID}
+				if key.a > key.b {
+					key.a, key.b = key.b, key.a
+				}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				source := found.Source
+				warnings = append(warnings, Warning{
+					Kind:                WarningKindInteraction,
+					OtherMedicationID:   other.ID,
+					OtherMedicationName: other.Name,
+					Ingredients:         []string{a, b},
+					Message: fmt.Sprintf("Для сочетания «%s» и «%s» (%s + %s) описано взаимодействие: %s. Обсудите с врачом.",
+						target.Name, other.Name, a, b, found.Description),
+					Source: &source,
+				})
+			}
+		}
+	}
+
+	return warnings
+}
+
+// intersect возвращает общие элементы двух списков в отсортированном виде
+func intersect(a, b []string) []string {
+	set := make(map[string]bool, len(a))
+	for _, item := range a {
+		set[item] = true
+	}
+	var shared []string
+	for _, item := range b {
+		if set[item] {
+			shared = append(shared, item)
+			delete(set, item)
+		}
+	}
+	sort.Strings(shared)
+	return shared
+}
+
+// NormalizeName приводит название лекарства или вещества к виду для сравнения
+func NormalizeName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
@@ -0,0 +1,53 @@
+package interaction
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// pairSource — источник с одним взаимодействием, найденным в обоих направлениях
+type pairSource struct {
+	ingredients map[string][]string
+	rule        *Interaction
+}
+
+func (s pairSource) Ingredients(name string) []string {
+	return s.ingredients[name]
+}
+
+func (s pairSource) Interaction(a, b string) *Interaction {
+	if (a == s.rule.IngredientA && b == s.rule.IngredientB) || (a == s.rule.IngredientB && b == s.rule.IngredientA) {
+		return s.rule
+	}
+	return nil
+}
+
+func TestCheckReportsInteractionOncePerOtherMedication(t *testing.T) {
+	source := pairSource{
+		ingredients: map[string][]string{
+			"Комбинированное":      {"ибупрофен", "варфарин"},
+			"Тоже комбинированное": {"варфарин", "ибупрофен"},
+			"Варфарин":             {"варфарин"},
+		},
+		rule: &Interaction{IngredientA: "варфарин", IngredientB: "ибупрофен", Description: "риск кровотечения"},
+	}
+	target := Medication{ID: uuid.New(), Name: "Комбинированное"}
+	combined := Medication{ID: uuid.New(), Name: "Тоже комбинированное"}
+	single := Medication{ID: uuid.New(), Name: "Варфарин"}
+
+	warnings := NewChecker(source).Check(target, []Medication{target, combined, single})
+
+	interactions := make(map[uuid.UUID]int)
+	for _, w := range warnings {
+		if w.Kind == WarningKindInteraction {
+			interactions[w.OtherMedicationID]++
+		}
+	}
+	if interactions[combined.ID] != 1 {
+		t.Errorf("got %d interaction warnings for %q, want 1", interactions[combined.ID], combined.Name)
+	}
+	if interactions[single.ID] != 1 {
+		t.Errorf("got %d interaction warnings for %q, want 1", interactions[single.ID], single.Name)
+	}
+}
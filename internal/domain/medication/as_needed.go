@@ -0,0 +1,109 @@
+package medication
+
+import (
+	"fmt"
+	"time"
+)
+
+// asNeededWindow — скользящее окно для ограничения числа приёмов
+const asNeededWindow = 24 * time.Hour
+
+// DoseWarningCode представляет вид превышения ограничения приёма
+type DoseWarningCode string
+
+const (
+	DoseWarningMaxDosesExceeded DoseWarningCode = "max_doses_exceeded"
+	DoseWarningIntervalTooShort DoseWarningCode = "interval_too_short"
+)
+
+// DoseWarning представляет предупреждение о превышении ограничения,
+// которое пользователь сам задал для лекарства
+type DoseWarning struct {
+	Code    DoseWarningCode
+	Message string
+}
+
+// SetAsNeededLimits задаёт ограничения для приёма по необходимости; nil снимает ограничение
+func (m *Medication) SetAsNeededLimits(maxDosesPer24h *int, minInterval *time.Duration) error {
+	if maxDosesPer24h != nil && *maxDosesPer24h <= 0 {
+		return ErrInvalidDoseLimits
+	}
+	if minInterval != nil && *minInterval <= 0 {
+		return ErrInvalidDoseLimits
+	}
+	m.MaxDosesPer24h = maxDosesPer24h
+	m.MinDoseInterval = minInterval
+	m.UpdatedAt = time.Now()
+	return nil
+}
+
+// CheckAsNeededDose проверяет новый приём в момент takenAt против ограничений
+// лекарства. others — принятые приёмы в пределах суток до и после takenAt.
+func (m *Medication) CheckAsNeededDose(takenAt time.Time, others []*MedicationIntake) []DoseWarning {
+	var warnings []DoseWarning
+
+	if m.MaxDosesPer24h != nil {
+		// Новый приём тоже учитывается
+		count := 1
+		for _, intake := range others {
+			at := intakeTime(intake)
+			if intake.IsTaken() && at.After(takenAt.Add(-asNeededWindow)) && !at.After(takenAt) {
+				count++
+			}
+		}
+		if count > *m.MaxDosesPer24h {
+			warnings = append(warnings, DoseWarning{
+				Code:    DoseWarningMaxDosesExceeded,
+				Message: fmt.Sprintf("Это %d-й приём за 24 часа, а ваше ограничение — %d", count, *m.MaxDosesPer24h),
+			})
+		}
+	}
+
+	if m.MinDoseInterval != nil {
+		var closest *time.Duration
+		for _, intake := range others {
+			if !intake.IsTaken() {
+				continue
+			}
+			gap := takenAt.Sub(intakeTime(intake))
+			if gap < 0 {
+				gap = -gap
+			}
+			if closest == nil || gap < *closest {
+				closest = &gap
+			}
+		}
+		if closest != nil && *closest < *m.MinDoseInterval {
+			warnings = append(warnings, DoseWarning{
+				Code: DoseWarningIntervalTooShort,
+				Message: fmt.Sprintf("Между приёмами %s, а ваш минимальный интервал — %s",
+					formatInterval(*closest), formatInterval(*m.MinDoseInterval)),
+			})
+		}
+	}
+
+	return warnings
+}
+
+// intakeTime возвращает фактическое время приёма или запланированное, если оно неизвестно
+func intakeTime(intake *MedicationIntake) time.Time {
+	if intake.TakenAt != nil {
+		return *intake.TakenAt
+	}
+	return intake.ScheduledTime
+}
+
+// formatInterval форматирует интервал в виде «2 ч 30 мин»
+func formatInterval(d time.Duration) string {
+	d = d.Round(time.Minute)
+	hours := int(d / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%d мин", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%d ч", hours)
+	default:
+		return fmt.Sprintf("%d ч %d мин", hours, minutes)
+	}
+}
@@ -0,0 +1,43 @@
+package medication
+
+// ComplianceStats содержит количество приёмов по статусам за период
+type ComplianceStats struct {
+	Planned int
+	Taken   int
+	Late    int
+	Skipped int
+	Missed  int
+}
+
+// Add учитывает приём с указанным статусом
+func (s *ComplianceStats) Add(status IntakeStatus, count int) {
+	switch status {
+	case IntakeStatusPlanned:
+		s.Planned += count
+	case IntakeStatusTaken:
+		s.Taken += count
+	case IntakeStatusLate:
+		s.Late += count
+	case IntakeStatusSkipped:
+		s.Skipped += count
+	case IntakeStatusMissed:
+		s.Missed += count
+	}
+}
+
+// Resolved возвращает количество приёмов с итоговым статусом.
+// Запланированные приёмы ещё ожидают отметки и не учитываются.
+func (s *ComplianceStats) Resolved() int {
+	return s.Taken + s.Late + s.Skipped + s.Missed
+}
+
+// Rate возвращает процент принятых (вовремя или с опозданием) приёмов среди
+// приёмов с итоговым статусом или nil, если таких приёмов нет
+func (s *ComplianceStats) Rate() *float64 {
+	resolved := s.Resolved()
+	if resolved == 0 {
+		return nil
+	}
+	rate := float64(s.Taken+s.Late) / float64(resolved) * 100
+	return &rate
+}
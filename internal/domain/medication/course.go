@@ -0,0 +1,118 @@
+package medication
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CourseEndReason представляет причину завершения курса
+type CourseEndReason string
+
+const (
+	CourseEndCompleted CourseEndReason = "completed" // курс закончился по дате окончания
+	CourseEndStopped   CourseEndReason = "stopped"   // курс прекращён досрочно
+	CourseEndReplaced  CourseEndReason = "replaced"  // лекарство заменено другим
+)
+
+// IsValid проверяет, является ли причина допустимой
+func (r CourseEndReason) IsValid() bool {
+	switch r {
+	case CourseEndCompleted, CourseEndStopped, CourseEndReplaced:
+		return true
+	}
+	return false
+}
+
+// Course представляет курс приёма лекарства в истории.
+// Завершённые курсы хранятся отдельно от лекарства; текущий курс
+// собирается из активного лекарства и не имеет даты окончания и причины.
+type Course struct {
+	ID             uuid.UUID
+	UserID         uuid.UUID
+	MedicationID   uuid.UUID
+	MedicationName string
+	Dosage         string
+	StartDate      time.Time
+	EndDate        *time.Time
+	Reason         *CourseEndReason
+	ReplacedByID   *uuid.UUID
+	CreatedAt      time.Time
+}
+
+// NewEndedCourse создаёт запись о завершённом курсе лекарства
+func NewEndedCourse(med *Medication, endDate time.Time, reason CourseEndReason, replacedByID *uuid.UUID) *Course {
+	return &Course{
+		ID:             uuid.New(),
+		UserID:         med.UserID,
+		MedicationID:   med.ID,
+		MedicationName: med.Name,
+		Dosage:         med.Dosage,
+		StartDate:      med.StartDate,
+		EndDate:        &endDate,
+		Reason:         &reason,
+		ReplacedByID:   replacedByID,
+		CreatedAt:      time.Now(),
+	}
+}
+
+// CurrentCourse возвращает текущий курс активного лекарства
+func CurrentCourse(med *Medication) *Course {
+	return &Course{
+		UserID:         med.UserID,
+		MedicationID:   med.ID,
+		MedicationName: med.Name,
+		Dosage:         med.Dosage,
+		StartDate:      med.StartDate,
+		EndDate:        med.EndDate,
+		CreatedAt:      med.CreatedAt,
+	}
+}
+
+// IsOngoing проверяет, продолжается ли курс
+func (c *Course) IsOngoing() bool {
+	return c.Reason == nil
+}
+
+// NormalizeName приводит название лекарства к виду для группировки курсов
+func NormalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// CourseTimeline представляет историю курсов одного лекарства
+type CourseTimeline struct {
+	Name    string
+	Courses []*Course
+}
+
+// GroupCourses группирует курсы по названию лекарства без учёта регистра.
+// Курсы внутри группы упорядочены по дате начала, группы — по названию;
+// отображается название из последнего курса.
+func GroupCourses(courses []*Course) []*CourseTimeline {
+	byName := make(map[string]*CourseTimeline)
+	var keys []string
+	for _, course := range courses {
+		key := NormalizeName(course.MedicationName)
+		timeline, ok := byName[key]
+		if !ok {
+			timeline = &CourseTimeline{}
+			byName[key] = timeline
+			keys = append(keys, key)
+		}
+		timeline.Courses = append(timeline.Courses, course)
+	}
+	sort.Strings(keys)
+
+	timelines := make([]*CourseTimeline, 0, len(keys))
+	for _, key := range keys {
+		timeline := byName[key]
+		sort.SliceStable(timeline.Courses, func(i, j int) bool {
+			return timeline.Courses[i].StartDate.Before(timeline.Courses[j].StartDate)
+		})
+		timeline.Name = timeline.Courses[len(timeline.Courses)-1].MedicationName
+		timelines = append(timelines, timeline)
+	}
+	return timelines
+}
@@ -0,0 +1,149 @@
+package medication
+
+import (
+	"fmt"
+	"time"
+)
+
+// stockForecastDays — горизонт прогноза окончания запаса
+const stockForecastDays = 366
+
+// DosageUnit представляет единицу измерения дозы
+type DosageUnit string
+
+const (
+	DosageUnitMg      DosageUnit = "mg"
+	DosageUnitMcg     DosageUnit = "mcg"
+	DosageUnitG       DosageUnit = "g"
+	DosageUnitMl      DosageUnit = "ml"
+	DosageUnitIU      DosageUnit = "iu"
+	DosageUnitTablet  DosageUnit = "tablet"
+	DosageUnitCapsule DosageUnit = "capsule"
+	DosageUnitDrop    DosageUnit = "drop"
+	DosageUnitPuff    DosageUnit = "puff"
+	DosageUnitSachet  DosageUnit = "sachet"
+)
+
+// IsValid проверяет, является ли единица допустимой
+func (u DosageUnit) IsValid() bool {
+	switch u {
+	case DosageUnitMg, DosageUnitMcg, DosageUnitG, DosageUnitMl, DosageUnitIU,
+		DosageUnitTablet, DosageUnitCapsule, DosageUnitDrop, DosageUnitPuff, DosageUnitSachet:
+		return true
+	}
+	return false
+}
+
+// DosageForm представляет лекарственную форму
+type DosageForm string
+
+const (
+	DosageFormTablet    DosageForm = "tablet"
+	DosageFormCapsule   DosageForm = "capsule"
+	DosageFormLiquid    DosageForm = "liquid"
+	DosageFormInjection DosageForm = "injection"
+	DosageFormDrops     DosageForm = "drops"
+	DosageFormSpray     DosageForm = "spray"
+	DosageFormInhaler   DosageForm = "inhaler"
+	DosageFormTopical   DosageForm = "topical"
+	DosageFormPowder    DosageForm = "powder"
+	DosageFormOther     DosageForm = "other"
+)
+
+// IsValid проверяет, является ли форма допустимой
+func (f DosageForm) IsValid() bool {
+	switch f {
+	case DosageFormTablet, DosageFormCapsule, DosageFormLiquid, DosageFormInjection, DosageFormDrops,
+		DosageFormSpray, DosageFormInhaler, DosageFormTopical, DosageFormPowder, DosageFormOther:
+		return true
+	}
+	return false
+}
+
+// DosageDetails представляет структурированную дозу одного приёма.
+// Строка Medication.Dosage остаётся для отображения.
+type DosageDetails struct {
+	Amount float64
+	Unit   DosageUnit
+	Form   DosageForm
+}
+
+// Validate проверяет структурированную дозу
+func (d DosageDetails) Validate() error {
+	if d.Amount <= 0 {
+		return fmt.Errorf("%w: amount must be positive", ErrInvalidDosage)
+	}
+	if !d.Unit.IsValid() {
+		return fmt.Errorf("%w: unknown unit %q", ErrInvalidDosage, d.Unit)
+	}
+	if !d.Form.IsValid() {
+		return fmt.Errorf("%w: unknown form %q", ErrInvalidDosage, d.Form)
+	}
+	return nil
+}
+
+// TracksStock проверяет, ведётся ли учёт запаса: для него нужны доза и остаток
+func (m *Medication) TracksStock() bool {
+	return m.DosageDetails != nil && m.StockQuantity != nil
+}
+
+// SetStock задаёт остаток в единицах дозы; nil отключает учёт запаса
+func (m *Medication) SetStock(quantity *float64) error {
+	if quantity != nil && *quantity < 0 {
+		return ErrInvalidStock
+	}
+	m.StockQuantity = quantity
+	m.RefillRemindedAt = nil
+	m.UpdatedAt = time.Now()
+	return nil
+}
+
+// Refill пополняет запас на quantity единиц дозы и сбрасывает отметку о напоминании
+func (m *Medication) Refill(quantity float64) error {
+	if quantity <= 0 {
+		return ErrInvalidStock
+	}
+	stock := quantity
+	if m.StockQuantity != nil {
+		stock += *m.StockQuantity
+	}
+	return m.SetStock(&stock)
+}
+
+// MarkRefillReminded отмечает, что о пополнении текущего запаса уже напомнили
+func (m *Medication) MarkRefillReminded() {
+	now := time.Now()
+	m.RefillRemindedAt = &now
+}
+
+// RunsOutOn прогнозирует день (в часовом поясе loc), на который запаса уже
+// не хватит для приёма по расписанию. Возвращает nil, если запас не учитывается,
+// лекарство принимается по необходимости или запаса хватит на весь горизонт прогноза.
+func (m *Medication) RunsOutOn(now time.Time, loc *time.Location) *time.Time {
+	if !m.TracksStock() || m.ScheduleType == ScheduleTypeAsNeeded {
+		return nil
+	}
+
+	remaining := *m.StockQuantity
+	local := now.In(loc)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	for i := 0; i < stockForecastDays; i++ {
+		day := today.AddDate(0, 0, i)
+		for _, at := range m.PlannedTimes(day, loc) {
+			// Прошедшие сегодня приёмы уже списаны при отметке
+			if !at.After(now) {
+				continue
+			}
+			if remaining < m.DosageDetails.Amount {
+				return &day
+			}
+			remaining -= m.DosageDetails.Amount
+		}
+	}
+	return nil
+}
+
+// RefillReminderMessage возвращает текст напоминания о пополнении запаса
+func (m *Medication) RefillReminderMessage(runsOutOn time.Time) string {
+	return fmt.Sprintf("Запас «%s» закончится примерно %s. Пора пополнить.", m.Name, runsOutOn.Format("02.01.2006"))
+}
@@ -12,11 +12,16 @@ type Medication struct {
 	UserID        uuid.UUID
 	Name          string
 	Dosage        string
+	DosageDetails *DosageDetails // структурированная доза одного приёма
 	ScheduleType  ScheduleType
 	ScheduleDetails ScheduleDetails
 	StartDate     time.Time
 	EndDate       *time.Time
 	IsActive      bool
+	MaxDosesPer24h  *int           // ограничение для приёма по необходимости, задаётся пользователем
+	MinDoseInterval *time.Duration // минимальный интервал между приёмами по необходимости
+	StockQuantity    *float64   // остаток в единицах дозы; nil — запас не учитывается
+	RefillRemindedAt *time.Time // когда напомнили о пополнении текущего запаса
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 }
@@ -34,6 +39,10 @@ const (
 type ScheduleDetails struct {
 	Times []string // ["09:00", "21:00"]
 	Days  []int    // [1,2,3,4,5,6,7] для дней недели (1=Monday)
+	IntervalHours int            // приём каждые N часов от первого времени из Times
+	EveryNDays    int            // приём раз в N дней от даты начала (2 = через день)
+	Cycle         *ScheduleCycle // циклический курс, например 21 день приёма / 7 дней перерыва
+	DoseSteps     []DoseStep     // этапы с изменением дозы, например 2 таблетки 5 дней, затем 1
 }
 
 // NewMedication создаёт новое лекарство
@@ -94,17 +103,39 @@ func (m *Medication) Update(
 	m.UpdatedAt = time.Now()
 }
 
+// Validate проверяет согласованность данных лекарства
+func (m *Medication) Validate() error {
+	if m.Name == "" {
+		return ErrInvalidMedication
+	}
+	if m.EndDate != nil && m.EndDate.Before(m.StartDate) {
+		return ErrInvalidMedication
+	}
+	if m.DosageDetails != nil {
+		if err := m.DosageDetails.Validate(); err != nil {
+			return err
+		}
+	}
+	if m.StockQuantity != nil && *m.StockQuantity < 0 {
+		return ErrInvalidStock
+	}
+	return m.ScheduleDetails.Validate(m.ScheduleType)
+}
+
 // Deactivate деактивирует лекарство
 func (m *Medication) Deactivate() {
 	m.IsActive = false
 	m.UpdatedAt = time.Now()
 }
 
-// IsExpired проверяет, истёк ли срок приёма лекарства
-func (m *Medication) IsExpired() bool {
+// IsExpired проверяет, истёк ли срок приёма лекарства: день окончания
+// (в часовом поясе loc) целиком входит в курс
+func (m *Medication) IsExpired(now time.Time, loc *time.Location) bool {
 	if m.EndDate == nil {
 		return false
 	}
-	return time.Now().After(*m.EndDate)
+	local := now.In(loc)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	return today.After(calendarDate(*m.EndDate, loc))
 }
 
@@ -0,0 +1,19 @@
+package medication
+
+import "errors"
+
+var (
+	ErrMedicationNotFound  = errors.New("medication not found")
+	ErrUnauthorized        = errors.New("unauthorized access to medication")
+	ErrIntakeNotFound      = errors.New("medication intake not found")
+	ErrInvalidIntakeStatus = errors.New("intake status can only be set to planned, taken or skipped")
+	ErrNotAsNeeded         = errors.New("medication is not taken as needed")
+	ErrInvalidDoseLimits   = errors.New("dose limits must be positive")
+	ErrInvalidSchedule     = errors.New("invalid medication schedule")
+	ErrInvalidMedication   = errors.New("medication requires a name and an end date not before the start date")
+	ErrInvalidDosage       = errors.New("invalid dosage")
+	ErrInvalidStock        = errors.New("stock quantity must not be negative and refill must be positive")
+	ErrInvalidCourseEnd    = errors.New("invalid course end reason or replacement")
+	ErrMedicationInactive  = errors.New("medication is already inactive")
+	ErrIntakeMarkConflict  = errors.New("intake is being marked concurrently, try again")
+)
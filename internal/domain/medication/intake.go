@@ -6,13 +6,39 @@ import (
 	"github.com/google/uuid"
 )
 
+// IntakeStatus представляет статус приёма лекарства
+type IntakeStatus string
+
+const (
+	IntakeStatusPlanned IntakeStatus = "planned" // запланирован и ещё не отмечен
+	IntakeStatusTaken   IntakeStatus = "taken"   // принят вовремя
+	IntakeStatusSkipped IntakeStatus = "skipped" // сознательно пропущен пользователем
+	IntakeStatusMissed  IntakeStatus = "missed"  // не отмечен и автоматически признан пропущенным
+	IntakeStatusLate    IntakeStatus = "late"    // принят после того, как был признан пропущенным
+)
+
+// IsValid проверяет, является ли статус допустимым
+func (s IntakeStatus) IsValid() bool {
+	switch s {
+	case IntakeStatusPlanned, IntakeStatusTaken, IntakeStatusSkipped, IntakeStatusMissed, IntakeStatusLate:
+		return true
+	}
+	return false
+}
+
 // MedicationIntake представляет факт приёма лекарства
 type MedicationIntake struct {
 	ID            uuid.UUID
 	MedicationID  uuid.UUID
 	ScheduledTime time.Time
+	Status        IntakeStatus
+	PlannedDose   *string // доза по этапу курса, если она отличается от назначенной
 	TakenAt       *time.Time
-	IsTaken       bool
+	MissedAt      *time.Time // когда приём автоматически отмечен пропущенным
+	SkipReason    *string
+	ActualDose    *string // фактически принятая доза, если она отличается от назначенной
+	IsAsNeeded    bool    // приём по необходимости, а не по расписанию
+	Reason        *string // причина приёма по необходимости
 	Notes         *string
 	CreatedAt     time.Time
 }
@@ -27,25 +53,89 @@ func NewMedicationIntake(
 		ID:            uuid.New(),
 		MedicationID:  medicationID,
 		ScheduledTime: scheduledTime,
-		IsTaken:       false,
+		Status:        IntakeStatusPlanned,
 		CreatedAt:     now,
 	}
 }
 
-// MarkTaken отмечает приём лекарства как выполненный
-func (m *MedicationIntake) MarkTaken(notes *string) {
+// NewAsNeededIntake создаёт запись о приёме лекарства по необходимости.
+// Такой приём не планируется заранее, поэтому сразу считается принятым.
+func NewAsNeededIntake(
+	medicationID uuid.UUID,
+	takenAt time.Time,
+	dose *string,
+	reason *string,
+) *MedicationIntake {
+	intake := NewMedicationIntake(medicationID, takenAt)
+	intake.Status = IntakeStatusTaken
+	intake.TakenAt = &takenAt
+	intake.ActualDose = dose
+	intake.IsAsNeeded = true
+	intake.Reason = reason
+	return intake
+}
+
+// MarkTaken отмечает приём лекарства как выполненный.
+// Приём после отметки «пропущен» считается запоздалым.
+func (m *MedicationIntake) MarkTaken(actualDose, notes *string) {
 	now := time.Now()
-	m.IsTaken = true
+	if m.Status == IntakeStatusMissed || m.Status == IntakeStatusLate {
+		m.Status = IntakeStatusLate
+	} else {
+		m.Status = IntakeStatusTaken
+	}
 	m.TakenAt = &now
+	m.SkipReason = nil
+	m.ActualDose = actualDose
 	if notes != nil {
 		m.Notes = notes
 	}
 }
 
-// MarkNotTaken отмечает приём лекарства как невыполненный
-func (m *MedicationIntake) MarkNotTaken() {
-	m.IsTaken = false
+// Skip отмечает приём как сознательно пропущенный с необязательной причиной
+func (m *MedicationIntake) Skip(reason, notes *string) {
+	m.Status = IntakeStatusSkipped
 	m.TakenAt = nil
-	m.Notes = nil
+	m.ActualDose = nil
+	m.SkipReason = reason
+	if notes != nil {
+		m.Notes = notes
+	}
 }
 
+// ResetToPlanned снимает отметку о приёме или пропуске, сохраняя заметки.
+// Автоматически пропущенный приём после сброса снова становится пропущенным.
+func (m *MedicationIntake) ResetToPlanned() {
+	m.Status = IntakeStatusPlanned
+	if m.MissedAt != nil {
+		m.Status = IntakeStatusMissed
+	}
+	m.TakenAt = nil
+	m.SkipReason = nil
+	m.ActualDose = nil
+}
+
+// MarkMissed отмечает приём пропущенным, если он так и не был отмечен
+func (m *MedicationIntake) MarkMissed() {
+	if m.Status != IntakeStatusPlanned {
+		return
+	}
+	now := time.Now()
+	m.Status = IntakeStatusMissed
+	m.MissedAt = &now
+}
+
+// IsTaken проверяет, принято ли лекарство (вовремя или с опозданием)
+func (m *MedicationIntake) IsTaken() bool {
+	return m.Status == IntakeStatusTaken || m.Status == IntakeStatusLate
+}
+
+// IsPlanned проверяет, ожидает ли приём отметки
+func (m *MedicationIntake) IsPlanned() bool {
+	return m.Status == IntakeStatusPlanned
+}
+
+// IsMissed проверяет, отмечен ли приём пропущенным
+func (m *MedicationIntake) IsMissed() bool {
+	return m.Status == IntakeStatusMissed
+}
@@ -0,0 +1,17 @@
+package medication
+
+import "fmt"
+
+// IntakeReminderMessage возвращает текст напоминания о приёме с дозой этапа курса, если она задана
+func (m *Medication) IntakeReminderMessage(intake *MedicationIntake) string {
+	dose := m.Dosage
+	if intake.PlannedDose != nil {
+		dose = *intake.PlannedDose
+	}
+	return fmt.Sprintf("Время принять «%s» (%s).", m.Name, dose)
+}
+
+// IntakeFollowUpMessage возвращает текст повторного напоминания о неотмеченном приёме
+func (m *Medication) IntakeFollowUpMessage() string {
+	return fmt.Sprintf("Ты не отметил(а) приём «%s». Если уже принял(а) — нажми «Принял(а)».", m.Name)
+}
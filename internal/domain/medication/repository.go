@@ -18,11 +18,31 @@ type Repository interface {
 	// FindByUserID возвращает все лекарства пользователя
 	FindByUserID(ctx context.Context, userID uuid.UUID, activeOnly bool) ([]*Medication, error)
 	
-	// Update обновляет лекарство
+	// Update обновляет лекарство, кроме запаса: остаток и отметка о напоминании
+	// о пополнении меняются только методами ниже, чтобы параллельные отметки
+	// приёмов не затирали друг друга
 	Update(ctx context.Context, medication *Medication) error
+
+	// SetStock задаёт остаток и снимает отметку о напоминании о пополнении;
+	// nil отключает учёт запаса
+	SetStock(ctx context.Context, id uuid.UUID, quantity *float64) error
+
+	// AdjustStock атомарно изменяет остаток на delta единиц дозы, не опуская его
+	// ниже нуля, и возвращает новый остаток; без учёта запаса возвращает nil
+	AdjustStock(ctx context.Context, id uuid.UUID, delta float64) (*float64, error)
+
+	// Refill атомарно пополняет запас на quantity единиц дозы, снимает отметку
+	// о напоминании о пополнении и возвращает новый остаток
+	Refill(ctx context.Context, id uuid.UUID, quantity float64) (*float64, error)
+
+	// MarkRefillReminded сохраняет время напоминания о пополнении текущего запаса
+	MarkRefillReminded(ctx context.Context, id uuid.UUID, at time.Time) error
 	
 	// Delete удаляет лекарство
 	Delete(ctx context.Context, id uuid.UUID) error
+	
+	// FindActive возвращает активные лекарства всех пользователей
+	FindActive(ctx context.Context) ([]*Medication, error)
 }
 
 // IntakeRepository определяет интерфейс для работы с приёмами лекарств
@@ -36,13 +56,48 @@ type IntakeRepository interface {
 	// FindByMedicationAndDate возвращает приёмы за конкретную дату
 	FindByMedicationAndDate(ctx context.Context, medicationID uuid.UUID, date time.Time) ([]*MedicationIntake, error)
 	
+	// FindByMedicationAndPeriod возвращает приёмы за период
+	FindByMedicationAndPeriod(ctx context.Context, medicationID uuid.UUID, startDate, endDate time.Time) ([]*MedicationIntake, error)
+	
+	// FindByUserAndPeriod возвращает приёмы всех лекарств пользователя за период
+	FindByUserAndPeriod(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]*MedicationIntake, error)
+	
 	// Update обновляет запись о приёме
 	Update(ctx context.Context, intake *MedicationIntake) error
 	
+	// UpdateMark в одной транзакции сохраняет отметку приёма, если его статус всё ещё
+	// равен from, и изменяет остаток лекарства на stockDelta. Возвращает false, если
+	// статус успел смениться, и новый остаток, если он менялся
+	UpdateMark(ctx context.Context, intake *MedicationIntake, from IntakeStatus, stockDelta float64) (bool, *float64, error)
+	
+	// Delete удаляет запись о приёме
+	Delete(ctx context.Context, id uuid.UUID) error
+	
+	// FindUnmarked возвращает неотмеченные и не пропущенные приёмы всех пользователей,
+	// запланированные в диапазоне [from, to]
+	FindUnmarked(ctx context.Context, from, to time.Time) ([]*MedicationIntake, error)
+	
+	// MarkMissed отмечает пропущенными те из приёмов ids, что всё ещё в статусе planned,
+	// и возвращает ID отмеченных
+	MarkMissed(ctx context.Context, ids []uuid.UUID, at time.Time) ([]uuid.UUID, error)
+	
 	// GetUpcomingIntakes возвращает предстоящие приёмы
 	GetUpcomingIntakes(ctx context.Context, userID uuid.UUID, fromTime time.Time, limit int) ([]*MedicationIntake, error)
 	
-	// GetComplianceRate возвращает процент соблюдения режима приёма
-	GetComplianceRate(ctx context.Context, medicationID uuid.UUID, startDate, endDate time.Time) (float64, error)
+	// GetComplianceStats возвращает количество приёмов по каждому статусу за период
+	GetComplianceStats(ctx context.Context, medicationID uuid.UUID, startDate, endDate time.Time) (*ComplianceStats, error)
 }
 
+// CourseRepository определяет интерфейс для работы с историей курсов
+type CourseRepository interface {
+	// End в одной транзакции сохраняет деактивированное лекарство и записывает
+	// завершённый курс. Возвращает ErrMedicationInactive, если лекарство
+	// уже деактивировано
+	End(ctx context.Context, med *Medication, course *Course) error
+
+	// FindByUserID возвращает завершённые курсы пользователя в хронологическом порядке
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*Course, error)
+
+	// FindEndedBetween возвращает курсы пользователя, завершённые в периоде
+	FindEndedBetween(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]*Course, error)
+}
@@ -0,0 +1,214 @@
+package medication
+
+import (
+	"fmt"
+	"time"
+)
+
+// ScheduleCycle описывает циклический курс: DaysOn дней приёма, затем DaysOff дней перерыва
+type ScheduleCycle struct {
+	DaysOn  int
+	DaysOff int
+}
+
+// DoseStep описывает этап курса с постепенным изменением дозы.
+// Days — длительность этапа; 0 у последнего этапа означает «до конца курса».
+type DoseStep struct {
+	Days int
+	Dose string
+}
+
+// Validate проверяет расписание для указанного типа.
+// Для приёма по необходимости расписание не используется.
+func (d ScheduleDetails) Validate(scheduleType ScheduleType) error {
+	switch scheduleType {
+	case ScheduleTypeDaily, ScheduleTypeWeekly:
+	case ScheduleTypeAsNeeded:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown schedule type %q", ErrInvalidSchedule, scheduleType)
+	}
+
+	for _, value := range d.Times {
+		if _, err := time.Parse("15:04", value); err != nil {
+			return fmt.Errorf("%w: time %q must be in HH:MM format", ErrInvalidSchedule, value)
+		}
+	}
+	for _, day := range d.Days {
+		if day < 1 || day > 7 {
+			return fmt.Errorf("%w: weekday %d must be between 1 and 7", ErrInvalidSchedule, day)
+		}
+	}
+	if scheduleType == ScheduleTypeWeekly && len(d.Days) == 0 {
+		return fmt.Errorf("%w: weekly schedule requires days", ErrInvalidSchedule)
+	}
+
+	if d.IntervalHours < 0 || d.IntervalHours > 24*7 {
+		return fmt.Errorf("%w: interval must be between 1 and 168 hours", ErrInvalidSchedule)
+	}
+	if d.IntervalHours > 0 && len(d.Times) > 1 {
+		return fmt.Errorf("%w: interval schedule accepts only the first intake time", ErrInvalidSchedule)
+	}
+	if d.IntervalHours == 0 && len(d.Times) == 0 {
+		return fmt.Errorf("%w: either times or interval is required", ErrInvalidSchedule)
+	}
+
+	if d.EveryNDays < 0 {
+		return fmt.Errorf("%w: day step must be positive", ErrInvalidSchedule)
+	}
+	if d.Cycle != nil && (d.Cycle.DaysOn <= 0 || d.Cycle.DaysOff <= 0) {
+		return fmt.Errorf("%w: cycle requires positive days on and days off", ErrInvalidSchedule)
+	}
+
+	for i, step := range d.DoseSteps {
+		if step.Dose == "" {
+			return fmt.Errorf("%w: dose step %d has no dose", ErrInvalidSchedule, i+1)
+		}
+		if step.Days < 0 || (step.Days == 0 && i != len(d.DoseSteps)-1) {
+			return fmt.Errorf("%w: only the last dose step may be open-ended", ErrInvalidSchedule)
+		}
+	}
+
+	return nil
+}
+
+// PlannedTimes возвращает запланированные моменты приёма в календарный день day
+// (в часовом поясе loc). Для приёма по необходимости расписания нет.
+func (m *Medication) PlannedTimes(day time.Time, loc *time.Location) []time.Time {
+	if !m.IsActive || m.ScheduleType == ScheduleTypeAsNeeded {
+		return nil
+	}
+
+	local := day.In(loc)
+	date := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	start := calendarDate(m.StartDate, loc)
+	if date.Before(start) {
+		return nil
+	}
+	if m.EndDate != nil && date.After(calendarDate(*m.EndDate, loc)) {
+		return nil
+	}
+
+	details := m.ScheduleDetails
+	if len(details.Days) > 0 && !containsDay(details.Days, isoWeekday(date)) {
+		return nil
+	}
+	dayIndex := calendarDaysBetween(start, date)
+	if details.EveryNDays > 1 && dayIndex%details.EveryNDays != 0 {
+		return nil
+	}
+	if c := details.Cycle; c != nil && dayIndex%(c.DaysOn+c.DaysOff) >= c.DaysOn {
+		return nil
+	}
+	if _, ok := details.doseStepAt(dayIndex); !ok {
+		return nil
+	}
+
+	if details.IntervalHours > 0 {
+		return details.intervalTimes(start, date)
+	}
+
+	times := make([]time.Time, 0, len(details.Times))
+	for _, value := range details.Times {
+		clock, err := time.Parse("15:04", value)
+		if err != nil {
+			continue
+		}
+		times = append(times, atClock(date, clock))
+	}
+	return times
+}
+
+// DoseOn возвращает дозу на календарный день day по этапам курса
+// или пустую строку, если доза не меняется по этапам
+func (m *Medication) DoseOn(day time.Time, loc *time.Location) string {
+	local := day.In(loc)
+	date := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	step, ok := m.ScheduleDetails.doseStepAt(calendarDaysBetween(calendarDate(m.StartDate, loc), date))
+	if !ok {
+		return ""
+	}
+	return step.Dose
+}
+
+// doseStepAt возвращает этап дозировки для дня курса с номером dayIndex (с нуля).
+// Без этапов любой день допустим; после окончания конечных этапов приёмов нет.
+func (d ScheduleDetails) doseStepAt(dayIndex int) (DoseStep, bool) {
+	if len(d.DoseSteps) == 0 {
+		return DoseStep{}, true
+	}
+	for _, step := range d.DoseSteps {
+		if step.Days == 0 || dayIndex < step.Days {
+			return step, true
+		}
+		dayIndex -= step.Days
+	}
+	return DoseStep{}, false
+}
+
+// intervalTimes возвращает моменты приёма с интервалом IntervalHours, попадающие
+// в день date. Отсчёт непрерывный от первого приёма в день начала курса, поэтому
+// интервал, не кратный суткам, корректно переносится между днями.
+func (d ScheduleDetails) intervalTimes(start, date time.Time) []time.Time {
+	first := start
+	if len(d.Times) > 0 {
+		if clock, err := time.Parse("15:04", d.Times[0]); err == nil {
+			first = atClock(start, clock)
+		}
+	}
+
+	interval := time.Duration(d.IntervalHours) * time.Hour
+	next := date.AddDate(0, 0, 1)
+	at := first
+	if gap := date.Sub(first); gap > 0 {
+		steps := gap / interval
+		at = first.Add(steps * interval)
+		if at.Before(date) {
+			at = at.Add(interval)
+		}
+	}
+
+	var times []time.Time
+	for ; at.Before(next); at = at.Add(interval) {
+		times = append(times, at)
+	}
+	return times
+}
+
+// atClock возвращает момент времени clock в день date по местным часам.
+// В дни перехода на летнее время и обратно сутки короче или длиннее 24 часов,
+// поэтому время собирается из календарных полей, а не прибавляется к полуночи
+func atClock(date, clock time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), 0, 0, date.Location())
+}
+
+// calendarDaysBetween возвращает число календарных дней от from до to
+func calendarDaysBetween(from, to time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
+
+// calendarDate переносит дату из колонки DATE (полночь UTC) в часовой пояс loc
+func calendarDate(t time.Time, loc *time.Location) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// containsDay проверяет, входит ли день недели в список
+func containsDay(days []int, day int) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// isoWeekday возвращает день недели в формате 1=Monday … 7=Sunday
+func isoWeekday(t time.Time) int {
+	if t.Weekday() == time.Sunday {
+		return 7
+	}
+	return int(t.Weekday())
+}
@@ -0,0 +1,41 @@
+package medication
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestPlannedTimesKeepsLocalClockAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+
+	med := &Medication{
+		ID:              uuid.New(),
+		UserID:          uuid.New(),
+		Name:            "Ибупрофен",
+		ScheduleType:    ScheduleTypeDaily,
+		ScheduleDetails: ScheduleDetails{Times: []string{"09:00", "21:00"}},
+		StartDate:       time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		IsActive:        true,
+	}
+
+	for _, day := range []time.Time{
+		time.Date(2026, 3, 29, 12, 0, 0, 0, loc),  // переход на летнее время, сутки 23 часа
+		time.Date(2026, 10, 25, 12, 0, 0, 0, loc), // переход на зимнее время, сутки 25 часов
+	} {
+		times := med.PlannedTimes(day, loc)
+		if len(times) != 2 {
+			t.Fatalf("%s: got %d planned times, want 2", day.Format("2006-01-02"), len(times))
+		}
+		for i, want := range []int{9, 21} {
+			got := times[i].In(loc)
+			if got.Day() != day.Day() || got.Hour() != want || got.Minute() != 0 {
+				t.Errorf("%s: planned time %d is %s, want %02d:00 local", day.Format("2006-01-02"), i, got.Format("2006-01-02 15:04 MST"), want)
+			}
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package notification
+
+import "context"
+
+// Button представляет inline-кнопку под сообщением
+type Button struct {
+	Text         string
+	CallbackData string
+}
+
+// Message представляет сообщение пользователю
+type Message struct {
+	Text    string
+	Buttons []Button
+}
+
+// Notifier определяет интерфейс доставки сообщений пользователю в чат
+type Notifier interface {
+	// Send отправляет сообщение в чат
+	Send(ctx context.Context, chatID int64, message Message) error
+}
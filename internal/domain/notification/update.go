@@ -0,0 +1,16 @@
+package notification
+
+import (
+	"context"
+	"time"
+)
+
+// UpdateRepository запоминает принятые обновления бота, чтобы повторная
+// доставка того же обновления не обрабатывалась дважды
+type UpdateRepository interface {
+	// Claim отмечает обновление как принятое; false — обновление уже было принято
+	Claim(ctx context.Context, updateID int64) (bool, error)
+
+	// DeleteBefore удаляет отметки, принятые раньше before, и возвращает их число
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
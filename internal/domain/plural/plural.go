@@ -0,0 +1,16 @@
+// Package plural склоняет русские слова по числу для текстов уведомлений
+package plural
+
+import "fmt"
+
+// Days склоняет слово «день» по числу: «1 день», «3 дня», «5 дней»
+func Days(n int) string {
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return fmt.Sprintf("%d день", n)
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 10 || n%100 >= 20):
+		return fmt.Sprintf("%d дня", n)
+	default:
+		return fmt.Sprintf("%d дней", n)
+	}
+}
@@ -0,0 +1,73 @@
+package reminder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Action представляет действие, доступное из уведомления (inline-кнопка бота)
+type Action string
+
+const (
+	// ActionLogSymptom открывает запись самочувствия
+	ActionLogSymptom Action = "log"
+	// ActionSnooze откладывает напоминание
+	ActionSnooze Action = "snooze"
+	// ActionDone отмечает, что напоминание выполнено
+	ActionDone Action = "done"
+	// ActionPrepareReport готовит отчёт к визиту и присылает его в чат
+	ActionPrepareReport Action = "report"
+	// ActionTake отмечает приём лекарства
+	ActionTake Action = "take"
+)
+
+// callbackPrefix отличает callback-данные напоминаний от прочих кнопок бота
+const callbackPrefix = "rem"
+
+// ActionButton представляет кнопку действия с подписью
+type ActionButton struct {
+	Action Action
+	Label  string
+}
+
+// Actions возвращает кнопки, доступные для напоминания данного типа
+func (r *Reminder) Actions() []ActionButton {
+	switch r.Type {
+	case TypeSymptomCheck:
+		return []ActionButton{{Action: ActionLogSymptom, Label: "Записать самочувствие"}}
+	case TypeAnalysis:
+		return []ActionButton{
+			{Action: ActionDone, Label: "Уже сдал(а)"},
+			{Action: ActionSnooze, Label: "Напомнить завтра"},
+		}
+	case TypeMedication:
+		return []ActionButton{
+			{Action: ActionTake, Label: "Принял(а)"},
+			{Action: ActionSnooze, Label: "Отложить"},
+		}
+	case TypeDoctorVisit:
+		return []ActionButton{{Action: ActionPrepareReport, Label: "Подготовить отчёт"}}
+	default:
+		return nil
+	}
+}
+
+// CallbackData кодирует действие над напоминанием для inline-кнопки
+func (r *Reminder) CallbackData(action Action) string {
+	return fmt.Sprintf("%s:%s:%s", callbackPrefix, action, r.ID)
+}
+
+// ParseCallbackData разбирает callback-данные inline-кнопки напоминания
+func ParseCallbackData(data string) (Action, uuid.UUID, error) {
+	parts := strings.Split(data, ":")
+	if len(parts) != 3 || parts[0] != callbackPrefix {
+		return "", uuid.Nil, ErrInvalidCallbackData
+	}
+	id, err := uuid.Parse(parts[2])
+	if err != nil {
+		return "", uuid.Nil, ErrInvalidCallbackData
+	}
+	return Action(parts[1]), id, nil
+}
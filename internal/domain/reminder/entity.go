@@ -0,0 +1,111 @@
+package reminder
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reminder представляет запланированное уведомление пользователю
+type Reminder struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	Type          Type
+	RelatedID     *uuid.UUID
+	ScheduledTime time.Time
+	// PostponedUntil — время доставки после откладывания (snooze или тихие часы);
+	// ScheduledTime при этом сохраняет исходный план
+	PostponedUntil *time.Time
+	Message        string
+	IsSent         bool
+	SentAt         *time.Time
+	CreatedAt      time.Time
+}
+
+// Type представляет тип напоминания
+type Type string
+
+const (
+	TypeMedication   Type = "medication"
+	TypeAnalysis     Type = "analysis"
+	TypeSymptomCheck Type = "symptom_check"
+	TypeMilestone    Type = "milestone"
+	TypeDoctorVisit  Type = "doctor_visit"
+	TypeRefill       Type = "refill"
+)
+
+// NewReminder создаёт новое напоминание
+func NewReminder(
+	userID uuid.UUID,
+	reminderType Type,
+	relatedID *uuid.UUID,
+	scheduledTime time.Time,
+	message string,
+) *Reminder {
+	return &Reminder{
+		ID:            uuid.New(),
+		UserID:        userID,
+		Type:          reminderType,
+		RelatedID:     relatedID,
+		ScheduledTime: scheduledTime,
+		Message:       message,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// Reschedule переносит напоминание на другое время
+func (r *Reminder) Reschedule(scheduledTime time.Time) {
+	r.ScheduledTime = scheduledTime
+	r.PostponedUntil = nil
+	r.IsSent = false
+	r.SentAt = nil
+}
+
+// DefaultSnoozeDuration возвращает срок откладывания по умолчанию для типа напоминания
+func DefaultSnoozeDuration(reminderType Type) time.Duration {
+	switch reminderType {
+	case TypeAnalysis:
+		return 24 * time.Hour
+	case TypeMedication:
+		return 15 * time.Minute
+	default:
+		return time.Hour
+	}
+}
+
+// RespectsQuietHours проверяет, нужно ли откладывать напоминание на время тихих часов.
+// Время приёма лекарств пользователь задаёт сам, поэтому такие напоминания не откладываются.
+func (r *Reminder) RespectsQuietHours() bool {
+	return r.Type != TypeMedication
+}
+
+// DeliveryTime возвращает время, когда напоминание должно быть доставлено
+func (r *Reminder) DeliveryTime() time.Time {
+	if r.PostponedUntil != nil {
+		return *r.PostponedUntil
+	}
+	return r.ScheduledTime
+}
+
+// Postpone откладывает доставку напоминания до until, не меняя исходный план
+func (r *Reminder) Postpone(until time.Time) {
+	r.PostponedUntil = &until
+	r.IsSent = false
+	r.SentAt = nil
+}
+
+// Snooze откладывает напоминание на duration от текущего момента
+func (r *Reminder) Snooze(duration time.Duration) error {
+	if duration <= 0 {
+		return ErrInvalidSnoozeDuration
+	}
+	r.Postpone(time.Now().Add(duration))
+	return nil
+}
+
+// MarkSent отмечает напоминание как отправленное
+func (r *Reminder) MarkSent() {
+	now := time.Now()
+	r.IsSent = true
+	r.SentAt = &now
+}
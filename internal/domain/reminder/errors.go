@@ -0,0 +1,12 @@
+package reminder
+
+import "errors"
+
+var (
+	ErrReminderNotFound = errors.New("reminder not found")
+	ErrUnauthorized     = errors.New("unauthorized access to reminder")
+
+	ErrInvalidCallbackData   = errors.New("invalid reminder callback data")
+	ErrInvalidSnoozeDuration = errors.New("snooze duration must be positive")
+	ErrUnsupportedAction     = errors.New("action is not supported for this reminder")
+)
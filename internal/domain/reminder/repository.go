@@ -0,0 +1,38 @@
+package reminder
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository определяет интерфейс для работы с напоминаниями
+type Repository interface {
+	// Create создаёт новое напоминание
+	Create(ctx context.Context, reminder *Reminder) error
+
+	// GetByID возвращает напоминание по ID
+	GetByID(ctx context.Context, id uuid.UUID) (*Reminder, error)
+
+	// Update обновляет напоминание
+	Update(ctx context.Context, reminder *Reminder) error
+
+	// Delete удаляет напоминание
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// Exists проверяет, запланировано ли уже такое напоминание
+	Exists(ctx context.Context, userID uuid.UUID, reminderType Type, relatedID *uuid.UUID, scheduledTime time.Time) (bool, error)
+
+	// FindDue возвращает неотправленные напоминания, время доставки которых наступило
+	FindDue(ctx context.Context, before time.Time, limit int) ([]*Reminder, error)
+
+	// FindPendingByType возвращает все неотправленные напоминания указанного типа
+	FindPendingByType(ctx context.Context, reminderType Type) ([]*Reminder, error)
+
+	// FindPendingByRelated возвращает неотправленные напоминания, связанные с сущностью
+	FindPendingByRelated(ctx context.Context, reminderType Type, relatedID uuid.UUID) ([]*Reminder, error)
+
+	// DeletePendingByRelated удаляет неотправленные напоминания, связанные с сущностью
+	DeletePendingByRelated(ctx context.Context, reminderType Type, relatedID uuid.UUID) error
+}
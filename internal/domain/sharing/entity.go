@@ -0,0 +1,221 @@
+package sharing
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Scope представляет раздел данных, доступный по выдаче
+type Scope string
+
+const (
+	ScopeSymptoms     Scope = "symptoms"
+	ScopeAnalyses     Scope = "analyses"
+	ScopeMedications  Scope = "medications"
+	ScopeDoctorVisits Scope = "doctor_visits"
+)
+
+// IsValid проверяет, что раздел известен
+func (s Scope) IsValid() bool {
+	switch s {
+	case ScopeSymptoms, ScopeAnalyses, ScopeMedications, ScopeDoctorVisits:
+		return true
+	}
+	return false
+}
+
+// Grant представляет выданный владельцем доступ к его данным только для чтения:
+// конкретному пользователю Telegram или по анонимной ссылке
+type Grant struct {
+	ID      uuid.UUID
+	OwnerID uuid.UUID // профиль, данными которого делятся
+	// GranteeTelegramUserID задан у доступа для пользователя Telegram
+	GranteeTelegramUserID *int64
+	// TokenHash задан у доступа по ссылке; сам токен хранится только у получателя
+	TokenHash *string
+	Scopes    []Scope
+	// DataStartDate и DataEndDate ограничивают период данных, включительно
+	DataStartDate *time.Time
+	DataEndDate   *time.Time
+	ExpiresAt     time.Time
+	RevokedAt     *time.Time
+	CreatedAt     time.Time
+}
+
+// NewUserGrant создаёт доступ для пользователя Telegram
+func NewUserGrant(
+	ownerID uuid.UUID,
+	granteeTelegramUserID int64,
+	scopes []Scope,
+	dataStartDate *time.Time,
+	dataEndDate *time.Time,
+	expiresAt time.Time,
+) (*Grant, error) {
+	if granteeTelegramUserID <= 0 {
+		return nil, ErrInvalidGrant
+	}
+	grant := newGrant(ownerID, scopes, dataStartDate, dataEndDate, expiresAt)
+	grant.GranteeTelegramUserID = &granteeTelegramUserID
+	if err := grant.Validate(time.Now()); err != nil {
+		return nil, err
+	}
+	return grant, nil
+}
+
+// NewLinkGrant создаёт доступ по анонимной ссылке и возвращает токен ссылки.
+// Токен показывается владельцу один раз, в БД хранится только его хэш.
+func NewLinkGrant(
+	ownerID uuid.UUID,
+	scopes []Scope,
+	dataStartDate *time.Time,
+	dataEndDate *time.Time,
+	expiresAt time.Time,
+) (*Grant, string, error) {
+	grant := newGrant(ownerID, scopes, dataStartDate, dataEndDate, expiresAt)
+	if err := grant.Validate(time.Now()); err != nil {
+		return nil, "", err
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, "", err
+	}
+	hash := HashToken(token)
+	grant.TokenHash = &hash
+	return grant, token, nil
+}
+
+// newGrant заполняет общие поля доступа
+func newGrant(ownerID uuid.UUID, scopes []Scope, dataStartDate, dataEndDate *time.Time, expiresAt time.Time) *Grant {
+	return &Grant{
+		ID:            uuid.New(),
+		OwnerID:       ownerID,
+		Scopes:        scopes,
+		DataStartDate: dataStartDate,
+		DataEndDate:   dataEndDate,
+		ExpiresAt:     expiresAt,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// Validate проверяет разделы, период данных и срок действия доступа
+func (g *Grant) Validate(now time.Time) error {
+	if len(g.Scopes) == 0 {
+		return ErrInvalidGrant
+	}
+	for _, scope := range g.Scopes {
+		if !scope.IsValid() {
+			return ErrInvalidGrant
+		}
+	}
+	if g.DataStartDate != nil && g.DataEndDate != nil && g.DataEndDate.Before(*g.DataStartDate) {
+		return ErrInvalidGrant
+	}
+	if !g.ExpiresAt.After(now) {
+		return ErrInvalidGrant
+	}
+	return nil
+}
+
+// IsLink проверяет, выдан ли доступ по анонимной ссылке
+func (g *Grant) IsLink() bool {
+	return g.TokenHash != nil
+}
+
+// IsActive проверяет, что доступ не отозван и не истёк
+func (g *Grant) IsActive(now time.Time) bool {
+	return g.RevokedAt == nil && now.Before(g.ExpiresAt)
+}
+
+// Allows проверяет, что доступ включает все перечисленные разделы
+func (g *Grant) Allows(scopes ...Scope) bool {
+	for _, required := range scopes {
+		found := false
+		for _, scope := range g.Scopes {
+			if scope == required {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// ClampPeriod ограничивает запрошенный период периодом данных доступа.
+// nil-границы означают «без ограничения».
+func (g *Grant) ClampPeriod(start, end *time.Time) (*time.Time, *time.Time) {
+	if g.DataStartDate != nil && (start == nil || start.Before(*g.DataStartDate)) {
+		start = g.DataStartDate
+	}
+	if g.DataEndDate != nil && (end == nil || end.After(*g.DataEndDate)) {
+		end = g.DataEndDate
+	}
+	return start, end
+}
+
+// OverlapsPeriod проверяет, пересекается ли период [start, end] с периодом данных
+// доступа; end == nil означает период, который ещё не закончился
+func (g *Grant) OverlapsPeriod(start time.Time, end *time.Time) bool {
+	if g.DataEndDate != nil && start.After(*g.DataEndDate) {
+		return false
+	}
+	if g.DataStartDate != nil && end != nil && end.Before(*g.DataStartDate) {
+		return false
+	}
+	return true
+}
+
+// Revoke отзывает доступ; повторный отзыв ничего не меняет
+func (g *Grant) Revoke() {
+	if g.RevokedAt != nil {
+		return
+	}
+	now := time.Now()
+	g.RevokedAt = &now
+}
+
+// AccessLogEntry представляет одно обращение получателя к данным владельца
+type AccessLogEntry struct {
+	ID      uuid.UUID
+	GrantID uuid.UUID
+	OwnerID uuid.UUID
+	// GranteeUserID не задан при обращении по анонимной ссылке
+	GranteeUserID *uuid.UUID
+	Field         string // запрошенное поле GraphQL, например "medicationHistory"
+	AccessedAt    time.Time
+}
+
+// NewAccessLogEntry создаёт запись журнала обращений
+func NewAccessLogEntry(grant *Grant, granteeUserID *uuid.UUID, field string) *AccessLogEntry {
+	return &AccessLogEntry{
+		ID:            uuid.New(),
+		GrantID:       grant.ID,
+		OwnerID:       grant.OwnerID,
+		GranteeUserID: granteeUserID,
+		Field:         field,
+		AccessedAt:    time.Now(),
+	}
+}
+
+// HashToken возвращает хэш токена ссылки для хранения и поиска
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// newToken генерирует случайный токен ссылки
+func newToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,17 @@
+package sharing
+
+import "errors"
+
+var (
+	ErrGrantNotFound = errors.New("share grant not found")
+	ErrGrantInactive = errors.New("share grant is revoked or expired")
+	ErrInvalidGrant  = errors.New("share grant requires scopes, a valid data period and a future expiry")
+	ErrAccessDenied  = errors.New("not allowed by share grant")
+	ErrUnauthorized  = errors.New("unauthorized")
+
+	ErrReportLinkNotFound = errors.New("report link not found")
+	ErrReportLinkInactive = errors.New("report link is revoked, expired or used up")
+	ErrInvalidReportLink  = errors.New("report link requires a valid period, expiry, view limit and a 4-8 digit PIN if set")
+	ErrPINRequired        = errors.New("report link requires a PIN")
+	ErrInvalidPIN         = errors.New("invalid PIN")
+)
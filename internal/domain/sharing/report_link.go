@@ -0,0 +1,140 @@
+package sharing
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxReportLinkViews ограничивает число просмотров одной ссылки на отчёт
+	MaxReportLinkViews = 20
+	// MaxReportLinkTTL ограничивает срок жизни ссылки на отчёт
+	MaxReportLinkTTL = 7 * 24 * time.Hour
+	// MaxPINAttempts — после стольких неверных PIN ссылка блокируется
+	MaxPINAttempts = 5
+	minPINLength   = 4
+	maxPINLength   = 8
+)
+
+// ReportLink представляет одноразовую ссылку на веб-страницу отчёта к визиту,
+// которую пациент передаёт врачу. Ссылка перестаёт работать по истечении срока,
+// после MaxViews просмотров, после отзыва или после серии неверных PIN.
+type ReportLink struct {
+	ID      uuid.UUID
+	OwnerID uuid.UUID // профиль, чей отчёт открывается по ссылке
+	VisitID uuid.UUID
+	// TokenHash — хэш токена из ссылки; сам токен хранится только у получателя
+	TokenHash string
+	// PINHash задан, если при открытии нужно ввести PIN, который пациент сообщает врачу
+	PINHash *string
+	// PeriodStart и PeriodEnd задают период данных отчёта
+	PeriodStart       time.Time
+	PeriodEnd         time.Time
+	MaxViews          int
+	Views             int
+	FailedPINAttempts int
+	ExpiresAt         time.Time
+	RevokedAt         *time.Time
+	CreatedAt         time.Time
+}
+
+// NewReportLink создаёт ссылку на отчёт и возвращает её токен.
+// Токен показывается владельцу один раз, в БД хранится только его хэш.
+func NewReportLink(
+	ownerID uuid.UUID,
+	visitID uuid.UUID,
+	periodStart time.Time,
+	periodEnd time.Time,
+	ttl time.Duration,
+	maxViews int,
+	pin *string,
+) (*ReportLink, string, error) {
+	if ttl <= 0 || ttl > MaxReportLinkTTL || maxViews <= 0 || maxViews > MaxReportLinkViews {
+		return nil, "", ErrInvalidReportLink
+	}
+	if periodEnd.Before(periodStart) {
+		return nil, "", ErrInvalidReportLink
+	}
+
+	now := time.Now()
+	link := &ReportLink{
+		ID:          uuid.New(),
+		OwnerID:     ownerID,
+		VisitID:     visitID,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		MaxViews:    maxViews,
+		ExpiresAt:   now.Add(ttl),
+		CreatedAt:   now,
+	}
+
+	if pin != nil {
+		if !isValidPIN(*pin) {
+			return nil, "", ErrInvalidReportLink
+		}
+		hash := link.hashPIN(*pin)
+		link.PINHash = &hash
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, "", err
+	}
+	link.TokenHash = HashToken(token)
+	return link, token, nil
+}
+
+// RequiresPIN проверяет, нужен ли PIN для открытия ссылки
+func (l *ReportLink) RequiresPIN() bool {
+	return l.PINHash != nil
+}
+
+// IsActive проверяет, что ссылка не отозвана, не истекла, не исчерпала
+// просмотры и не заблокирована неверными PIN
+func (l *ReportLink) IsActive(now time.Time) bool {
+	return l.RevokedAt == nil &&
+		now.Before(l.ExpiresAt) &&
+		l.Views < l.MaxViews &&
+		l.FailedPINAttempts < MaxPINAttempts
+}
+
+// CheckPIN сверяет PIN. Попытки учитывает репозиторий (ReservePINAttempt),
+// чтобы параллельные запросы не обходили блокировку.
+func (l *ReportLink) CheckPIN(pin string) bool {
+	if l.PINHash == nil {
+		return true
+	}
+	return subtle.ConstantTimeCompare([]byte(l.hashPIN(pin)), []byte(*l.PINHash)) == 1
+}
+
+// Revoke отзывает ссылку; повторный отзыв ничего не меняет
+func (l *ReportLink) Revoke() {
+	if l.RevokedAt != nil {
+		return
+	}
+	now := time.Now()
+	l.RevokedAt = &now
+}
+
+// hashPIN возвращает хэш PIN, привязанный к ссылке
+func (l *ReportLink) hashPIN(pin string) string {
+	sum := sha256.Sum256([]byte(l.ID.String() + ":" + pin))
+	return hex.EncodeToString(sum[:])
+}
+
+// isValidPIN проверяет, что PIN состоит из 4–8 цифр
+func isValidPIN(pin string) bool {
+	if len(pin) < minPINLength || len(pin) > maxPINLength {
+		return false
+	}
+	for _, r := range pin {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,67 @@
+package sharing
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// GrantRepository определяет интерфейс для работы с выданными доступами
+type GrantRepository interface {
+	// Create создаёт новый доступ
+	Create(ctx context.Context, grant *Grant) error
+
+	// GetByID возвращает доступ по ID
+	GetByID(ctx context.Context, id uuid.UUID) (*Grant, error)
+
+	// GetByTokenHash возвращает доступ по хэшу токена ссылки
+	GetByTokenHash(ctx context.Context, tokenHash string) (*Grant, error)
+
+	// FindByOwnerID возвращает все доступы к данным профиля, новые первыми
+	FindByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*Grant, error)
+
+	// FindByGrantee возвращает доступы, выданные пользователю Telegram, новые первыми
+	FindByGrantee(ctx context.Context, telegramUserID int64) ([]*Grant, error)
+
+	// Update обновляет доступ
+	Update(ctx context.Context, grant *Grant) error
+}
+
+// AccessLogRepository определяет интерфейс журнала обращений по доступам
+type AccessLogRepository interface {
+	// Create добавляет запись в журнал
+	Create(ctx context.Context, entry *AccessLogEntry) error
+
+	// FindByOwnerID возвращает последние обращения к данным профиля;
+	// grantID ограничивает журнал одним доступом
+	FindByOwnerID(ctx context.Context, ownerID uuid.UUID, grantID *uuid.UUID, limit int) ([]*AccessLogEntry, error)
+}
+
+// ReportLinkRepository определяет интерфейс для работы со ссылками на отчёт
+type ReportLinkRepository interface {
+	// Create создаёт новую ссылку
+	Create(ctx context.Context, link *ReportLink) error
+
+	// GetByID возвращает ссылку по ID
+	GetByID(ctx context.Context, id uuid.UUID) (*ReportLink, error)
+
+	// GetByTokenHash возвращает ссылку по хэшу токена
+	GetByTokenHash(ctx context.Context, tokenHash string) (*ReportLink, error)
+
+	// FindByVisitID возвращает ссылки на отчёт к визиту, новые первыми
+	FindByVisitID(ctx context.Context, visitID uuid.UUID) ([]*ReportLink, error)
+
+	// Revoke сохраняет отзыв ссылки, не затрагивая счётчики просмотров и попыток PIN
+	Revoke(ctx context.Context, link *ReportLink) error
+
+	// RegisterView атомарно учитывает просмотр, если ссылка ещё действует;
+	// false означает, что лимит просмотров исчерпан, ссылка отозвана или заблокирована
+	RegisterView(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// ReservePINAttempt атомарно учитывает попытку ввода PIN до его проверки;
+	// false означает, что ссылка уже заблокирована неверными PIN
+	ReservePINAttempt(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// ReleasePINAttempt снимает учёт попытки, если PIN оказался верным
+	ReleasePINAttempt(ctx context.Context, id uuid.UUID) error
+}
@@ -5,5 +5,6 @@ import "errors"
 var (
 	ErrInvalidWellbeingScale = errors.New("wellbeing scale must be between 1 and 10")
 	ErrSymptomNotFound       = errors.New("symptom entry not found")
+	ErrUnauthorized          = errors.New("unauthorized access to symptom entry")
 )
 
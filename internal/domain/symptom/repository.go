@@ -35,6 +35,17 @@ type Repository interface {
 	
 	// GetWellbeingTrend возвращает тренд самочувствия за период
 	GetWellbeingTrend(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]WellbeingDataPoint, error)
+	
+	// GetDailyWellbeing возвращает среднее самочувствие по дням за период;
+	// дни считаются в часовом поясе loc
+	GetDailyWellbeing(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time, loc *time.Location) ([]DailyWellbeing, error)
+	
+	// GetEntryTimes возвращает время всех записей пользователя начиная с since
+	GetEntryTimes(ctx context.Context, userID uuid.UUID, since time.Time) ([]time.Time, error)
+	
+	// GetSuggestions возвращает похожие описания из прошлых записей пользователя;
+	// непустые startDate и endDate ограничивают записи, из которых берутся подсказки
+	GetSuggestions(ctx context.Context, userID uuid.UUID, prefix string, startDate, endDate *time.Time, limit int) ([]*SymptomSuggestion, error)
 }
 
 // WellbeingDataPoint представляет точку данных для графика самочувствия
@@ -43,3 +54,17 @@ type WellbeingDataPoint struct {
 	Value int
 }
 
+
+// DailyWellbeing представляет среднее самочувствие за день
+type DailyWellbeing struct {
+	Date         time.Time // полночь дня в часовом поясе пользователя
+	Average      float64
+	EntriesCount int
+}
+
+// SymptomSuggestion представляет подсказку для автозаполнения описания симптома
+type SymptomSuggestion struct {
+	Description string
+	Count       int
+	LastUsedAt  time.Time
+}
@@ -0,0 +1,101 @@
+package user
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Удаление аккаунта проходит в два этапа: сначала аккаунт помечается удалённым
+// (DeletedAt) и его ещё можно восстановить, по истечении срока восстановления
+// фоновая задача удаляет все данные и файлы безвозвратно.
+
+// RequestDeletion помечает аккаунт к удалению. Удалить можно только аккаунт
+// владельца целиком, вместе с подопечными профилями.
+func (u *User) RequestDeletion() error {
+	if u.IsDependent() {
+		return ErrNotAccountHolder
+	}
+	if u.IsDeleted() {
+		return ErrAccountPendingDeletion
+	}
+	u.Delete()
+	return nil
+}
+
+// PurgeAt возвращает момент, после которого данные аккаунта удаляются безвозвратно
+func (u *User) PurgeAt(grace time.Duration) time.Time {
+	if u.DeletedAt == nil {
+		return time.Time{}
+	}
+	return u.DeletedAt.Add(grace)
+}
+
+// Restore отменяет удаление аккаунта, пока не истёк срок восстановления
+func (u *User) Restore(now time.Time, grace time.Duration) error {
+	if !u.IsDeleted() {
+		return ErrAccountNotDeleted
+	}
+	if !now.Before(u.PurgeAt(grace)) {
+		return ErrRestorePeriodExpired
+	}
+	u.DeletedAt = nil
+	u.UpdatedAt = now
+	return nil
+}
+
+// DeletionReceipt подтверждает безвозвратное удаление данных аккаунта.
+// Подпись позволяет проверить, что квитанция выдана сервисом и не изменена.
+type DeletionReceipt struct {
+	ID             uuid.UUID
+	AccountID      uuid.UUID
+	TelegramUserID int64
+	RequestedAt    time.Time
+	PurgedAt       time.Time
+	Profiles       int // число удалённых профилей, включая подопечных
+	Files          int // число удалённых файлов
+}
+
+// NewDeletionReceipt создаёт квитанцию об удалении аккаунта
+func NewDeletionReceipt(account *User, profiles, files int) *DeletionReceipt {
+	receipt := &DeletionReceipt{
+		ID:             uuid.New(),
+		AccountID:      account.ID,
+		TelegramUserID: account.TelegramUserID,
+		PurgedAt:       time.Now().UTC(),
+		Profiles:       profiles,
+		Files:          files,
+	}
+	if account.DeletedAt != nil {
+		receipt.RequestedAt = account.DeletedAt.UTC()
+	}
+	return receipt
+}
+
+// Payload возвращает каноническое представление квитанции, которое подписывается
+func (r *DeletionReceipt) Payload() string {
+	return fmt.Sprintf("%s|%s|%d|%s|%s|%d|%d",
+		r.ID,
+		r.AccountID,
+		r.TelegramUserID,
+		r.RequestedAt.Format(time.RFC3339),
+		r.PurgedAt.Format(time.RFC3339),
+		r.Profiles,
+		r.Files,
+	)
+}
+
+// Sign возвращает HMAC-SHA256 подпись квитанции. Ключ производится из секрета,
+// чтобы секрет (например, токен бота) не использовался напрямую.
+func (r *DeletionReceipt) Sign(secret string) string {
+	keyMAC := hmac.New(sha256.New, []byte("DeletionReceipt"))
+	keyMAC.Write([]byte(secret))
+
+	mac := hmac.New(sha256.New, keyMAC.Sum(nil))
+	mac.Write([]byte(r.Payload()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
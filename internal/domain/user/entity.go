@@ -13,9 +13,17 @@ type User struct {
 	Name           string
 	Age            *int
 	Gender         *Gender
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	DeletedAt      *time.Time
+	Timezone       string
+	// NotificationPreferences хранит настройки напоминаний вместе с пользователем
+	NotificationPreferences NotificationPreferences
+	// Onboarding — прохождение онбординга; у подопечного профиля не используется
+	Onboarding Onboarding
+	// OwnerID задан у подопечного профиля (ребёнок, пожилой родственник) и указывает
+	// на владельца аккаунта Telegram; у подопечного нет своего TelegramUserID
+	OwnerID   *uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time
 }
 
 // Gender представляет пол пользователя
@@ -27,15 +35,20 @@ const (
 	GenderOther  Gender = "other"
 )
 
+// DefaultTimezone — часовой пояс пользователя по умолчанию
+const DefaultTimezone = "UTC"
+
 // NewUser создаёт нового пользователя
 func NewUser(telegramUserID int64, name string) *User {
 	now := time.Now()
 	return &User{
-		ID:             uuid.New(),
-		TelegramUserID: telegramUserID,
-		Name:           name,
-		CreatedAt:      now,
-		UpdatedAt:      now,
+		ID:                      uuid.New(),
+		TelegramUserID:          telegramUserID,
+		Name:                    name,
+		Timezone:                DefaultTimezone,
+		NotificationPreferences: DefaultNotificationPreferences(),
+		CreatedAt:               now,
+		UpdatedAt:               now,
 	}
 }
 
@@ -53,6 +66,25 @@ func (u *User) UpdateProfile(name string, age *int, gender *Gender) {
 	u.UpdatedAt = time.Now()
 }
 
+// SetTimezone устанавливает часовой пояс пользователя (IANA, например "Europe/Moscow")
+func (u *User) SetTimezone(timezone string) error {
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return ErrInvalidTimezone
+	}
+	u.Timezone = timezone
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// Location возвращает часовой пояс пользователя
+func (u *User) Location() *time.Location {
+	loc, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
 // IsDeleted проверяет, удалён ли пользователь
 func (u *User) IsDeleted() bool {
 	return u.DeletedAt != nil
@@ -0,0 +1,28 @@
+package user
+
+import "errors"
+
+var (
+	ErrUserNotFound    = errors.New("user not found")
+	ErrInvalidTimezone = errors.New("invalid timezone")
+
+	ErrProfileNotFound    = errors.New("profile not found")
+	ErrNotAccountHolder   = errors.New("only the account holder can manage dependent profiles")
+	ErrInvalidProfileName = errors.New("profile name is required")
+
+	ErrInvalidTimeOfDay           = errors.New("time of day must be in HH:MM format")
+	ErrInvalidWeekday             = errors.New("weekday must be between 1 and 7")
+	ErrInvalidQuietHours          = errors.New("quiet hours require both start and end")
+	ErrInvalidNotificationChannel = errors.New("unknown notification channel")
+
+	ErrAccountPendingDeletion = errors.New("account is scheduled for deletion")
+	ErrAccountNotDeleted      = errors.New("account is not scheduled for deletion")
+	ErrRestorePeriodExpired   = errors.New("account restore period has expired")
+
+	ErrUnknownOnboardingStep      = errors.New("unknown onboarding step")
+	ErrOnboardingStepOutOfOrder   = errors.New("onboarding step is not the current step")
+	ErrOnboardingStepNotSkippable = errors.New("onboarding step cannot be skipped")
+	ErrOnboardingStepIncomplete   = errors.New("onboarding step requirements are not met")
+	ErrOnboardingCompleted        = errors.New("onboarding is already completed")
+	ErrOnboardingNotApplicable    = errors.New("onboarding is only available to the account holder")
+)
@@ -0,0 +1,145 @@
+package user
+
+import (
+	"fmt"
+	"time"
+)
+
+// NotificationChannel представляет канал доставки уведомлений
+type NotificationChannel string
+
+const (
+	// NotificationChannelTelegram — сообщение от бота
+	NotificationChannelTelegram NotificationChannel = "telegram"
+	// NotificationChannelWebApp — только внутри WebApp (блок «Ближайшие напоминания»)
+	NotificationChannelWebApp NotificationChannel = "web_app"
+)
+
+// NotificationPreferences представляет настройки уведомлений пользователя
+type NotificationPreferences struct {
+	CheckInTimes    []string              // ["09:00"] — время вопроса «Как ты себя чувствуешь?»
+	Channels        []NotificationChannel // включённые каналы доставки
+	QuietHoursStart *string               // "22:00"
+	QuietHoursEnd   *string               // "08:00"
+	Days            []int                 // [1..7] дни недели для check-in (1=Monday)
+}
+
+// DefaultNotificationPreferences возвращает настройки по умолчанию:
+// check-in выключен, уведомления приходят в Telegram каждый день
+func DefaultNotificationPreferences() NotificationPreferences {
+	return NotificationPreferences{
+		CheckInTimes: []string{},
+		Channels:     []NotificationChannel{NotificationChannelTelegram},
+		Days:         []int{1, 2, 3, 4, 5, 6, 7},
+	}
+}
+
+// Validate проверяет корректность настроек
+func (p NotificationPreferences) Validate() error {
+	for _, t := range p.CheckInTimes {
+		if _, err := ParseClock(t); err != nil {
+			return err
+		}
+	}
+	for _, d := range p.Days {
+		if d < 1 || d > 7 {
+			return ErrInvalidWeekday
+		}
+	}
+	for _, c := range p.Channels {
+		if c != NotificationChannelTelegram && c != NotificationChannelWebApp {
+			return ErrInvalidNotificationChannel
+		}
+	}
+	if (p.QuietHoursStart == nil) != (p.QuietHoursEnd == nil) {
+		return ErrInvalidQuietHours
+	}
+	if p.QuietHoursStart != nil {
+		if _, err := ParseClock(*p.QuietHoursStart); err != nil {
+			return err
+		}
+		if _, err := ParseClock(*p.QuietHoursEnd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasChannel проверяет, включён ли канал доставки
+func (p NotificationPreferences) HasChannel(channel NotificationChannel) bool {
+	for _, c := range p.Channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActiveDay проверяет, включён ли день недели для check-in
+func (p NotificationPreferences) IsActiveDay(t time.Time) bool {
+	weekday := isoWeekday(t)
+	for _, d := range p.Days {
+		if d == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// InQuietHours проверяет, попадает ли момент t (в часовом поясе loc) в тихие часы
+func (p NotificationPreferences) InQuietHours(t time.Time, loc *time.Location) bool {
+	if p.QuietHoursStart == nil || p.QuietHoursEnd == nil {
+		return false
+	}
+	start, _ := ParseClock(*p.QuietHoursStart)
+	end, _ := ParseClock(*p.QuietHoursEnd)
+	local := t.In(loc)
+	current := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute
+
+	if start <= end {
+		return current >= start && current < end
+	}
+	// Тихие часы через полночь, например 22:00–08:00
+	return current >= start || current < end
+}
+
+// NextAllowedTime возвращает ближайший момент не раньше t вне тихих часов
+func (p NotificationPreferences) NextAllowedTime(t time.Time, loc *time.Location) time.Time {
+	if !p.InQuietHours(t, loc) {
+		return t
+	}
+	end, _ := ParseClock(*p.QuietHoursEnd)
+	local := t.In(loc)
+	next := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc).Add(end)
+	if !next.After(local) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// UpdateNotificationPreferences обновляет настройки уведомлений
+func (u *User) UpdateNotificationPreferences(prefs NotificationPreferences) error {
+	if err := prefs.Validate(); err != nil {
+		return err
+	}
+	u.NotificationPreferences = prefs
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// ParseClock разбирает время суток в формате "HH:MM" и возвращает смещение от полуночи
+func ParseClock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidTimeOfDay, value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// isoWeekday возвращает день недели в формате 1=Monday … 7=Sunday
+func isoWeekday(t time.Time) int {
+	if t.Weekday() == time.Sunday {
+		return 7
+	}
+	return int(t.Weekday())
+}
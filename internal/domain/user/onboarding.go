@@ -0,0 +1,105 @@
+package user
+
+import (
+	"slices"
+	"time"
+)
+
+// OnboardingStep представляет шаг онбординга
+type OnboardingStep string
+
+const (
+	// OnboardingStepConsent — согласие на обработку данных
+	OnboardingStepConsent OnboardingStep = "consent"
+	// OnboardingStepProfile — имя, возраст, пол
+	OnboardingStepProfile OnboardingStep = "profile"
+	// OnboardingStepFirstAction — первая запись: симптом, анализ или лекарство
+	OnboardingStepFirstAction OnboardingStep = "first_action"
+)
+
+// onboardingSteps — шаги онбординга в порядке прохождения
+var onboardingSteps = []OnboardingStep{
+	OnboardingStepConsent,
+	OnboardingStepProfile,
+	OnboardingStepFirstAction,
+}
+
+// IsValid проверяет, что шаг известен
+func (s OnboardingStep) IsValid() bool {
+	return slices.Contains(onboardingSteps, s)
+}
+
+// IsSkippable проверяет, можно ли пропустить шаг; согласие пропустить нельзя
+func (s OnboardingStep) IsSkippable() bool {
+	return s != OnboardingStepConsent
+}
+
+// Onboarding представляет прохождение онбординга владельцем аккаунта.
+// Шаги проходятся строго по порядку: каждый шаг либо выполняется, либо пропускается.
+type Onboarding struct {
+	CompletedSteps []OnboardingStep
+	SkippedSteps   []OnboardingStep
+	CompletedAt    *time.Time // когда пройден последний шаг
+}
+
+// CurrentStep возвращает шаг, который нужно показать, или nil, если онбординг пройден
+func (o *Onboarding) CurrentStep() *OnboardingStep {
+	for _, step := range onboardingSteps {
+		if !slices.Contains(o.CompletedSteps, step) && !slices.Contains(o.SkippedSteps, step) {
+			return &step
+		}
+	}
+	return nil
+}
+
+// IsCompleted проверяет, что все шаги пройдены или пропущены
+func (o *Onboarding) IsCompleted() bool {
+	return o.CurrentStep() == nil
+}
+
+// Complete отмечает текущий шаг выполненным. Выполнять шаг можно только текущий:
+// условия шага (например, наличие согласия) проверяются до вызова.
+func (o *Onboarding) Complete(step OnboardingStep) error {
+	if err := o.checkCurrent(step); err != nil {
+		return err
+	}
+	o.CompletedSteps = append(o.CompletedSteps, step)
+	o.finish()
+	return nil
+}
+
+// Skip пропускает текущий шаг, если его можно пропустить
+func (o *Onboarding) Skip(step OnboardingStep) error {
+	if err := o.checkCurrent(step); err != nil {
+		return err
+	}
+	if !step.IsSkippable() {
+		return ErrOnboardingStepNotSkippable
+	}
+	o.SkippedSteps = append(o.SkippedSteps, step)
+	o.finish()
+	return nil
+}
+
+// checkCurrent проверяет, что шаг известен и является текущим
+func (o *Onboarding) checkCurrent(step OnboardingStep) error {
+	if !step.IsValid() {
+		return ErrUnknownOnboardingStep
+	}
+	current := o.CurrentStep()
+	if current == nil {
+		return ErrOnboardingCompleted
+	}
+	if *current != step {
+		return ErrOnboardingStepOutOfOrder
+	}
+	return nil
+}
+
+// finish отмечает время завершения, когда пройден последний шаг
+func (o *Onboarding) finish() {
+	if o.IsCompleted() {
+		now := time.Now()
+		o.CompletedAt = &now
+	}
+}
@@ -0,0 +1,63 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NewDependent создаёт подопечный профиль, которым управляет владелец аккаунта.
+// Часовой пояс наследуется от владельца и может быть изменён отдельно.
+func NewDependent(owner *User, name string, age *int, gender *Gender) (*User, error) {
+	if owner.IsDependent() {
+		return nil, ErrNotAccountHolder
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, ErrInvalidProfileName
+	}
+	now := time.Now()
+	ownerID := owner.ID
+	return &User{
+		ID:                      uuid.New(),
+		Name:                    strings.TrimSpace(name),
+		Age:                     age,
+		Gender:                  gender,
+		Timezone:                owner.Timezone,
+		NotificationPreferences: DefaultNotificationPreferences(),
+		OwnerID:                 &ownerID,
+		CreatedAt:               now,
+		UpdatedAt:               now,
+	}, nil
+}
+
+// IsDependent проверяет, является ли профиль подопечным
+func (u *User) IsDependent() bool {
+	return u.OwnerID != nil
+}
+
+// IsManagedBy проверяет, может ли владелец аккаунта accountID работать с профилем
+func (u *User) IsManagedBy(accountID uuid.UUID) bool {
+	return u.ID == accountID || (u.OwnerID != nil && *u.OwnerID == accountID)
+}
+
+// LabelMessage помечает уведомление именем подопечного,
+// чтобы владелец аккаунта понимал, к кому оно относится
+func (u *User) LabelMessage(text string) string {
+	if !u.IsDependent() {
+		return text
+	}
+	return fmt.Sprintf("👤 %s\n%s", u.Name, text)
+}
+
+// Recipient возвращает пользователя, которому доставляются уведомления профиля:
+// для подопечного — владельца аккаунта, для остальных — сам профиль.
+// Возвращает nil, если получателя больше нет.
+func Recipient(ctx context.Context, repo Repository, profile *User) (*User, error) {
+	if !profile.IsDependent() {
+		return profile, nil
+	}
+	return repo.GetByID(ctx, *profile.OwnerID)
+}
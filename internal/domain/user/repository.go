@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -17,9 +18,42 @@ type Repository interface {
 	// GetByTelegramUserID возвращает пользователя по Telegram User ID
 	GetByTelegramUserID(ctx context.Context, telegramUserID int64) (*User, error)
 
-	// Update обновляет пользователя
-	Update(ctx context.Context, user *User) error
+	// FindDependents возвращает подопечные профили владельца аккаунта
+	FindDependents(ctx context.Context, ownerID uuid.UUID) ([]*User, error)
+
+	// UpdateProfile сохраняет имя, возраст и пол пользователя
+	UpdateProfile(ctx context.Context, user *User) error
+
+	// UpdateTimezone сохраняет часовой пояс пользователя
+	UpdateTimezone(ctx context.Context, user *User) error
+
+	// UpdateNotificationPreferences сохраняет настройки уведомлений пользователя
+	UpdateNotificationPreferences(ctx context.Context, user *User) error
+
+	// UpdateOnboarding сохраняет прогресс онбординга пользователя
+	UpdateOnboarding(ctx context.Context, user *User) error
+
+	// FindWithCheckIns возвращает пользователей с настроенными check-in напоминаниями
+	FindWithCheckIns(ctx context.Context) ([]*User, error)
 
 	// Delete удаляет пользователя (soft delete)
 	Delete(ctx context.Context, id uuid.UUID) error
+
+	// GetDeletedByID возвращает удалённого, но ещё не стёртого пользователя по ID
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*User, error)
+
+	// GetDeletedByTelegramUserID возвращает удалённого, но ещё не стёртого пользователя по Telegram User ID
+	GetDeletedByTelegramUserID(ctx context.Context, telegramUserID int64) (*User, error)
+
+	// FindDeletedBefore возвращает пользователей, удалённых раньше before
+	FindDeletedBefore(ctx context.Context, before time.Time) ([]*User, error)
+
+	// FindDependentIDs возвращает ID всех подопечных профилей владельца, включая удалённые
+	FindDependentIDs(ctx context.Context, ownerID uuid.UUID) ([]uuid.UUID, error)
+
+	// Restore снимает пометку об удалении
+	Restore(ctx context.Context, id uuid.UUID) error
+
+	// Purge безвозвратно удаляет пользователя; связанные данные удаляются каскадно
+	Purge(ctx context.Context, id uuid.UUID) error
 }
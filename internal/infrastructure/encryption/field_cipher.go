@@ -0,0 +1,263 @@
+package encryption
+
+import (
+	"context"
+	"crypto/cipher"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/encryption"
+)
+
+// fieldPrefix отмечает зашифрованное значение колонки:
+// "enc:v1:<ID ключа данных>:<base64(nonce || шифротекст)>"
+const fieldPrefix = "enc:v1:"
+
+// FieldCipher прозрачно шифрует чувствительные колонки ключом данных профиля.
+// Ключи данных создаются при первой записи и хранятся зашифрованными
+// мастер-ключом; расшифрованные ключи кэшируются в памяти процесса.
+//
+// Значения без префикса считаются открытыми (записанными до включения
+// шифрования) и возвращаются как есть, пока их не перешифрует команда reencrypt.
+// Методы nil-шифратора пропускают значения без изменений: так шифрование
+// выключается, если мастер-ключи не заданы.
+type FieldCipher struct {
+	keys *Keyring
+	repo encryption.DataKeyRepository
+
+	mu    sync.RWMutex
+	cache map[uuid.UUID]cipher.AEAD
+}
+
+// NewFieldCipher создаёт шифратор колонок; без мастер-ключей возвращает nil
+func NewFieldCipher(keys *Keyring, repo encryption.DataKeyRepository) *FieldCipher {
+	if keys == nil {
+		return nil
+	}
+	return &FieldCipher{
+		keys:  keys,
+		repo:  repo,
+		cache: make(map[uuid.UUID]cipher.AEAD),
+	}
+}
+
+// Enabled проверяет, что новые значения шифруются
+func (c *FieldCipher) Enabled() bool {
+	return c != nil
+}
+
+// IsEncrypted проверяет, что значение колонки зашифровано
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, fieldPrefix)
+}
+
+// KeyID возвращает ID ключа данных, которым зашифровано значение
+func KeyID(value string) (uuid.UUID, bool) {
+	if !IsEncrypted(value) {
+		return uuid.Nil, false
+	}
+	id, _, ok := strings.Cut(strings.TrimPrefix(value, fieldPrefix), ":")
+	if !ok {
+		return uuid.Nil, false
+	}
+	keyID, err := uuid.Parse(id)
+	return keyID, err == nil
+}
+
+// KeyPrefix возвращает начало значений, зашифрованных ключом keyID
+func KeyPrefix(keyID uuid.UUID) string {
+	return fieldPrefix + keyID.String() + ":"
+}
+
+// Encrypt шифрует значение колонки field действующим ключом профиля userID.
+// Имя колонки входит в аутентифицированные данные, поэтому шифротекст
+// нельзя перенести в другую колонку.
+func (c *FieldCipher) Encrypt(ctx context.Context, userID uuid.UUID, field, value string) (string, error) {
+	if c == nil {
+		return value, nil
+	}
+
+	key, err := c.activeKey(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	aead, err := c.aead(key)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := seal(aead, []byte(value), fieldAAD(field, key.ID))
+	if err != nil {
+		return "", err
+	}
+	return KeyPrefix(key.ID) + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt расшифровывает значение колонки field; открытые значения возвращаются как есть
+func (c *FieldCipher) Decrypt(ctx context.Context, field, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	if c == nil {
+		return "", encryption.ErrEncryptionDisabled
+	}
+
+	keyID, ok := KeyID(value)
+	if !ok {
+		return "", encryption.ErrMalformedCiphertext
+	}
+	_, encoded, _ := strings.Cut(strings.TrimPrefix(value, fieldPrefix), ":")
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", encryption.ErrMalformedCiphertext
+	}
+
+	aead, err := c.aeadByID(ctx, keyID)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(aead, sealed, fieldAAD(field, keyID))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// ActiveKeyID возвращает ID действующего ключа профиля, создавая ключ при необходимости
+func (c *FieldCipher) ActiveKeyID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	key, err := c.activeKey(ctx, userID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return key.ID, nil
+}
+
+// RotateDataKeys выводит из оборота действующие ключи данных всех профилей.
+// Новые ключи создаются при следующей записи; старые остаются для
+// расшифровки, пока значения не перешифрованы.
+func (c *FieldCipher) RotateDataKeys(ctx context.Context) (int, error) {
+	keys, err := c.repo.FindActive(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	for _, key := range keys {
+		key.Retire(now)
+		if err := c.repo.Update(ctx, key); err != nil {
+			return 0, err
+		}
+	}
+	return len(keys), nil
+}
+
+// RewrapDataKeys перешифровывает действующим мастер-ключом ключи данных,
+// зашифрованные другими мастер-ключами. Сами данные при этом не меняются.
+func (c *FieldCipher) RewrapDataKeys(ctx context.Context) (int, error) {
+	keys, err := c.repo.FindNotWrappedWith(ctx, c.keys.ActiveID())
+	if err != nil {
+		return 0, err
+	}
+
+	for _, key := range keys {
+		plain, err := c.keys.Unwrap(key.MasterKeyID, key.WrappedKey, dataKeyAAD(key))
+		if err != nil {
+			return 0, err
+		}
+		masterKeyID, wrapped, err := c.keys.Wrap(plain, dataKeyAAD(key))
+		if err != nil {
+			return 0, err
+		}
+		key.Rewrap(masterKeyID, wrapped)
+		if err := c.repo.Update(ctx, key); err != nil {
+			return 0, err
+		}
+	}
+	return len(keys), nil
+}
+
+// activeKey возвращает действующий ключ профиля или создаёт новый
+func (c *FieldCipher) activeKey(ctx context.Context, userID uuid.UUID) (*encryption.DataKey, error) {
+	key, err := c.repo.GetActive(ctx, userID)
+	if err != nil || key != nil {
+		return key, err
+	}
+
+	plain, err := newKey()
+	if err != nil {
+		return nil, err
+	}
+	key = &encryption.DataKey{
+		ID:        uuid.New(),
+		UserID:    userID,
+		CreatedAt: time.Now(),
+	}
+	masterKeyID, wrapped, err := c.keys.Wrap(plain, dataKeyAAD(key))
+	if err != nil {
+		return nil, err
+	}
+	key.Rewrap(masterKeyID, wrapped)
+
+	if err := c.repo.Create(ctx, key); err != nil {
+		// Ключ одновременно создал другой запрос — используется он
+		if errors.Is(err, encryption.ErrDataKeyAlreadyActive) {
+			return c.repo.GetActive(ctx, userID)
+		}
+		return nil, err
+	}
+	return key, nil
+}
+
+// aeadByID возвращает шифр ключа данных по его ID
+func (c *FieldCipher) aeadByID(ctx context.Context, keyID uuid.UUID) (cipher.AEAD, error) {
+	c.mu.RLock()
+	aead, ok := c.cache[keyID]
+	c.mu.RUnlock()
+	if ok {
+		return aead, nil
+	}
+
+	key, err := c.repo.GetByID(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	return c.aead(key)
+}
+
+// aead расшифровывает ключ данных мастер-ключом и кэширует его шифр
+func (c *FieldCipher) aead(key *encryption.DataKey) (cipher.AEAD, error) {
+	c.mu.RLock()
+	aead, ok := c.cache[key.ID]
+	c.mu.RUnlock()
+	if ok {
+		return aead, nil
+	}
+
+	plain, err := c.keys.Unwrap(key.MasterKeyID, key.WrappedKey, dataKeyAAD(key))
+	if err != nil {
+		return nil, err
+	}
+	aead, err = newAEAD(plain)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.cache[key.ID] = aead
+	c.mu.Unlock()
+	return aead, nil
+}
+
+// dataKeyAAD привязывает зашифрованный ключ данных к его записи и профилю
+func dataKeyAAD(key *encryption.DataKey) []byte {
+	return []byte("DataKey:" + key.ID.String() + ":" + key.UserID.String())
+}
+
+// fieldAAD привязывает шифротекст к колонке и ключу данных
+func fieldAAD(field string, keyID uuid.UUID) []byte {
+	return []byte(field + ":" + keyID.String())
+}
@@ -0,0 +1,131 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"sort"
+
+	"github.com/health-hub-bot-api/internal/domain/encryption"
+)
+
+// masterKeySize — длина мастер-ключа AES-256 в байтах
+const masterKeySize = 32
+
+// Keyring хранит мастер-ключи из конфигурации. Новые ключи данных шифруются
+// действующим мастер-ключом; остальные нужны, чтобы расшифровать ключи,
+// ещё не перешифрованные после ротации.
+type Keyring struct {
+	activeID string
+	keys     map[string]cipher.AEAD
+}
+
+// NewKeyring создаёт набор мастер-ключей из пар «ID → ключ в base64».
+// Без ключей шифрование выключено и возвращается nil. Если activeID не задан,
+// действующим считается единственный ключ.
+func NewKeyring(keys map[string]string, activeID string) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	if activeID == "" {
+		if len(keys) > 1 {
+			ids := make([]string, 0, len(keys))
+			for id := range keys {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+			return nil, fmt.Errorf("encryption: active master key must be set, configured keys: %v", ids)
+		}
+		for id := range keys {
+			activeID = id
+		}
+	}
+	if _, ok := keys[activeID]; !ok {
+		return nil, fmt.Errorf("encryption: active master key %q is not configured", activeID)
+	}
+
+	ring := &Keyring{activeID: activeID, keys: make(map[string]cipher.AEAD, len(keys))}
+	for id, encoded := range keys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("encryption: master key %q is not valid base64: %w", id, err)
+		}
+		if len(key) != masterKeySize {
+			return nil, fmt.Errorf("encryption: master key %q must be %d bytes, got %d", id, masterKeySize, len(key))
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		ring.keys[id] = aead
+	}
+
+	return ring, nil
+}
+
+// ActiveID возвращает ID действующего мастер-ключа
+func (r *Keyring) ActiveID() string {
+	return r.activeID
+}
+
+// Wrap шифрует ключ действующим мастер-ключом. aad привязывает результат
+// к владельцу, чтобы зашифрованный ключ нельзя было подставить в другую запись.
+func (r *Keyring) Wrap(key, aad []byte) (string, []byte, error) {
+	sealed, err := seal(r.keys[r.activeID], key, aad)
+	if err != nil {
+		return "", nil, err
+	}
+	return r.activeID, sealed, nil
+}
+
+// Unwrap расшифровывает ключ мастер-ключом masterKeyID
+func (r *Keyring) Unwrap(masterKeyID string, wrapped, aad []byte) ([]byte, error) {
+	aead, ok := r.keys[masterKeyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", encryption.ErrUnknownMasterKey, masterKeyID)
+	}
+	return open(aead, wrapped, aad)
+}
+
+// newKey генерирует случайный ключ AES-256
+func newKey() ([]byte, error) {
+	key := make([]byte, masterKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// newAEAD создаёт AES-GCM для ключа
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal шифрует данные со случайным nonce, который записывается перед шифротекстом
+func seal(aead cipher.AEAD, plaintext, aad []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// open расшифровывает результат seal
+func open(aead cipher.AEAD, sealed, aad []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize()+aead.Overhead() {
+		return nil, encryption.ErrMalformedCiphertext
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, aad)
+	if err != nil {
+		return nil, encryption.ErrMalformedCiphertext
+	}
+	return plaintext, nil
+}
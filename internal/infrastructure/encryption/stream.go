@@ -0,0 +1,222 @@
+package encryption
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/health-hub-bot-api/internal/domain/encryption"
+)
+
+const (
+	// streamMagic начинает зашифрованный файл
+	streamMagic = "HHENC\x01"
+	// streamSegmentSize — размер открытого сегмента файла; сегменты шифруются
+	// по отдельности, чтобы большие файлы не читались в память целиком
+	streamSegmentSize = 64 * 1024
+	// streamPrefixSize — длина случайной части nonce сегментов
+	streamPrefixSize = 7
+)
+
+// Зашифрованный файл устроен так:
+//
+//	magic | len(ID мастер-ключа) u8 | ID мастер-ключа | len(ключ) u16 | ключ файла,
+//	зашифрованный мастер-ключом | префикс nonce | сегменты AES-GCM
+//
+// Nonce сегмента — префикс, номер сегмента и признак последнего сегмента,
+// поэтому сегменты нельзя переставить, а файл — обрезать незаметно.
+
+// EncryptStream возвращает поток, который шифрует src новым ключом файла,
+// зашифрованным действующим мастер-ключом
+func (r *Keyring) EncryptStream(src io.Reader) (io.Reader, error) {
+	key, err := newKey()
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var prefix [streamPrefixSize]byte
+	if _, err := rand.Read(prefix[:]); err != nil {
+		return nil, err
+	}
+	masterKeyID, wrapped, err := r.Wrap(key, fileKeyAAD(prefix))
+	if err != nil {
+		return nil, err
+	}
+
+	header := []byte(streamMagic)
+	header = append(header, byte(len(masterKeyID)))
+	header = append(header, masterKeyID...)
+	header = binary.BigEndian.AppendUint16(header, uint16(len(wrapped)))
+	header = append(header, wrapped...)
+	header = append(header, prefix[:]...)
+
+	sealSegment := func(dst, nonce, plaintext, aad []byte) ([]byte, error) {
+		return aead.Seal(dst, nonce, plaintext, aad), nil
+	}
+	return &segmentReader{
+		src:     bufio.NewReader(src),
+		aead:    aead,
+		prefix:  prefix,
+		size:    streamSegmentSize,
+		process: sealSegment,
+		pending: header,
+	}, nil
+}
+
+// DecryptStream возвращает поток с расшифрованным содержимым src и ID мастер-ключа,
+// которым зашифрован ключ файла. Открытые файлы (сохранённые до включения
+// шифрования) возвращаются как есть с пустым ID. Методы nil-набора ключей
+// пропускают только открытые файлы.
+func (r *Keyring) DecryptStream(src io.Reader) (io.Reader, string, error) {
+	buffered := bufio.NewReader(src)
+	magic, err := buffered.Peek(len(streamMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, "", err
+	}
+	if string(magic) != streamMagic {
+		return buffered, "", nil
+	}
+	if r == nil {
+		return nil, "", encryption.ErrEncryptionDisabled
+	}
+
+	if _, err := buffered.Discard(len(streamMagic)); err != nil {
+		return nil, "", err
+	}
+	idLen, err := buffered.ReadByte()
+	if err != nil {
+		return nil, "", malformed(err)
+	}
+	masterKeyID := make([]byte, idLen)
+	if _, err := io.ReadFull(buffered, masterKeyID); err != nil {
+		return nil, "", malformed(err)
+	}
+	var wrappedLen uint16
+	if err := binary.Read(buffered, binary.BigEndian, &wrappedLen); err != nil {
+		return nil, "", malformed(err)
+	}
+	wrapped := make([]byte, wrappedLen)
+	if _, err := io.ReadFull(buffered, wrapped); err != nil {
+		return nil, "", malformed(err)
+	}
+	var prefix [streamPrefixSize]byte
+	if _, err := io.ReadFull(buffered, prefix[:]); err != nil {
+		return nil, "", malformed(err)
+	}
+
+	key, err := r.Unwrap(string(masterKeyID), wrapped, fileKeyAAD(prefix))
+	if err != nil {
+		return nil, "", err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	openSegment := func(dst, nonce, ciphertext, aad []byte) ([]byte, error) {
+		plaintext, err := aead.Open(dst, nonce, ciphertext, aad)
+		if err != nil {
+			return nil, encryption.ErrMalformedCiphertext
+		}
+		return plaintext, nil
+	}
+	return &segmentReader{
+		src:     buffered,
+		aead:    aead,
+		prefix:  prefix,
+		size:    streamSegmentSize + aead.Overhead(),
+		process: openSegment,
+	}, string(masterKeyID), nil
+}
+
+// segmentReader шифрует или расшифровывает поток по сегментам
+type segmentReader struct {
+	src     *bufio.Reader
+	aead    cipher.AEAD
+	prefix  [streamPrefixSize]byte
+	size    int
+	process func(dst, nonce, data, aad []byte) ([]byte, error)
+
+	counter uint32
+	segment []byte
+	pending []byte
+	done    bool
+}
+
+// Read отдаёт обработанные сегменты
+func (s *segmentReader) Read(p []byte) (int, error) {
+	for len(s.pending) == 0 {
+		if s.done {
+			return 0, io.EOF
+		}
+		if err := s.next(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, s.pending)
+	s.pending = s.pending[n:]
+	return n, nil
+}
+
+// next читает и обрабатывает следующий сегмент
+func (s *segmentReader) next() error {
+	if s.segment == nil {
+		s.segment = make([]byte, s.size)
+	}
+
+	n, err := io.ReadFull(s.src, s.segment)
+	last := false
+	switch {
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		last = true
+	case err != nil:
+		return err
+	default:
+		if _, err := s.src.Peek(1); errors.Is(err, io.EOF) {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	out, err := s.process(s.pending[:0], s.nonce(last), s.segment[:n], nil)
+	if err != nil {
+		return err
+	}
+	s.pending = out
+	s.counter++
+	s.done = last
+	return nil
+}
+
+// nonce собирает nonce сегмента из префикса, номера и признака последнего сегмента
+func (s *segmentReader) nonce(last bool) []byte {
+	nonce := make([]byte, 0, s.aead.NonceSize())
+	nonce = append(nonce, s.prefix[:]...)
+	nonce = binary.BigEndian.AppendUint32(nonce, s.counter)
+	if last {
+		return append(nonce, 1)
+	}
+	return append(nonce, 0)
+}
+
+// fileKeyAAD привязывает зашифрованный ключ файла к префиксу nonce файла
+func fileKeyAAD(prefix [streamPrefixSize]byte) []byte {
+	return append([]byte("FileKey:"), prefix[:]...)
+}
+
+// malformed сообщает об обрезанном заголовке файла
+func malformed(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return encryption.ErrMalformedCiphertext
+	}
+	return err
+}
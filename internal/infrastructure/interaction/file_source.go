@@ -0,0 +1,103 @@
+package interaction
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/health-hub-bot-api/internal/domain/interaction"
+)
+
+// datasetFile представляет формат файла с данными о лекарствах
+type datasetFile struct {
+	Products []struct {
+		Name        string   `json:"name"`
+		Ingredients []string `json:"ingredients"`
+	} `json:"products"`
+	Interactions []struct {
+		Ingredients [2]string `json:"ingredients"`
+		Description string    `json:"description"`
+		Source      string    `json:"source"`
+	} `json:"interactions"`
+}
+
+// FileSource представляет источник данных о взаимодействиях, загруженный из локального JSON-файла
+type FileSource struct {
+	products     map[string][]string
+	interactions map[[2]string]*interaction.Interaction
+}
+
+// LoadFileSource загружает набор данных из файла. Если файла нет,
+// возвращает пустой источник: проверка просто не находит совпадений.
+func LoadFileSource(path string) (*FileSource, error) {
+	source := &FileSource{
+		products:     make(map[string][]string),
+		interactions: make(map[[2]string]*interaction.Interaction),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		log.Printf("interactions dataset %s not found, interaction checks are disabled", path)
+		return source, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read interactions dataset: %w", err)
+	}
+
+	var file datasetFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse interactions dataset: %w", err)
+	}
+
+	for _, product := range file.Products {
+		ingredients := make([]string, 0, len(product.Ingredients))
+		for _, ingredient := range product.Ingredients {
+			ingredients = append(ingredients, interaction.NormalizeName(ingredient))
+		}
+		source.products[interaction.NormalizeName(product.Name)] = ingredients
+	}
+
+	for _, item := range file.Interactions {
+		a := interaction.NormalizeName(item.Ingredients[0])
+		b := interaction.NormalizeName(item.Ingredients[1])
+		if a == "" || b == "" {
+			return nil, fmt.Errorf("interactions dataset: interaction %q has an empty ingredient", item.Description)
+		}
+		source.interactions[pairKey(a, b)] = &interaction.Interaction{
+			IngredientA: a,
+			IngredientB: b,
+			Description: item.Description,
+			Source:      item.Source,
+		}
+	}
+
+	return source, nil
+}
+
+// Ingredients возвращает действующие вещества лекарства. Название, которого нет
+// среди препаратов, считается названием самого вещества.
+func (s *FileSource) Ingredients(name string) []string {
+	normalized := interaction.NormalizeName(name)
+	if normalized == "" {
+		return nil
+	}
+	if ingredients, ok := s.products[normalized]; ok {
+		return ingredients
+	}
+	return []string{normalized}
+}
+
+// Interaction возвращает известное взаимодействие пары веществ независимо от порядка
+func (s *FileSource) Interaction(a, b string) *interaction.Interaction {
+	return s.interactions[pairKey(interaction.NormalizeName(a), interaction.NormalizeName(b))]
+}
+
+// pairKey возвращает ключ пары веществ, не зависящий от порядка
+func pairKey(a, b string) [2]string {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]string{a, b}
+}
@@ -19,6 +19,7 @@ type analysisModel struct {
 	FileURL         string     `gorm:"type:varchar(500);not null"`
 	FileType        string     `gorm:"type:varchar(10);not null;check:file_type IN ('image','pdf')"`
 	NextReminderDate *time.Time `gorm:"type:date;index"`
+	FollowUpAnalysisID *uuid.UUID `gorm:"type:uuid"`
 	CreatedAt       time.Time  `gorm:"not null"`
 	UpdatedAt       time.Time  `gorm:"not null"`
 }
@@ -60,6 +61,7 @@ func (m *analysisModel) toDomain() *analysis.Analysis {
 		FileURL:         m.FileURL,
 		FileType:        fileType,
 		NextReminderDate: m.NextReminderDate,
+		FollowUpAnalysisID: m.FollowUpAnalysisID,
 		CreatedAt:       m.CreatedAt,
 		UpdatedAt:       m.UpdatedAt,
 	}
@@ -75,6 +77,7 @@ func (m *analysisModel) fromDomain(a *analysis.Analysis) {
 	m.FileURL = a.FileURL
 	m.FileType = string(a.FileType)
 	m.NextReminderDate = a.NextReminderDate
+	m.FollowUpAnalysisID = a.FollowUpAnalysisID
 	m.CreatedAt = a.CreatedAt
 	m.UpdatedAt = a.UpdatedAt
 }
@@ -161,9 +164,11 @@ func (r *AnalysisRepository) Update(ctx context.Context, a *analysis.Analysis) e
 	model := &analysisModel{}
 	model.fromDomain(a)
 
+	// Select("*") нужен, чтобы сохранять сброс next_reminder_date в NULL
 	return r.db.WithContext(ctx).
 		Model(&analysisModel{}).
 		Where("id = ?", a.ID).
+		Select("*").
 		Updates(model).Error
 }
 
@@ -211,3 +216,21 @@ func (r *AnalysisRepository) GetUpcomingReminders(ctx context.Context, userID uu
 	return analyses, nil
 }
 
+
+// FindWithReminderBefore возвращает анализы всех пользователей с датой повторного анализа не позже beforeDate
+func (r *AnalysisRepository) FindWithReminderBefore(ctx context.Context, beforeDate time.Time) ([]*analysis.Analysis, error) {
+	var models []analysisModel
+	if err := r.db.WithContext(ctx).
+		Where("next_reminder_date IS NOT NULL AND next_reminder_date <= ?", beforeDate).
+		Order("next_reminder_date ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	analyses := make([]*analysis.Analysis, len(models))
+	for i := range models {
+		analyses[i] = models[i].toDomain()
+	}
+
+	return analyses, nil
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/audit"
+	"gorm.io/gorm"
+)
+
+// auditFieldsJSON представляет JSON для имён полей записи журнала
+type auditFieldsJSON []string
+
+// Value реализует driver.Valuer для GORM
+func (f auditFieldsJSON) Value() (driver.Value, error) {
+	if f == nil {
+		return json.Marshal([]string{})
+	}
+	return json.Marshal([]string(f))
+}
+
+// Scan реализует sql.Scanner для GORM
+func (f *auditFieldsJSON) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(bytes, f)
+}
+
+// auditEntryModel представляет модель записи журнала аудита в БД
+type auditEntryModel struct {
+	ID                uuid.UUID       `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	OwnerID           uuid.UUID       `gorm:"type:uuid;not null;index"`
+	ActorKind         string          `gorm:"type:varchar(20);not null"`
+	ActorUserID       *uuid.UUID      `gorm:"type:uuid"`
+	ActorCredentialID *uuid.UUID      `gorm:"type:uuid"`
+	Action            string          `gorm:"type:varchar(10);not null"`
+	Operation         string          `gorm:"type:varchar(100);not null"`
+	EntityType        string          `gorm:"type:varchar(50);not null"`
+	EntityID          *uuid.UUID      `gorm:"type:uuid"`
+	Fields            auditFieldsJSON `gorm:"type:jsonb;not null"`
+	RequestID         string          `gorm:"type:varchar(64);not null"`
+	CreatedAt         time.Time       `gorm:"not null"`
+}
+
+// TableName возвращает имя таблицы
+func (auditEntryModel) TableName() string {
+	return "audit_log"
+}
+
+// toDomain преобразует модель БД в доменную сущность
+func (m *auditEntryModel) toDomain() *audit.Entry {
+	return &audit.Entry{
+		ID:      m.ID,
+		OwnerID: m.OwnerID,
+		Actor: audit.Actor{
+			Kind:         audit.ActorKind(m.ActorKind),
+			UserID:       m.ActorUserID,
+			CredentialID: m.ActorCredentialID,
+		},
+		Action:     audit.Action(m.Action),
+		Operation:  m.Operation,
+		EntityType: m.EntityType,
+		EntityID:   m.EntityID,
+		Fields:     m.Fields,
+		RequestID:  m.RequestID,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+// fromDomain преобразует доменную сущность в модель БД
+func (m *auditEntryModel) fromDomain(entry *audit.Entry) {
+	m.ID = entry.ID
+	m.OwnerID = entry.OwnerID
+	m.ActorKind = string(entry.Actor.Kind)
+	m.ActorUserID = entry.Actor.UserID
+	m.ActorCredentialID = entry.Actor.CredentialID
+	m.Action = string(entry.Action)
+	m.Operation = entry.Operation
+	m.EntityType = entry.EntityType
+	m.EntityID = entry.EntityID
+	m.Fields = entry.Fields
+	m.RequestID = entry.RequestID
+	m.CreatedAt = entry.CreatedAt
+}
+
+// AuditRepository реализует audit.Repository для PostgreSQL
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository создаёт новый репозиторий журнала аудита
+func NewAuditRepository(db *gorm.DB) audit.Repository {
+	return &AuditRepository{db: db}
+}
+
+// Create добавляет запись в журнал
+func (r *AuditRepository) Create(ctx context.Context, entry *audit.Entry) error {
+	model := &auditEntryModel{}
+	model.fromDomain(entry)
+
+	return r.db.WithContext(ctx).Create(model).Error
+}
+
+// FindByOwnerID возвращает последние записи журнала профиля
+func (r *AuditRepository) FindByOwnerID(ctx context.Context, ownerID uuid.UUID, filter audit.Filter, limit int) ([]*audit.Entry, error) {
+	query := r.db.WithContext(ctx).Where("owner_id = ?", ownerID)
+	if filter.OthersOnly {
+		query = query.Where("actor_kind <> ?", string(audit.ActorOwner))
+	}
+
+	var models []auditEntryModel
+	if err := query.
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	entries := make([]*audit.Entry, len(models))
+	for i := range models {
+		entries[i] = models[i].toDomain()
+	}
+
+	return entries, nil
+}
+
+// DeleteBefore удаляет записи старше before
+func (r *AuditRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("created_at < ?", before).
+		Delete(&auditEntryModel{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/health-hub-bot-api/internal/domain/notification"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// botUpdateModel представляет модель принятого обновления бота в БД
+type botUpdateModel struct {
+	UpdateID   int64     `gorm:"primaryKey;autoIncrement:false"`
+	ReceivedAt time.Time `gorm:"not null"`
+}
+
+// TableName возвращает имя таблицы
+func (botUpdateModel) TableName() string {
+	return "bot_updates"
+}
+
+// BotUpdateRepository реализует notification.UpdateRepository
+type BotUpdateRepository struct {
+	db *gorm.DB
+}
+
+// NewBotUpdateRepository создаёт новый репозиторий принятых обновлений бота
+func NewBotUpdateRepository(db *gorm.DB) notification.UpdateRepository {
+	return &BotUpdateRepository{db: db}
+}
+
+// Claim отмечает обновление как принятое; повторная отметка ничего не вставляет
+func (r *BotUpdateRepository) Claim(ctx context.Context, updateID int64) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&botUpdateModel{UpdateID: updateID, ReceivedAt: time.Now()})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteBefore удаляет отметки, принятые раньше before
+func (r *BotUpdateRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("received_at < ?", before).
+		Delete(&botUpdateModel{})
+	return result.RowsAffected, result.Error
+}
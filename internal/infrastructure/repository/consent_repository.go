@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/consent"
+	"gorm.io/gorm"
+)
+
+// consentModel представляет модель согласия в БД
+type consentModel struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null;index"`
+	PolicyVersion string    `gorm:"type:varchar(50);not null"`
+	Channel       string    `gorm:"type:varchar(20);not null"`
+	EvidenceHash  string    `gorm:"type:varchar(64);not null"`
+	AcceptedAt    time.Time `gorm:"not null"`
+	WithdrawnAt   *time.Time
+}
+
+// TableName возвращает имя таблицы
+func (consentModel) TableName() string {
+	return "consents"
+}
+
+// toDomain преобразует модель БД в доменную сущность
+func (m *consentModel) toDomain() *consent.Consent {
+	return &consent.Consent{
+		ID:            m.ID,
+		UserID:        m.UserID,
+		PolicyVersion: m.PolicyVersion,
+		Channel:       consent.Channel(m.Channel),
+		EvidenceHash:  m.EvidenceHash,
+		AcceptedAt:    m.AcceptedAt,
+		WithdrawnAt:   m.WithdrawnAt,
+	}
+}
+
+// fromDomain преобразует доменную сущность в модель БД
+func (m *consentModel) fromDomain(c *consent.Consent) {
+	m.ID = c.ID
+	m.UserID = c.UserID
+	m.PolicyVersion = c.PolicyVersion
+	m.Channel = string(c.Channel)
+	m.EvidenceHash = c.EvidenceHash
+	m.AcceptedAt = c.AcceptedAt
+	m.WithdrawnAt = c.WithdrawnAt
+}
+
+// ConsentRepository реализует consent.Repository для PostgreSQL
+type ConsentRepository struct {
+	db *gorm.DB
+}
+
+// NewConsentRepository создаёт новый репозиторий согласий
+func NewConsentRepository(db *gorm.DB) consent.Repository {
+	return &ConsentRepository{db: db}
+}
+
+// Create сохраняет новое согласие
+func (r *ConsentRepository) Create(ctx context.Context, c *consent.Consent) error {
+	model := &consentModel{}
+	model.fromDomain(c)
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+
+	*c = *model.toDomain()
+	return nil
+}
+
+// GetLatest возвращает последнее согласие пользователя или nil, если его не было
+func (r *ConsentRepository) GetLatest(ctx context.Context, userID uuid.UUID) (*consent.Consent, error) {
+	var model consentModel
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("accepted_at DESC").
+		First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.toDomain(), nil
+}
+
+// Update обновляет согласие
+func (r *ConsentRepository) Update(ctx context.Context, c *consent.Consent) error {
+	model := &consentModel{}
+	model.fromDomain(c)
+
+	return r.db.WithContext(ctx).
+		Model(&consentModel{}).
+		Where("id = ?", c.ID).
+		Select("*").
+		Updates(model).Error
+}
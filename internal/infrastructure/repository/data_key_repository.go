@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/encryption"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// dataKeyModel представляет модель ключа данных в БД
+type dataKeyModel struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;index"`
+	MasterKeyID string    `gorm:"type:varchar(64);not null"`
+	WrappedKey  []byte    `gorm:"type:bytea;not null"`
+	CreatedAt   time.Time `gorm:"not null"`
+	RetiredAt   *time.Time
+}
+
+// TableName возвращает имя таблицы
+func (dataKeyModel) TableName() string {
+	return "user_data_keys"
+}
+
+// toDomain преобразует модель БД в доменную сущность
+func (m *dataKeyModel) toDomain() *encryption.DataKey {
+	return &encryption.DataKey{
+		ID:          m.ID,
+		UserID:      m.UserID,
+		MasterKeyID: m.MasterKeyID,
+		WrappedKey:  m.WrappedKey,
+		CreatedAt:   m.CreatedAt,
+		RetiredAt:   m.RetiredAt,
+	}
+}
+
+// fromDomain преобразует доменную сущность в модель БД
+func (m *dataKeyModel) fromDomain(key *encryption.DataKey) {
+	m.ID = key.ID
+	m.UserID = key.UserID
+	m.MasterKeyID = key.MasterKeyID
+	m.WrappedKey = key.WrappedKey
+	m.CreatedAt = key.CreatedAt
+	m.RetiredAt = key.RetiredAt
+}
+
+// DataKeyRepository реализует encryption.DataKeyRepository для PostgreSQL
+type DataKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewDataKeyRepository создаёт новый репозиторий ключей данных
+func NewDataKeyRepository(db *gorm.DB) encryption.DataKeyRepository {
+	return &DataKeyRepository{db: db}
+}
+
+// Create сохраняет новый ключ. Уникальный индекс допускает один
+// действующий ключ на профиль, поэтому конфликт означает, что ключ
+// одновременно создан другим запросом.
+func (r *DataKeyRepository) Create(ctx context.Context, key *encryption.DataKey) error {
+	model := &dataKeyModel{}
+	model.fromDomain(key)
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return encryption.ErrDataKeyAlreadyActive
+	}
+
+	*key = *model.toDomain()
+	return nil
+}
+
+// GetByID возвращает ключ по ID
+func (r *DataKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*encryption.DataKey, error) {
+	var model dataKeyModel
+	if err := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, encryption.ErrDataKeyNotFound
+		}
+		return nil, err
+	}
+
+	return model.toDomain(), nil
+}
+
+// GetActive возвращает действующий ключ профиля или nil
+func (r *DataKeyRepository) GetActive(ctx context.Context, userID uuid.UUID) (*encryption.DataKey, error) {
+	var model dataKeyModel
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND retired_at IS NULL", userID).
+		First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.toDomain(), nil
+}
+
+// FindActive возвращает действующие ключи всех профилей
+func (r *DataKeyRepository) FindActive(ctx context.Context) ([]*encryption.DataKey, error) {
+	return r.find(ctx, "retired_at IS NULL")
+}
+
+// FindNotWrappedWith возвращает ключи, зашифрованные другим мастер-ключом
+func (r *DataKeyRepository) FindNotWrappedWith(ctx context.Context, masterKeyID string) ([]*encryption.DataKey, error) {
+	return r.find(ctx, "master_key_id <> ?", masterKeyID)
+}
+
+// FindRetiredBefore возвращает ключи, выведенные из оборота до before
+func (r *DataKeyRepository) FindRetiredBefore(ctx context.Context, before time.Time) ([]*encryption.DataKey, error) {
+	return r.find(ctx, "retired_at < ?", before)
+}
+
+// Update обновляет ключ
+func (r *DataKeyRepository) Update(ctx context.Context, key *encryption.DataKey) error {
+	model := &dataKeyModel{}
+	model.fromDomain(key)
+
+	return r.db.WithContext(ctx).
+		Model(&dataKeyModel{}).
+		Where("id = ?", key.ID).
+		Select("*").
+		Updates(model).Error
+}
+
+// Delete удаляет ключ
+func (r *DataKeyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Where("id = ?", id).
+		Delete(&dataKeyModel{}).Error
+}
+
+// find возвращает ключи по условию, старые первыми
+func (r *DataKeyRepository) find(ctx context.Context, query string, args ...interface{}) ([]*encryption.DataKey, error) {
+	var models []dataKeyModel
+	if err := r.db.WithContext(ctx).
+		Where(query, args...).
+		Order("created_at ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	keys := make([]*encryption.DataKey, len(models))
+	for i := range models {
+		keys[i] = models[i].toDomain()
+	}
+
+	return keys, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+	"github.com/health-hub-bot-api/internal/infrastructure/encryption"
 	"gorm.io/gorm"
 )
 
@@ -116,14 +117,42 @@ func (m *doctorVisitModel) fromDomain(v *doctorvisit.DoctorVisit) error {
 	return nil
 }
 
+// encrypt шифрует чувствительные колонки визита
+func (m *doctorVisitModel) encrypt(ctx context.Context, c *encryption.FieldCipher) error {
+	return encryptOptionalField(ctx, c, m.UserID, visitQuestionsField, &m.Questions)
+}
+
+// decrypt расшифровывает чувствительные колонки визита
+func (m *doctorVisitModel) decrypt(ctx context.Context, c *encryption.FieldCipher) error {
+	return decryptOptionalField(ctx, c, visitQuestionsField, &m.Questions)
+}
+
 // DoctorVisitRepository реализует doctorvisit.Repository для PostgreSQL
 type DoctorVisitRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	cipher *encryption.FieldCipher
 }
 
-// NewDoctorVisitRepository создаёт новый репозиторий визитов к врачу
-func NewDoctorVisitRepository(db *gorm.DB) doctorvisit.Repository {
-	return &DoctorVisitRepository{db: db}
+// NewDoctorVisitRepository создаёт новый репозиторий визитов к врачу.
+// Вопросы к врачу шифруются cipher; nil хранит их открытыми.
+func NewDoctorVisitRepository(db *gorm.DB, cipher *encryption.FieldCipher) doctorvisit.Repository {
+	return &DoctorVisitRepository{db: db, cipher: cipher}
+}
+
+// toDomain расшифровывает модели и преобразует их в доменные сущности
+func (r *DoctorVisitRepository) toDomain(ctx context.Context, models []doctorVisitModel) ([]*doctorvisit.DoctorVisit, error) {
+	visits := make([]*doctorvisit.DoctorVisit, 0, len(models))
+	for i := range models {
+		if err := models[i].decrypt(ctx, r.cipher); err != nil {
+			return nil, err
+		}
+		visit, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		visits = append(visits, visit)
+	}
+	return visits, nil
 }
 
 // Create создаёт новый визит
@@ -132,11 +161,17 @@ func (r *DoctorVisitRepository) Create(ctx context.Context, visit *doctorvisit.D
 	if err := model.fromDomain(visit); err != nil {
 		return err
 	}
+	if err := model.encrypt(ctx, r.cipher); err != nil {
+		return err
+	}
 
 	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
 		return err
 	}
 
+	if err := model.decrypt(ctx, r.cipher); err != nil {
+		return err
+	}
 	domain, err := model.toDomain()
 	if err != nil {
 		return err
@@ -157,13 +192,23 @@ func (r *DoctorVisitRepository) GetByID(ctx context.Context, id uuid.UUID) (*doc
 		return nil, err
 	}
 
+	if err := model.decrypt(ctx, r.cipher); err != nil {
+		return nil, err
+	}
 	return model.toDomain()
 }
 
-// FindByUserID возвращает визиты пользователя
-func (r *DoctorVisitRepository) FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*doctorvisit.DoctorVisit, int, error) {
+// FindByFilter возвращает визиты по фильтру
+func (r *DoctorVisitRepository) FindByFilter(ctx context.Context, filter doctorvisit.Filter, limit, offset int) ([]*doctorvisit.DoctorVisit, int, error) {
 	query := r.db.WithContext(ctx).Model(&doctorVisitModel{}).
-		Where("user_id = ?", userID)
+		Where("user_id = ?", filter.UserID)
+
+	if filter.StartDate != nil {
+		query = query.Where("visit_date >= ?", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		query = query.Where("visit_date <= ?", *filter.EndDate)
+	}
 
 	// Подсчёт общего количества
 	var total int64
@@ -181,13 +226,9 @@ func (r *DoctorVisitRepository) FindByUserID(ctx context.Context, userID uuid.UU
 		return nil, 0, err
 	}
 
-	visits := make([]*doctorvisit.DoctorVisit, 0, len(models))
-	for i := range models {
-		visit, err := models[i].toDomain()
-		if err != nil {
-			return nil, 0, err
-		}
-		visits = append(visits, visit)
+	visits, err := r.toDomain(ctx, models)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	return visits, int(total), nil
@@ -199,6 +240,9 @@ func (r *DoctorVisitRepository) Update(ctx context.Context, visit *doctorvisit.D
 	if err := model.fromDomain(visit); err != nil {
 		return err
 	}
+	if err := model.encrypt(ctx, r.cipher); err != nil {
+		return err
+	}
 
 	return r.db.WithContext(ctx).
 		Model(&doctorVisitModel{}).
@@ -223,15 +267,18 @@ func (r *DoctorVisitRepository) GetUpcomingVisits(ctx context.Context, userID uu
 		return nil, err
 	}
 
-	visits := make([]*doctorvisit.DoctorVisit, 0, len(models))
-	for i := range models {
-		visit, err := models[i].toDomain()
-		if err != nil {
-			return nil, err
-		}
-		visits = append(visits, visit)
+	return r.toDomain(ctx, models)
+}
+
+// FindBetween возвращает визиты всех пользователей с датой в диапазоне [from, to]
+func (r *DoctorVisitRepository) FindBetween(ctx context.Context, from, to time.Time) ([]*doctorvisit.DoctorVisit, error) {
+	var models []doctorVisitModel
+	if err := r.db.WithContext(ctx).
+		Where("visit_date >= ? AND visit_date <= ?", from, to).
+		Order("visit_date ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
 	}
 
-	return visits, nil
+	return r.toDomain(ctx, models)
 }
-
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/infrastructure/encryption"
+)
+
+// Колонки, которые хранятся зашифрованными ключом данных профиля
+const (
+	symptomDescriptionField = "symptom_entries.description"
+	visitQuestionsField     = "doctor_visits.questions"
+	intakeNotesField        = "medication_intakes.notes"
+	intakeSkipReasonField   = "medication_intakes.skip_reason"
+	intakeReasonField       = "medication_intakes.reason"
+)
+
+// medication_courses.reason не шифруется: это код причины завершения курса
+// (completed, stopped, replaced) под ограничением CHECK, а не свободный текст
+
+// encryptField шифрует значение колонки на месте
+func encryptField(ctx context.Context, c *encryption.FieldCipher, userID uuid.UUID, field string, value *string) error {
+	encrypted, err := c.Encrypt(ctx, userID, field, *value)
+	if err != nil {
+		return err
+	}
+	*value = encrypted
+	return nil
+}
+
+// encryptOptionalField шифрует необязательное значение колонки. Указатель
+// заменяется, потому что модель разделяет строку с доменной сущностью.
+func encryptOptionalField(ctx context.Context, c *encryption.FieldCipher, userID uuid.UUID, field string, value **string) error {
+	if *value == nil {
+		return nil
+	}
+	encrypted, err := c.Encrypt(ctx, userID, field, **value)
+	if err != nil {
+		return err
+	}
+	*value = &encrypted
+	return nil
+}
+
+// decryptField расшифровывает значение колонки на месте
+func decryptField(ctx context.Context, c *encryption.FieldCipher, field string, value *string) error {
+	plain, err := c.Decrypt(ctx, field, *value)
+	if err != nil {
+		return err
+	}
+	*value = plain
+	return nil
+}
+
+// decryptOptionalField расшифровывает необязательное значение колонки
+func decryptOptionalField(ctx context.Context, c *encryption.FieldCipher, field string, value **string) error {
+	if *value == nil {
+		return nil
+	}
+	plain, err := c.Decrypt(ctx, field, **value)
+	if err != nil {
+		return err
+	}
+	*value = &plain
+	return nil
+}
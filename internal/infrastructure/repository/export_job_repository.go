@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/export"
+	"gorm.io/gorm"
+)
+
+// exportJobModel представляет модель задачи экспорта в БД
+type exportJobModel struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;index"`
+	Status      string    `gorm:"type:varchar(20);not null"`
+	ArchiveURL  *string   `gorm:"type:varchar(500)"`
+	SizeBytes   int64     `gorm:"not null;default:0"`
+	Error       *string   `gorm:"type:text"`
+	ExpiresAt   *time.Time
+	CreatedAt   time.Time `gorm:"not null"`
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+}
+
+// TableName возвращает имя таблицы
+func (exportJobModel) TableName() string {
+	return "export_jobs"
+}
+
+// toDomain преобразует модель БД в доменную сущность
+func (m *exportJobModel) toDomain() *export.Job {
+	return &export.Job{
+		ID:          m.ID,
+		UserID:      m.UserID,
+		Status:      export.Status(m.Status),
+		ArchiveURL:  m.ArchiveURL,
+		SizeBytes:   m.SizeBytes,
+		Error:       m.Error,
+		ExpiresAt:   m.ExpiresAt,
+		CreatedAt:   m.CreatedAt,
+		StartedAt:   m.StartedAt,
+		CompletedAt: m.CompletedAt,
+	}
+}
+
+// fromDomain преобразует доменную сущность в модель БД
+func (m *exportJobModel) fromDomain(job *export.Job) {
+	m.ID = job.ID
+	m.UserID = job.UserID
+	m.Status = string(job.Status)
+	m.ArchiveURL = job.ArchiveURL
+	m.SizeBytes = job.SizeBytes
+	m.Error = job.Error
+	m.ExpiresAt = job.ExpiresAt
+	m.CreatedAt = job.CreatedAt
+	m.StartedAt = job.StartedAt
+	m.CompletedAt = job.CompletedAt
+}
+
+// ExportJobRepository реализует export.JobRepository для PostgreSQL
+type ExportJobRepository struct {
+	db *gorm.DB
+}
+
+// NewExportJobRepository создаёт новый репозиторий задач экспорта
+func NewExportJobRepository(db *gorm.DB) export.JobRepository {
+	return &ExportJobRepository{db: db}
+}
+
+// Create создаёт новую задачу
+func (r *ExportJobRepository) Create(ctx context.Context, job *export.Job) error {
+	model := &exportJobModel{}
+	model.fromDomain(job)
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+
+	*job = *model.toDomain()
+	return nil
+}
+
+// GetByID возвращает задачу по ID
+func (r *ExportJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*export.Job, error) {
+	var model exportJobModel
+	if err := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, export.ErrJobNotFound
+		}
+		return nil, err
+	}
+
+	return model.toDomain(), nil
+}
+
+// FindByUserID возвращает задачи профиля, новые первыми
+func (r *ExportJobRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*export.Job, error) {
+	return r.find(ctx, "created_at DESC", 0, "user_id = ?", userID)
+}
+
+// FindPending возвращает задачи в очереди, старые первыми
+func (r *ExportJobRepository) FindPending(ctx context.Context, limit int) ([]*export.Job, error) {
+	return r.find(ctx, "created_at ASC", limit, "status = ?", string(export.StatusPending))
+}
+
+// FindStale возвращает задачи, сборка которых началась до startedBefore и не завершилась
+func (r *ExportJobRepository) FindStale(ctx context.Context, startedBefore time.Time) ([]*export.Job, error) {
+	return r.find(ctx, "started_at ASC", 0, "status = ? AND started_at < ?", string(export.StatusProcessing), startedBefore)
+}
+
+// FindExpired возвращает готовые задачи, срок скачивания которых истёк до before
+func (r *ExportJobRepository) FindExpired(ctx context.Context, before time.Time) ([]*export.Job, error) {
+	return r.find(ctx, "expires_at ASC", 0, "status = ? AND expires_at < ?", string(export.StatusCompleted), before)
+}
+
+// Update обновляет задачу
+func (r *ExportJobRepository) Update(ctx context.Context, job *export.Job) error {
+	model := &exportJobModel{}
+	model.fromDomain(job)
+
+	return r.db.WithContext(ctx).
+		Model(&exportJobModel{}).
+		Where("id = ?", job.ID).
+		Select("*").
+		Updates(model).Error
+}
+
+// find возвращает задачи по условию в заданном порядке; limit 0 — без ограничения
+func (r *ExportJobRepository) find(ctx context.Context, order string, limit int, query string, args ...interface{}) ([]*export.Job, error) {
+	db := r.db.WithContext(ctx).
+		Where(query, args...).
+		Order(order)
+	if limit > 0 {
+		db = db.Limit(limit)
+	}
+
+	var models []exportJobModel
+	if err := db.Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*export.Job, len(models))
+	for i := range models {
+		jobs[i] = models[i].toDomain()
+	}
+
+	return jobs, nil
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/health-hub-bot-api/internal/domain/analysis"
+	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+	"github.com/health-hub-bot-api/internal/domain/engagement"
+	"github.com/health-hub-bot-api/internal/domain/export"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/symptom"
+	"github.com/health-hub-bot-api/internal/infrastructure/encryption"
+	"gorm.io/gorm"
+)
+
+// importSavePoint — точка сохранения перед каждой импортируемой записью
+const importSavePoint = "import_row"
+
+// ImportRepository реализует export.ImportRepository для PostgreSQL
+type ImportRepository struct {
+	db     *gorm.DB
+	cipher *encryption.FieldCipher
+}
+
+// NewImportRepository создаёт новый репозиторий импорта. Чувствительные
+// колонки шифруются так же, как в репозиториях сущностей.
+func NewImportRepository(db *gorm.DB, cipher *encryption.FieldCipher) export.ImportRepository {
+	return &ImportRepository{db: db, cipher: cipher}
+}
+
+// ImportSymptoms сохраняет записи дневника в одной транзакции
+func (r *ImportRepository) ImportSymptoms(ctx context.Context, entries []*symptom.SymptomEntry) ([]error, error) {
+	return importRows(ctx, r.db, entries, func(entry *symptom.SymptomEntry) (interface{}, error) {
+		model := &symptomModel{}
+		model.fromDomain(entry)
+		if err := model.encrypt(ctx, r.cipher); err != nil {
+			return nil, err
+		}
+		return model, nil
+	})
+}
+
+// ImportAnalyses сохраняет анализы в одной транзакции
+func (r *ImportRepository) ImportAnalyses(ctx context.Context, analyses []*analysis.Analysis) ([]error, error) {
+	return importRows(ctx, r.db, analyses, func(a *analysis.Analysis) (interface{}, error) {
+		model := &analysisModel{}
+		model.fromDomain(a)
+		return model, nil
+	})
+}
+
+// ImportMedications сохраняет лекарства в одной транзакции
+func (r *ImportRepository) ImportMedications(ctx context.Context, medications []*medication.Medication) ([]error, error) {
+	return importRows(ctx, r.db, medications, func(med *medication.Medication) (interface{}, error) {
+		model := &medicationModel{}
+		model.fromDomain(med)
+		return model, nil
+	})
+}
+
+// ImportIntakes сохраняет приёмы лекарств в одной транзакции
+func (r *ImportRepository) ImportIntakes(ctx context.Context, intakes []*medication.MedicationIntake) ([]error, error) {
+	return importRows(ctx, r.db, intakes, func(intake *medication.MedicationIntake) (interface{}, error) {
+		model := &medicationIntakeModel{}
+		model.fromDomain(intake)
+		if err := encryptIntake(ctx, r.db, r.cipher, model); err != nil {
+			return nil, err
+		}
+		return model, nil
+	})
+}
+
+// ImportCourses сохраняет историю курсов в одной транзакции
+func (r *ImportRepository) ImportCourses(ctx context.Context, courses []*medication.Course) ([]error, error) {
+	return importRows(ctx, r.db, courses, func(course *medication.Course) (interface{}, error) {
+		model := &medicationCourseModel{}
+		model.fromDomain(course)
+		return model, nil
+	})
+}
+
+// ImportDoctorVisits сохраняет визиты вместе со снимками отчётов в одной транзакции
+func (r *ImportRepository) ImportDoctorVisits(ctx context.Context, visits []*doctorvisit.DoctorVisit) ([]error, error) {
+	return importRows(ctx, r.db, visits, func(visit *doctorvisit.DoctorVisit) (interface{}, error) {
+		model := &doctorVisitModel{}
+		if err := model.fromDomain(visit); err != nil {
+			return nil, err
+		}
+		if err := model.encrypt(ctx, r.cipher); err != nil {
+			return nil, err
+		}
+		return model, nil
+	})
+}
+
+// ImportMilestones сохраняет вехи в одной транзакции
+func (r *ImportRepository) ImportMilestones(ctx context.Context, milestones []*engagement.Milestone) ([]error, error) {
+	return importRows(ctx, r.db, milestones, func(milestone *engagement.Milestone) (interface{}, error) {
+		model := &milestoneModel{}
+		model.fromDomain(milestone)
+		return model, nil
+	})
+}
+
+// importRows сохраняет записи в одной транзакции. Перед каждой записью ставится
+// точка сохранения, поэтому ошибка записи откатывает только её.
+func importRows[T any](ctx context.Context, db *gorm.DB, rows []T, toModel func(T) (interface{}, error)) ([]error, error) {
+	errs := make([]error, len(rows))
+	if len(rows) == 0 {
+		return errs, nil
+	}
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, row := range rows {
+			model, err := toModel(row)
+			if err != nil {
+				errs[i] = err
+				continue
+			}
+
+			if err := tx.SavePoint(importSavePoint).Error; err != nil {
+				return err
+			}
+			if err := tx.Create(model).Error; err != nil {
+				errs[i] = err
+				if err := tx.RollbackTo(importSavePoint).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+
+	return errs, err
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"gorm.io/gorm"
+)
+
+// medicationCourseModel представляет модель завершённого курса в БД
+type medicationCourseModel struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null;index"`
+	MedicationID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	MedicationName string     `gorm:"type:varchar(255);not null"`
+	Dosage         string     `gorm:"type:varchar(100);not null"`
+	StartDate      time.Time  `gorm:"type:date;not null"`
+	EndDate        time.Time  `gorm:"type:date;not null"`
+	Reason         string     `gorm:"type:varchar(20);not null"`
+	ReplacedByID   *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt      time.Time  `gorm:"not null"`
+}
+
+// TableName возвращает имя таблицы
+func (medicationCourseModel) TableName() string {
+	return "medication_courses"
+}
+
+// toDomain преобразует модель БД в доменную сущность
+func (m *medicationCourseModel) toDomain() *medication.Course {
+	endDate := m.EndDate
+	reason := medication.CourseEndReason(m.Reason)
+	return &medication.Course{
+		ID:             m.ID,
+		UserID:         m.UserID,
+		MedicationID:   m.MedicationID,
+		MedicationName: m.MedicationName,
+		Dosage:         m.Dosage,
+		StartDate:      m.StartDate,
+		EndDate:        &endDate,
+		Reason:         &reason,
+		ReplacedByID:   m.ReplacedByID,
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+// fromDomain преобразует доменную сущность в модель БД
+func (m *medicationCourseModel) fromDomain(course *medication.Course) {
+	m.ID = course.ID
+	m.UserID = course.UserID
+	m.MedicationID = course.MedicationID
+	m.MedicationName = course.MedicationName
+	m.Dosage = course.Dosage
+	m.StartDate = course.StartDate
+	if course.EndDate != nil {
+		m.EndDate = *course.EndDate
+	}
+	if course.Reason != nil {
+		m.Reason = string(*course.Reason)
+	}
+	m.ReplacedByID = course.ReplacedByID
+	m.CreatedAt = course.CreatedAt
+}
+
+// CourseRepository реализует medication.CourseRepository для PostgreSQL
+type CourseRepository struct {
+	db *gorm.DB
+}
+
+// NewCourseRepository создаёт новый репозиторий истории курсов
+func NewCourseRepository(db *gorm.DB) medication.CourseRepository {
+	return &CourseRepository{db: db}
+}
+
+// End в одной транзакции сохраняет деактивированное лекарство и записывает завершённый курс
+func (r *CourseRepository) End(ctx context.Context, med *medication.Medication, course *medication.Course) error {
+	medModel := &medicationModel{}
+	medModel.fromDomain(med)
+	model := &medicationCourseModel{}
+	model.fromDomain(course)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Условие на is_active не даёт завершить один курс дважды; запас,
+		// как и в MedicationRepository.Update, меняется только атомарными запросами
+		result := tx.Model(&medicationModel{}).
+			Where("id = ? AND is_active", med.ID).
+			Select("*").
+			Omit("stock_quantity", "refill_reminded_at").
+			Updates(medModel)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return medication.ErrMedicationInactive
+		}
+
+		return tx.Create(model).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	*course = *model.toDomain()
+	return nil
+}
+
+// FindByUserID возвращает завершённые курсы пользователя в хронологическом порядке
+func (r *CourseRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*medication.Course, error) {
+	var models []medicationCourseModel
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("start_date ASC, end_date ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	return coursesToDomain(models), nil
+}
+
+// FindEndedBetween возвращает курсы пользователя, завершённые в периоде
+func (r *CourseRepository) FindEndedBetween(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]*medication.Course, error) {
+	var models []medicationCourseModel
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND end_date >= ? AND end_date <= ?", userID, startDate, endDate).
+		Order("end_date ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	return coursesToDomain(models), nil
+}
+
+// coursesToDomain преобразует список моделей в доменные сущности
+func coursesToDomain(models []medicationCourseModel) []*medication.Course {
+	courses := make([]*medication.Course, len(models))
+	for i := range models {
+		courses[i] = models[i].toDomain()
+	}
+	return courses
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/infrastructure/encryption"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // medicationIntakeModel представляет модель приёма лекарства в БД
@@ -14,8 +16,14 @@ type medicationIntakeModel struct {
 	ID            uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
 	MedicationID  uuid.UUID  `gorm:"type:uuid;not null;index"`
 	ScheduledTime time.Time  `gorm:"not null;index"`
+	Status        string     `gorm:"type:varchar(20);not null;default:'planned';index"`
 	TakenAt       *time.Time
-	IsTaken       bool       `gorm:"not null;default:false;index"`
+	MissedAt      *time.Time
+	SkipReason    *string    `gorm:"type:text"`
+	PlannedDose   *string    `gorm:"type:varchar(100)"`
+	ActualDose    *string    `gorm:"type:varchar(100)"`
+	IsAsNeeded    bool       `gorm:"not null;default:false"`
+	Reason        *string    `gorm:"type:text"`
 	Notes         *string    `gorm:"type:text"`
 	CreatedAt     time.Time  `gorm:"not null"`
 }
@@ -31,8 +39,14 @@ func (m *medicationIntakeModel) toDomain() *medication.MedicationIntake {
 		ID:            m.ID,
 		MedicationID:  m.MedicationID,
 		ScheduledTime: m.ScheduledTime,
+		Status:        medication.IntakeStatus(m.Status),
 		TakenAt:       m.TakenAt,
-		IsTaken:       m.IsTaken,
+		MissedAt:      m.MissedAt,
+		SkipReason:    m.SkipReason,
+		PlannedDose:   m.PlannedDose,
+		ActualDose:    m.ActualDose,
+		IsAsNeeded:    m.IsAsNeeded,
+		Reason:        m.Reason,
 		Notes:         m.Notes,
 		CreatedAt:     m.CreatedAt,
 	}
@@ -43,31 +57,94 @@ func (m *medicationIntakeModel) fromDomain(intake *medication.MedicationIntake)
 	m.ID = intake.ID
 	m.MedicationID = intake.MedicationID
 	m.ScheduledTime = intake.ScheduledTime
+	m.Status = string(intake.Status)
 	m.TakenAt = intake.TakenAt
-	m.IsTaken = intake.IsTaken
+	m.MissedAt = intake.MissedAt
+	m.SkipReason = intake.SkipReason
+	m.PlannedDose = intake.PlannedDose
+	m.ActualDose = intake.ActualDose
+	m.IsAsNeeded = intake.IsAsNeeded
+	m.Reason = intake.Reason
 	m.Notes = intake.Notes
 	m.CreatedAt = intake.CreatedAt
 }
 
 // IntakeRepository реализует medication.IntakeRepository для PostgreSQL
 type IntakeRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	cipher *encryption.FieldCipher
 }
 
-// NewIntakeRepository создаёт новый репозиторий приёмов лекарств
-func NewIntakeRepository(db *gorm.DB) medication.IntakeRepository {
-	return &IntakeRepository{db: db}
+// NewIntakeRepository создаёт новый репозиторий приёмов лекарств.
+// Заметки и причины пропуска или приёма шифруются cipher; nil хранит их открытыми.
+func NewIntakeRepository(db *gorm.DB, cipher *encryption.FieldCipher) medication.IntakeRepository {
+	return &IntakeRepository{db: db, cipher: cipher}
+}
+
+// encryptIntake шифрует свободный текст приёма (заметки, причины пропуска
+// и приёма по необходимости) ключом владельца лекарства
+func encryptIntake(ctx context.Context, db *gorm.DB, c *encryption.FieldCipher, model *medicationIntakeModel) error {
+	if !c.Enabled() || (model.Notes == nil && model.SkipReason == nil && model.Reason == nil) {
+		return nil
+	}
+
+	var userIDs []uuid.UUID
+	if err := db.WithContext(ctx).
+		Model(&medicationModel{}).
+		Where("id = ?", model.MedicationID).
+		Pluck("user_id", &userIDs).Error; err != nil {
+		return err
+	}
+	if len(userIDs) == 0 {
+		return medication.ErrMedicationNotFound
+	}
+	if err := encryptOptionalField(ctx, c, userIDs[0], intakeNotesField, &model.Notes); err != nil {
+		return err
+	}
+	if err := encryptOptionalField(ctx, c, userIDs[0], intakeSkipReasonField, &model.SkipReason); err != nil {
+		return err
+	}
+	return encryptOptionalField(ctx, c, userIDs[0], intakeReasonField, &model.Reason)
+}
+
+// decryptIntake расшифровывает свободный текст приёма
+func decryptIntake(ctx context.Context, c *encryption.FieldCipher, model *medicationIntakeModel) error {
+	if err := decryptOptionalField(ctx, c, intakeNotesField, &model.Notes); err != nil {
+		return err
+	}
+	if err := decryptOptionalField(ctx, c, intakeSkipReasonField, &model.SkipReason); err != nil {
+		return err
+	}
+	return decryptOptionalField(ctx, c, intakeReasonField, &model.Reason)
+}
+
+// toDomain расшифровывает модели и преобразует их в доменные сущности
+func (r *IntakeRepository) toDomain(ctx context.Context, models []medicationIntakeModel) ([]*medication.MedicationIntake, error) {
+	intakes := make([]*medication.MedicationIntake, len(models))
+	for i := range models {
+		if err := decryptIntake(ctx, r.cipher, &models[i]); err != nil {
+			return nil, err
+		}
+		intakes[i] = models[i].toDomain()
+	}
+	return intakes, nil
 }
 
 // Create создаёт запись о приёме лекарства
 func (r *IntakeRepository) Create(ctx context.Context, intake *medication.MedicationIntake) error {
 	model := &medicationIntakeModel{}
 	model.fromDomain(intake)
+	if err := encryptIntake(ctx, r.db, r.cipher, model); err != nil {
+		return err
+	}
 
 	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
 		return err
 	}
 
+	if err := decryptIntake(ctx, r.cipher, model); err != nil {
+		return err
+	}
 	*intake = *model.toDomain()
 	return nil
 }
@@ -84,6 +161,9 @@ func (r *IntakeRepository) GetByID(ctx context.Context, id uuid.UUID) (*medicati
 		return nil, err
 	}
 
+	if err := decryptIntake(ctx, r.cipher, &model); err != nil {
+		return nil, err
+	}
 	return model.toDomain(), nil
 }
 
@@ -100,66 +180,178 @@ func (r *IntakeRepository) FindByMedicationAndDate(ctx context.Context, medicati
 		return nil, err
 	}
 
-	intakes := make([]*medication.MedicationIntake, len(models))
-	for i := range models {
-		intakes[i] = models[i].toDomain()
+	return r.toDomain(ctx, models)
+}
+
+// FindByMedicationAndPeriod возвращает приёмы за период
+func (r *IntakeRepository) FindByMedicationAndPeriod(ctx context.Context, medicationID uuid.UUID, startDate, endDate time.Time) ([]*medication.MedicationIntake, error) {
+	var models []medicationIntakeModel
+	if err := r.db.WithContext(ctx).
+		Where("medication_id = ? AND scheduled_time >= ? AND scheduled_time <= ?", medicationID, startDate, endDate).
+		Order("scheduled_time ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
 	}
 
-	return intakes, nil
+	return r.toDomain(ctx, models)
+}
+
+// FindByUserAndPeriod возвращает приёмы всех лекарств пользователя за период
+func (r *IntakeRepository) FindByUserAndPeriod(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]*medication.MedicationIntake, error) {
+	var models []medicationIntakeModel
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN medications ON medications.id = medication_intakes.medication_id").
+		Where("medications.user_id = ? AND medication_intakes.scheduled_time >= ? AND medication_intakes.scheduled_time <= ?", userID, startDate, endDate).
+		Order("medication_intakes.scheduled_time ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	return r.toDomain(ctx, models)
 }
 
 // Update обновляет запись о приёме
 func (r *IntakeRepository) Update(ctx context.Context, intake *medication.MedicationIntake) error {
 	model := &medicationIntakeModel{}
 	model.fromDomain(intake)
+	if err := encryptIntake(ctx, r.db, r.cipher, model); err != nil {
+		return err
+	}
 
+	// Select("*") нужен, чтобы сохранять сброс отметок (taken_at = NULL, skip_reason = NULL)
 	return r.db.WithContext(ctx).
 		Model(&medicationIntakeModel{}).
 		Where("id = ?", intake.ID).
+		Select("*").
 		Updates(model).Error
 }
 
+// UpdateMark сохраняет отметку приёма условным UPDATE по прежнему статусу и в той же
+// транзакции изменяет остаток лекарства, поэтому доза списывается или возвращается
+// ровно один раз даже при параллельных отметках
+func (r *IntakeRepository) UpdateMark(ctx context.Context, intake *medication.MedicationIntake, from medication.IntakeStatus, stockDelta float64) (bool, *float64, error) {
+	model := &medicationIntakeModel{}
+	model.fromDomain(intake)
+	if err := encryptIntake(ctx, r.db, r.cipher, model); err != nil {
+		return false, nil, err
+	}
+
+	var (
+		applied bool
+		stock   *float64
+	)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Model(&medicationIntakeModel{}).
+			Where("id = ? AND status = ?", intake.ID, from).
+			Select("*").
+			Updates(model)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return nil
+		}
+		applied = true
+
+		if stockDelta == 0 {
+			return nil
+		}
+		var err error
+		stock, err = adjustStock(tx, intake.MedicationID, stockDelta)
+		return err
+	})
+	if err != nil {
+		return false, nil, err
+	}
+	return applied, stock, nil
+}
+
+// Delete удаляет запись о приёме
+func (r *IntakeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Where("id = ?", id).
+		Delete(&medicationIntakeModel{}).Error
+}
+
+// FindUnmarked возвращает приёмы всех пользователей в статусе planned,
+// запланированные в диапазоне [from, to]
+func (r *IntakeRepository) FindUnmarked(ctx context.Context, from, to time.Time) ([]*medication.MedicationIntake, error) {
+	var models []medicationIntakeModel
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND scheduled_time >= ? AND scheduled_time <= ?", medication.IntakeStatusPlanned, from, to).
+		Order("scheduled_time ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	return r.toDomain(ctx, models)
+}
+
+// MarkMissed одним UPDATE переводит в missed те из приёмов ids, что всё ещё в статусе
+// planned, и возвращает их ID. Отметка пользователя, сделанная после выборки, не затирается
+func (r *IntakeRepository) MarkMissed(ctx context.Context, ids []uuid.UUID, at time.Time) ([]uuid.UUID, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var models []medicationIntakeModel
+	if err := r.db.WithContext(ctx).
+		Model(&models).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+		Where("id IN ? AND status = ?", ids, medication.IntakeStatusPlanned).
+		Updates(map[string]any{
+			"status":    medication.IntakeStatusMissed,
+			"missed_at": at,
+		}).Error; err != nil {
+		return nil, err
+	}
+
+	marked := make([]uuid.UUID, len(models))
+	for i := range models {
+		marked[i] = models[i].ID
+	}
+	return marked, nil
+}
+
 // GetUpcomingIntakes возвращает предстоящие приёмы
 func (r *IntakeRepository) GetUpcomingIntakes(ctx context.Context, userID uuid.UUID, fromTime time.Time, limit int) ([]*medication.MedicationIntake, error) {
 	var models []medicationIntakeModel
 	if err := r.db.WithContext(ctx).
 		Joins("JOIN medications ON medications.id = medication_intakes.medication_id").
-		Where("medications.user_id = ? AND medication_intakes.scheduled_time >= ? AND medication_intakes.is_taken = ?", userID, fromTime, false).
+		Where("medications.user_id = ? AND medication_intakes.scheduled_time >= ? AND medication_intakes.status = ?", userID, fromTime, medication.IntakeStatusPlanned).
 		Order("medication_intakes.scheduled_time ASC").
 		Limit(limit).
 		Find(&models).Error; err != nil {
 		return nil, err
 	}
 
-	intakes := make([]*medication.MedicationIntake, len(models))
-	for i := range models {
-		intakes[i] = models[i].toDomain()
-	}
-
-	return intakes, nil
+	return r.toDomain(ctx, models)
 }
 
-// GetComplianceRate возвращает процент соблюдения режима приёма
-func (r *IntakeRepository) GetComplianceRate(ctx context.Context, medicationID uuid.UUID, startDate, endDate time.Time) (float64, error) {
-	var result struct {
-		Total int64
-		Taken int64
+// GetComplianceStats возвращает количество приёмов по каждому статусу за период.
+// Приёмы по необходимости не входят в расписание и соблюдение не меняют
+func (r *IntakeRepository) GetComplianceStats(ctx context.Context, medicationID uuid.UUID, startDate, endDate time.Time) (*medication.ComplianceStats, error) {
+	var rows []struct {
+		Status string
+		Count  int
 	}
 
 	err := r.db.WithContext(ctx).
 		Model(&medicationIntakeModel{}).
-		Select("COUNT(*) as total, SUM(CASE WHEN is_taken THEN 1 ELSE 0 END) as taken").
-		Where("medication_id = ? AND scheduled_time >= ? AND scheduled_time <= ?", medicationID, startDate, endDate).
-		Scan(&result).Error
+		Select("status, COUNT(*) as count").
+		Where("medication_id = ? AND scheduled_time >= ? AND scheduled_time <= ? AND is_as_needed = FALSE", medicationID, startDate, endDate).
+		Group("status").
+		Scan(&rows).Error
 
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	if result.Total == 0 {
-		return 0, nil
+	stats := &medication.ComplianceStats{}
+	for _, row := range rows {
+		stats.Add(medication.IntakeStatus(row.Status), row.Count)
 	}
 
-	return float64(result.Taken) / float64(result.Total) * 100, nil
+	return stats, nil
 }
-
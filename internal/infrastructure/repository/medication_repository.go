@@ -9,12 +9,64 @@ import (
 	"github.com/google/uuid"
 	"github.com/health-hub-bot-api/internal/domain/medication"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-// scheduleDetailsJSON представляет JSON для schedule_details
+// scheduleDetailsJSON представляет JSON для schedule_details.
+// Новые поля необязательны, поэтому прежние записи с times/days читаются без изменений.
 type scheduleDetailsJSON struct {
-	Times []string `json:"times"`
-	Days  []int    `json:"days"`
+	Times         []string           `json:"times"`
+	Days          []int              `json:"days"`
+	IntervalHours int                `json:"interval_hours,omitempty"`
+	EveryNDays    int                `json:"every_n_days,omitempty"`
+	Cycle         *scheduleCycleJSON `json:"cycle,omitempty"`
+	DoseSteps     []doseStepJSON     `json:"dose_steps,omitempty"`
+}
+
+// scheduleCycleJSON представляет JSON циклического курса
+type scheduleCycleJSON struct {
+	DaysOn  int `json:"days_on"`
+	DaysOff int `json:"days_off"`
+}
+
+// doseStepJSON представляет JSON этапа дозировки
+type doseStepJSON struct {
+	Days int    `json:"days,omitempty"`
+	Dose string `json:"dose"`
+}
+
+// toDomain преобразует JSON расписания в доменную структуру
+func (s scheduleDetailsJSON) toDomain() medication.ScheduleDetails {
+	details := medication.ScheduleDetails{
+		Times:         s.Times,
+		Days:          s.Days,
+		IntervalHours: s.IntervalHours,
+		EveryNDays:    s.EveryNDays,
+	}
+	if s.Cycle != nil {
+		details.Cycle = &medication.ScheduleCycle{DaysOn: s.Cycle.DaysOn, DaysOff: s.Cycle.DaysOff}
+	}
+	for _, step := range s.DoseSteps {
+		details.DoseSteps = append(details.DoseSteps, medication.DoseStep{Days: step.Days, Dose: step.Dose})
+	}
+	return details
+}
+
+// newScheduleDetailsJSON преобразует доменное расписание в JSON
+func newScheduleDetailsJSON(details medication.ScheduleDetails) scheduleDetailsJSON {
+	s := scheduleDetailsJSON{
+		Times:         details.Times,
+		Days:          details.Days,
+		IntervalHours: details.IntervalHours,
+		EveryNDays:    details.EveryNDays,
+	}
+	if details.Cycle != nil {
+		s.Cycle = &scheduleCycleJSON{DaysOn: details.Cycle.DaysOn, DaysOff: details.Cycle.DaysOff}
+	}
+	for _, step := range details.DoseSteps {
+		s.DoseSteps = append(s.DoseSteps, doseStepJSON{Days: step.Days, Dose: step.Dose})
+	}
+	return s
 }
 
 // Value реализует driver.Valuer для GORM
@@ -36,17 +88,24 @@ func (s *scheduleDetailsJSON) Scan(value interface{}) error {
 
 // medicationModel представляет модель лекарства в БД
 type medicationModel struct {
-	ID              uuid.UUID           `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
-	UserID          uuid.UUID           `gorm:"type:uuid;not null;index"`
-	Name            string              `gorm:"type:varchar(255);not null"`
-	Dosage          string              `gorm:"type:varchar(100);not null"`
-	ScheduleType    string              `gorm:"type:varchar(20);not null;check:schedule_type IN ('daily','weekly','as_needed')"`
-	ScheduleDetails scheduleDetailsJSON `gorm:"type:jsonb;not null"`
-	StartDate       time.Time           `gorm:"type:date;not null"`
-	EndDate         *time.Time          `gorm:"type:date"`
-	IsActive        bool                `gorm:"not null;index"`
-	CreatedAt       time.Time           `gorm:"not null"`
-	UpdatedAt       time.Time           `gorm:"not null"`
+	ID               uuid.UUID           `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID           uuid.UUID           `gorm:"type:uuid;not null;index"`
+	Name             string              `gorm:"type:varchar(255);not null"`
+	Dosage           string              `gorm:"type:varchar(100);not null"`
+	DosageAmount     *float64            `gorm:"type:numeric(10,3)"`
+	DosageUnit       *string             `gorm:"type:varchar(20)"`
+	DosageForm       *string             `gorm:"type:varchar(20)"`
+	ScheduleType     string              `gorm:"type:varchar(20);not null;check:schedule_type IN ('daily','weekly','as_needed')"`
+	ScheduleDetails  scheduleDetailsJSON `gorm:"type:jsonb;not null"`
+	StartDate        time.Time           `gorm:"type:date;not null"`
+	EndDate          *time.Time          `gorm:"type:date"`
+	IsActive         bool                `gorm:"not null;index"`
+	MaxDosesPer24h   *int                `gorm:"column:max_doses_per_24h"`
+	MinIntervalMin   *int                `gorm:"column:min_dose_interval_minutes"`
+	StockQuantity    *float64            `gorm:"type:numeric(10,3)"`
+	RefillRemindedAt *time.Time
+	CreatedAt        time.Time `gorm:"not null"`
+	UpdatedAt        time.Time `gorm:"not null"`
 }
 
 // TableName возвращает имя таблицы
@@ -67,17 +126,34 @@ func (m *medicationModel) toDomain() *medication.Medication {
 	}
 
 	return &medication.Medication{
-		ID:              m.ID,
-		UserID:          m.UserID,
-		Name:            m.Name,
-		Dosage:          m.Dosage,
-		ScheduleType:    scheduleType,
-		ScheduleDetails: medication.ScheduleDetails(m.ScheduleDetails),
-		StartDate:       m.StartDate,
-		EndDate:         m.EndDate,
-		IsActive:        m.IsActive,
-		CreatedAt:       m.CreatedAt,
-		UpdatedAt:       m.UpdatedAt,
+		ID:               m.ID,
+		UserID:           m.UserID,
+		Name:             m.Name,
+		Dosage:           m.Dosage,
+		DosageDetails:    m.dosageDetails(),
+		ScheduleType:     scheduleType,
+		ScheduleDetails:  m.ScheduleDetails.toDomain(),
+		StartDate:        m.StartDate,
+		EndDate:          m.EndDate,
+		IsActive:         m.IsActive,
+		MaxDosesPer24h:   m.MaxDosesPer24h,
+		MinDoseInterval:  minutesToDuration(m.MinIntervalMin),
+		StockQuantity:    m.StockQuantity,
+		RefillRemindedAt: m.RefillRemindedAt,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+	}
+}
+
+// dosageDetails собирает структурированную дозу, если она заполнена
+func (m *medicationModel) dosageDetails() *medication.DosageDetails {
+	if m.DosageAmount == nil || m.DosageUnit == nil || m.DosageForm == nil {
+		return nil
+	}
+	return &medication.DosageDetails{
+		Amount: *m.DosageAmount,
+		Unit:   medication.DosageUnit(*m.DosageUnit),
+		Form:   medication.DosageForm(*m.DosageForm),
 	}
 }
 
@@ -87,11 +163,20 @@ func (m *medicationModel) fromDomain(med *medication.Medication) {
 	m.UserID = med.UserID
 	m.Name = med.Name
 	m.Dosage = med.Dosage
+	m.DosageAmount, m.DosageUnit, m.DosageForm = nil, nil, nil
+	if d := med.DosageDetails; d != nil {
+		amount, unit, form := d.Amount, string(d.Unit), string(d.Form)
+		m.DosageAmount, m.DosageUnit, m.DosageForm = &amount, &unit, &form
+	}
 	m.ScheduleType = string(med.ScheduleType)
-	m.ScheduleDetails = scheduleDetailsJSON(med.ScheduleDetails)
+	m.ScheduleDetails = newScheduleDetailsJSON(med.ScheduleDetails)
 	m.StartDate = med.StartDate
 	m.EndDate = med.EndDate
 	m.IsActive = med.IsActive
+	m.MaxDosesPer24h = med.MaxDosesPer24h
+	m.MinIntervalMin = durationToMinutes(med.MinDoseInterval)
+	m.StockQuantity = med.StockQuantity
+	m.RefillRemindedAt = med.RefillRemindedAt
 	m.CreatedAt = med.CreatedAt
 	m.UpdatedAt = med.UpdatedAt
 }
@@ -161,12 +246,80 @@ func (r *MedicationRepository) Update(ctx context.Context, med *medication.Medic
 	model := &medicationModel{}
 	model.fromDomain(med)
 
+	// Select("*") нужен, чтобы сохранять снятие ограничений и деактивацию;
+	// запас меняется только отдельными атомарными запросами
 	return r.db.WithContext(ctx).
 		Model(&medicationModel{}).
 		Where("id = ?", med.ID).
+		Select("*").
+		Omit("stock_quantity", "refill_reminded_at").
 		Updates(model).Error
 }
 
+// SetStock задаёт остаток и снимает отметку о напоминании о пополнении
+func (r *MedicationRepository) SetStock(ctx context.Context, id uuid.UUID, quantity *float64) error {
+	return r.db.WithContext(ctx).
+		Model(&medicationModel{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"stock_quantity":     quantity,
+			"refill_reminded_at": nil,
+			"updated_at":         time.Now(),
+		}).Error
+}
+
+// AdjustStock изменяет остаток одним UPDATE, поэтому параллельные отметки
+// приёмов списывают дозы по очереди, а не по устаревшему снимку
+func (r *MedicationRepository) AdjustStock(ctx context.Context, id uuid.UUID, delta float64) (*float64, error) {
+	return adjustStock(r.db.WithContext(ctx), id, delta)
+}
+
+// adjustStock изменяет остаток лекарства в db, в том числе внутри чужой транзакции
+func adjustStock(db *gorm.DB, id uuid.UUID, delta float64) (*float64, error) {
+	var model medicationModel
+	result := db.
+		Model(&model).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "stock_quantity"}}}).
+		Where("id = ? AND stock_quantity IS NOT NULL", id).
+		Updates(map[string]any{
+			"stock_quantity": gorm.Expr("GREATEST(stock_quantity + ?, 0)", delta),
+			"updated_at":     time.Now(),
+		})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
+	}
+	return model.StockQuantity, nil
+}
+
+// Refill пополняет запас одним UPDATE; лекарство без учёта запаса получает остаток quantity
+func (r *MedicationRepository) Refill(ctx context.Context, id uuid.UUID, quantity float64) (*float64, error) {
+	var model medicationModel
+	result := r.db.WithContext(ctx).
+		Model(&model).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "stock_quantity"}}}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"stock_quantity":     gorm.Expr("COALESCE(stock_quantity, 0) + ?", quantity),
+			"refill_reminded_at": nil,
+			"updated_at":         time.Now(),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, medication.ErrMedicationNotFound
+	}
+	return model.StockQuantity, nil
+}
+
+// MarkRefillReminded сохраняет только время напоминания о пополнении
+func (r *MedicationRepository) MarkRefillReminded(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&medicationModel{}).
+		Where("id = ?", id).
+		Update("refill_reminded_at", at).Error
+}
+
 // Delete удаляет лекарство
 func (r *MedicationRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.db.WithContext(ctx).
@@ -174,3 +327,37 @@ func (r *MedicationRepository) Delete(ctx context.Context, id uuid.UUID) error {
 		Delete(&medicationModel{}).Error
 }
 
+// FindActive возвращает активные лекарства всех пользователей
+func (r *MedicationRepository) FindActive(ctx context.Context) ([]*medication.Medication, error) {
+	var models []medicationModel
+	if err := r.db.WithContext(ctx).
+		Where("is_active = ?", true).
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	medications := make([]*medication.Medication, len(models))
+	for i := range models {
+		medications[i] = models[i].toDomain()
+	}
+
+	return medications, nil
+}
+
+// minutesToDuration преобразует количество минут из БД в time.Duration
+func minutesToDuration(minutes *int) *time.Duration {
+	if minutes == nil {
+		return nil
+	}
+	d := time.Duration(*minutes) * time.Minute
+	return &d
+}
+
+// durationToMinutes преобразует time.Duration в количество минут для БД
+func durationToMinutes(d *time.Duration) *int {
+	if d == nil {
+		return nil
+	}
+	minutes := int(*d / time.Minute)
+	return &minutes
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/engagement"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// milestoneModel представляет модель вехи в БД
+type milestoneModel struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_milestones_unique"`
+	Kind       string    `gorm:"type:varchar(30);not null;uniqueIndex:idx_milestones_unique"`
+	Days       int       `gorm:"not null;uniqueIndex:idx_milestones_unique"`
+	AchievedAt time.Time `gorm:"not null"`
+}
+
+// TableName возвращает имя таблицы
+func (milestoneModel) TableName() string {
+	return "milestones"
+}
+
+// toDomain преобразует модель БД в доменную сущность
+func (m *milestoneModel) toDomain() *engagement.Milestone {
+	return &engagement.Milestone{
+		ID:         m.ID,
+		UserID:     m.UserID,
+		Kind:       engagement.StreakKind(m.Kind),
+		Days:       m.Days,
+		AchievedAt: m.AchievedAt,
+	}
+}
+
+// fromDomain преобразует доменную сущность в модель БД
+func (m *milestoneModel) fromDomain(ms *engagement.Milestone) {
+	m.ID = ms.ID
+	m.UserID = ms.UserID
+	m.Kind = string(ms.Kind)
+	m.Days = ms.Days
+	m.AchievedAt = ms.AchievedAt
+}
+
+// MilestoneRepository реализует engagement.MilestoneRepository для PostgreSQL
+type MilestoneRepository struct {
+	db *gorm.DB
+}
+
+// NewMilestoneRepository создаёт новый репозиторий вех
+func NewMilestoneRepository(db *gorm.DB) engagement.MilestoneRepository {
+	return &MilestoneRepository{db: db}
+}
+
+// Record сохраняет веху, если она ещё не была отмечена
+func (r *MilestoneRepository) Record(ctx context.Context, milestone *engagement.Milestone) (bool, error) {
+	model := &milestoneModel{}
+	model.fromDomain(milestone)
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(model)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// FindByUserID возвращает вехи пользователя
+func (r *MilestoneRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*engagement.Milestone, error) {
+	var models []milestoneModel
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("achieved_at DESC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	milestones := make([]*engagement.Milestone, len(models))
+	for i := range models {
+		milestones[i] = models[i].toDomain()
+	}
+
+	return milestones, nil
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/infrastructure/encryption"
+	"gorm.io/gorm"
+)
+
+// reencryptBatchSize — сколько строк колонки перешифровывается за один запрос
+const reencryptBatchSize = 500
+
+// encryptedColumn описывает зашифрованную колонку и владельца её строк
+type encryptedColumn struct {
+	field  string
+	table  string
+	column string
+	// owner — выражение SQL с ID профиля, чьим ключом шифруется строка
+	owner string
+	join  string
+}
+
+// encryptedColumns — все колонки, которые шифруются ключами данных профилей
+var encryptedColumns = []encryptedColumn{
+	{field: symptomDescriptionField, table: "symptom_entries", column: "description", owner: "symptom_entries.user_id"},
+	{field: visitQuestionsField, table: "doctor_visits", column: "questions", owner: "doctor_visits.user_id"},
+	{
+		field:  intakeNotesField,
+		table:  "medication_intakes",
+		column: "notes",
+		owner:  "medications.user_id",
+		join:   "JOIN medications ON medications.id = medication_intakes.medication_id",
+	},
+	{
+		field:  intakeSkipReasonField,
+		table:  "medication_intakes",
+		column: "skip_reason",
+		owner:  "medications.user_id",
+		join:   "JOIN medications ON medications.id = medication_intakes.medication_id",
+	},
+	{
+		field:  intakeReasonField,
+		table:  "medication_intakes",
+		column: "reason",
+		owner:  "medications.user_id",
+		join:   "JOIN medications ON medications.id = medication_intakes.medication_id",
+	},
+}
+
+// Reencryptor перешифровывает сохранённые данные после включения шифрования
+// или ротации ключей
+type Reencryptor struct {
+	db     *gorm.DB
+	cipher *encryption.FieldCipher
+}
+
+// NewReencryptor создаёт перешифровщик данных
+func NewReencryptor(db *gorm.DB, cipher *encryption.FieldCipher) *Reencryptor {
+	return &Reencryptor{db: db, cipher: cipher}
+}
+
+// ReencryptColumns шифрует действующими ключами профилей значения, которые
+// хранятся открытыми или зашифрованы выведенными из оборота ключами.
+// Возвращает число перезаписанных значений.
+func (r *Reencryptor) ReencryptColumns(ctx context.Context) (int, error) {
+	activeKeys := make(map[uuid.UUID]uuid.UUID)
+	total := 0
+	for _, col := range encryptedColumns {
+		n, err := r.reencryptColumn(ctx, col, activeKeys)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// reencryptColumn перешифровывает одну колонку, проходя строки по возрастанию ID
+func (r *Reencryptor) reencryptColumn(ctx context.Context, col encryptedColumn, activeKeys map[uuid.UUID]uuid.UUID) (int, error) {
+	var rows []struct {
+		ID     uuid.UUID `gorm:"column:id"`
+		Value  string    `gorm:"column:value"`
+		UserID uuid.UUID `gorm:"column:user_id"`
+	}
+
+	updated := 0
+	after := uuid.Nil
+	for {
+		query := r.db.WithContext(ctx).
+			Table(col.table).
+			Select(col.table+".id AS id, "+col.table+"."+col.column+" AS value, "+col.owner+" AS user_id").
+			Where(col.table+"."+col.column+" IS NOT NULL AND "+col.table+".id > ?", after).
+			Order(col.table + ".id ASC").
+			Limit(reencryptBatchSize)
+		if col.join != "" {
+			query = query.Joins(col.join)
+		}
+		rows = rows[:0]
+		if err := query.Scan(&rows).Error; err != nil {
+			return updated, err
+		}
+		if len(rows) == 0 {
+			return updated, nil
+		}
+
+		for _, row := range rows {
+			after = row.ID
+
+			activeKey, ok := activeKeys[row.UserID]
+			if !ok {
+				var err error
+				if activeKey, err = r.cipher.ActiveKeyID(ctx, row.UserID); err != nil {
+					return updated, err
+				}
+				activeKeys[row.UserID] = activeKey
+			}
+			if keyID, ok := encryption.KeyID(row.Value); ok && keyID == activeKey {
+				continue
+			}
+
+			plain, err := r.cipher.Decrypt(ctx, col.field, row.Value)
+			if err != nil {
+				return updated, err
+			}
+			encrypted, err := r.cipher.Encrypt(ctx, row.UserID, col.field, plain)
+			if err != nil {
+				return updated, err
+			}
+
+			// Условие на прежнее значение не даёт затереть правку, сделанную во время прохода
+			result := r.db.WithContext(ctx).
+				Table(col.table).
+				Where("id = ? AND "+col.column+" = ?", row.ID, row.Value).
+				Update(col.column, encrypted)
+			if result.Error != nil {
+				return updated, result.Error
+			}
+			updated += int(result.RowsAffected)
+		}
+	}
+}
+
+// IsKeyReferenced проверяет, что ключом данных зашифровано хотя бы одно значение
+func (r *Reencryptor) IsKeyReferenced(ctx context.Context, keyID uuid.UUID) (bool, error) {
+	pattern := escapeLike(encryption.KeyPrefix(keyID)) + "%"
+	for _, col := range encryptedColumns {
+		var exists bool
+		if err := r.db.WithContext(ctx).
+			Raw("SELECT EXISTS (SELECT 1 FROM "+col.table+" WHERE "+col.column+" LIKE ?)", pattern).
+			Scan(&exists).Error; err != nil {
+			return false, err
+		}
+		if exists {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// StoredFileURLs возвращает расположение всех файлов в хранилище:
+// фото симптомов, файлов анализов и архивов экспорта
+func (r *Reencryptor) StoredFileURLs(ctx context.Context) ([]string, error) {
+	var urls []string
+	err := r.db.WithContext(ctx).
+		Raw(`SELECT photo_url FROM symptom_entries WHERE photo_url IS NOT NULL
+			UNION SELECT file_url FROM analyses
+			UNION SELECT archive_url FROM export_jobs WHERE archive_url IS NOT NULL`).
+		Scan(&urls).Error
+	return urls, err
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/reminder"
+	"gorm.io/gorm"
+)
+
+// reminderModel представляет модель напоминания в БД
+type reminderModel struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Type           string     `gorm:"type:varchar(20);not null;index"`
+	RelatedID      *uuid.UUID `gorm:"type:uuid"`
+	ScheduledTime  time.Time  `gorm:"not null;index"`
+	PostponedUntil *time.Time
+	Message        string `gorm:"type:text;not null"`
+	IsSent         bool   `gorm:"not null;default:false;index"`
+	SentAt         *time.Time
+	CreatedAt      time.Time `gorm:"not null"`
+}
+
+// TableName возвращает имя таблицы
+func (reminderModel) TableName() string {
+	return "reminders"
+}
+
+// toDomain преобразует модель БД в доменную сущность
+func (m *reminderModel) toDomain() *reminder.Reminder {
+	return &reminder.Reminder{
+		ID:             m.ID,
+		UserID:         m.UserID,
+		Type:           reminder.Type(m.Type),
+		RelatedID:      m.RelatedID,
+		ScheduledTime:  m.ScheduledTime,
+		PostponedUntil: m.PostponedUntil,
+		Message:        m.Message,
+		IsSent:         m.IsSent,
+		SentAt:         m.SentAt,
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+// fromDomain преобразует доменную сущность в модель БД
+func (m *reminderModel) fromDomain(r *reminder.Reminder) {
+	m.ID = r.ID
+	m.UserID = r.UserID
+	m.Type = string(r.Type)
+	m.RelatedID = r.RelatedID
+	m.ScheduledTime = r.ScheduledTime
+	m.PostponedUntil = r.PostponedUntil
+	m.Message = r.Message
+	m.IsSent = r.IsSent
+	m.SentAt = r.SentAt
+	m.CreatedAt = r.CreatedAt
+}
+
+// ReminderRepository реализует reminder.Repository для PostgreSQL
+type ReminderRepository struct {
+	db *gorm.DB
+}
+
+// NewReminderRepository создаёт новый репозиторий напоминаний
+func NewReminderRepository(db *gorm.DB) reminder.Repository {
+	return &ReminderRepository{db: db}
+}
+
+// Create создаёт новое напоминание
+func (r *ReminderRepository) Create(ctx context.Context, rem *reminder.Reminder) error {
+	model := &reminderModel{}
+	model.fromDomain(rem)
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+
+	*rem = *model.toDomain()
+	return nil
+}
+
+// GetByID возвращает напоминание по ID
+func (r *ReminderRepository) GetByID(ctx context.Context, id uuid.UUID) (*reminder.Reminder, error) {
+	var model reminderModel
+	if err := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, reminder.ErrReminderNotFound
+		}
+		return nil, err
+	}
+
+	return model.toDomain(), nil
+}
+
+// Update обновляет напоминание
+func (r *ReminderRepository) Update(ctx context.Context, rem *reminder.Reminder) error {
+	model := &reminderModel{}
+	model.fromDomain(rem)
+
+	// Select("*") нужен, чтобы сохранять нулевые значения (например, is_sent = false при переносе)
+	return r.db.WithContext(ctx).
+		Model(&reminderModel{}).
+		Where("id = ?", rem.ID).
+		Select("*").
+		Updates(model).Error
+}
+
+// Delete удаляет напоминание
+func (r *ReminderRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Where("id = ?", id).
+		Delete(&reminderModel{}).Error
+}
+
+// Exists проверяет, запланировано ли уже такое напоминание
+func (r *ReminderRepository) Exists(ctx context.Context, userID uuid.UUID, reminderType reminder.Type, relatedID *uuid.UUID, scheduledTime time.Time) (bool, error) {
+	query := r.db.WithContext(ctx).Model(&reminderModel{}).
+		Where("user_id = ? AND type = ? AND scheduled_time = ?", userID, string(reminderType), scheduledTime)
+
+	if relatedID != nil {
+		query = query.Where("related_id = ?", *relatedID)
+	} else {
+		query = query.Where("related_id IS NULL")
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// FindDue возвращает неотправленные напоминания, время доставки которых наступило
+func (r *ReminderRepository) FindDue(ctx context.Context, before time.Time, limit int) ([]*reminder.Reminder, error) {
+	var models []reminderModel
+	if err := r.db.WithContext(ctx).
+		Where("is_sent = ? AND COALESCE(postponed_until, scheduled_time) <= ?", false, before).
+		Order("COALESCE(postponed_until, scheduled_time) ASC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	reminders := make([]*reminder.Reminder, len(models))
+	for i := range models {
+		reminders[i] = models[i].toDomain()
+	}
+
+	return reminders, nil
+}
+
+// FindPendingByType возвращает все неотправленные напоминания указанного типа
+func (r *ReminderRepository) FindPendingByType(ctx context.Context, reminderType reminder.Type) ([]*reminder.Reminder, error) {
+	var models []reminderModel
+	if err := r.db.WithContext(ctx).
+		Where("is_sent = ? AND type = ?", false, string(reminderType)).
+		Order("scheduled_time ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	reminders := make([]*reminder.Reminder, len(models))
+	for i := range models {
+		reminders[i] = models[i].toDomain()
+	}
+
+	return reminders, nil
+}
+
+// FindPendingByRelated возвращает неотправленные напоминания, связанные с сущностью
+func (r *ReminderRepository) FindPendingByRelated(ctx context.Context, reminderType reminder.Type, relatedID uuid.UUID) ([]*reminder.Reminder, error) {
+	var models []reminderModel
+	if err := r.db.WithContext(ctx).
+		Where("is_sent = ? AND type = ? AND related_id = ?", false, string(reminderType), relatedID).
+		Order("scheduled_time ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	reminders := make([]*reminder.Reminder, len(models))
+	for i := range models {
+		reminders[i] = models[i].toDomain()
+	}
+
+	return reminders, nil
+}
+
+// DeletePendingByRelated удаляет неотправленные напоминания, связанные с сущностью
+func (r *ReminderRepository) DeletePendingByRelated(ctx context.Context, reminderType reminder.Type, relatedID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Where("is_sent = ? AND type = ? AND related_id = ?", false, string(reminderType), relatedID).
+		Delete(&reminderModel{}).Error
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/sharing"
+	"gorm.io/gorm"
+)
+
+// reportLinkModel представляет модель ссылки на отчёт в БД
+type reportLinkModel struct {
+	ID                uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	OwnerID           uuid.UUID `gorm:"type:uuid;not null;index"`
+	VisitID           uuid.UUID `gorm:"type:uuid;not null;index"`
+	TokenHash         string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	PINHash           *string   `gorm:"column:pin_hash;type:varchar(64)"`
+	PeriodStart       time.Time `gorm:"not null"`
+	PeriodEnd         time.Time `gorm:"not null"`
+	MaxViews          int       `gorm:"not null"`
+	Views             int       `gorm:"not null;default:0"`
+	FailedPINAttempts int       `gorm:"column:failed_pin_attempts;not null;default:0"`
+	ExpiresAt         time.Time `gorm:"not null"`
+	RevokedAt         *time.Time
+	CreatedAt         time.Time `gorm:"not null"`
+}
+
+// TableName возвращает имя таблицы
+func (reportLinkModel) TableName() string {
+	return "report_links"
+}
+
+// toDomain преобразует модель БД в доменную сущность
+func (m *reportLinkModel) toDomain() *sharing.ReportLink {
+	return &sharing.ReportLink{
+		ID:                m.ID,
+		OwnerID:           m.OwnerID,
+		VisitID:           m.VisitID,
+		TokenHash:         m.TokenHash,
+		PINHash:           m.PINHash,
+		PeriodStart:       m.PeriodStart,
+		PeriodEnd:         m.PeriodEnd,
+		MaxViews:          m.MaxViews,
+		Views:             m.Views,
+		FailedPINAttempts: m.FailedPINAttempts,
+		ExpiresAt:         m.ExpiresAt,
+		RevokedAt:         m.RevokedAt,
+		CreatedAt:         m.CreatedAt,
+	}
+}
+
+// fromDomain преобразует доменную сущность в модель БД
+func (m *reportLinkModel) fromDomain(link *sharing.ReportLink) {
+	m.ID = link.ID
+	m.OwnerID = link.OwnerID
+	m.VisitID = link.VisitID
+	m.TokenHash = link.TokenHash
+	m.PINHash = link.PINHash
+	m.PeriodStart = link.PeriodStart
+	m.PeriodEnd = link.PeriodEnd
+	m.MaxViews = link.MaxViews
+	m.Views = link.Views
+	m.FailedPINAttempts = link.FailedPINAttempts
+	m.ExpiresAt = link.ExpiresAt
+	m.RevokedAt = link.RevokedAt
+	m.CreatedAt = link.CreatedAt
+}
+
+// ReportLinkRepository реализует sharing.ReportLinkRepository для PostgreSQL
+type ReportLinkRepository struct {
+	db *gorm.DB
+}
+
+// NewReportLinkRepository создаёт новый репозиторий ссылок на отчёт
+func NewReportLinkRepository(db *gorm.DB) sharing.ReportLinkRepository {
+	return &ReportLinkRepository{db: db}
+}
+
+// Create создаёт новую ссылку
+func (r *ReportLinkRepository) Create(ctx context.Context, link *sharing.ReportLink) error {
+	model := &reportLinkModel{}
+	model.fromDomain(link)
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+
+	*link = *model.toDomain()
+	return nil
+}
+
+// GetByID возвращает ссылку по ID
+func (r *ReportLinkRepository) GetByID(ctx context.Context, id uuid.UUID) (*sharing.ReportLink, error) {
+	return r.first(ctx, "id = ?", id)
+}
+
+// GetByTokenHash возвращает ссылку по хэшу токена
+func (r *ReportLinkRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*sharing.ReportLink, error) {
+	return r.first(ctx, "token_hash = ?", tokenHash)
+}
+
+// FindByVisitID возвращает ссылки на отчёт к визиту, новые первыми
+func (r *ReportLinkRepository) FindByVisitID(ctx context.Context, visitID uuid.UUID) ([]*sharing.ReportLink, error) {
+	var models []reportLinkModel
+	if err := r.db.WithContext(ctx).
+		Where("visit_id = ?", visitID).
+		Order("created_at DESC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	links := make([]*sharing.ReportLink, len(models))
+	for i := range models {
+		links[i] = models[i].toDomain()
+	}
+
+	return links, nil
+}
+
+// Revoke сохраняет отзыв ссылки. Обновляется только revoked_at, чтобы не откатить
+// параллельно учтённые просмотры и неверные PIN.
+func (r *ReportLinkRepository) Revoke(ctx context.Context, link *sharing.ReportLink) error {
+	return r.db.WithContext(ctx).
+		Model(&reportLinkModel{}).
+		Where("id = ? AND revoked_at IS NULL", link.ID).
+		Update("revoked_at", link.RevokedAt).Error
+}
+
+// RegisterView атомарно учитывает просмотр, если ссылка ещё действует.
+// Условие в UPDATE не даёт параллельным запросам превысить лимит просмотров.
+func (r *ReportLinkRepository) RegisterView(ctx context.Context, id uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&reportLinkModel{}).
+		Where("id = ? AND views < max_views AND failed_pin_attempts < ? AND revoked_at IS NULL AND expires_at > ?",
+			id, sharing.MaxPINAttempts, time.Now()).
+		Update("views", gorm.Expr("views + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// ReservePINAttempt атомарно учитывает попытку ввода PIN. Условие в UPDATE
+// не даёт параллельным запросам проверить больше MaxPINAttempts PIN.
+func (r *ReportLinkRepository) ReservePINAttempt(ctx context.Context, id uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&reportLinkModel{}).
+		Where("id = ? AND failed_pin_attempts < ?", id, sharing.MaxPINAttempts).
+		Update("failed_pin_attempts", gorm.Expr("failed_pin_attempts + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// ReleasePINAttempt снимает учёт попытки с верным PIN
+func (r *ReportLinkRepository) ReleasePINAttempt(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&reportLinkModel{}).
+		Where("id = ? AND failed_pin_attempts > 0", id).
+		Update("failed_pin_attempts", gorm.Expr("failed_pin_attempts - 1")).Error
+}
+
+// first возвращает одну ссылку по условию
+func (r *ReportLinkRepository) first(ctx context.Context, query string, args ...interface{}) (*sharing.ReportLink, error) {
+	var model reportLinkModel
+	if err := r.db.WithContext(ctx).
+		Where(query, args...).
+		First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, sharing.ErrReportLinkNotFound
+		}
+		return nil, err
+	}
+
+	return model.toDomain(), nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/sharing"
+	"gorm.io/gorm"
+)
+
+// shareAccessLogModel представляет модель записи журнала обращений в БД
+type shareAccessLogModel struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	GrantID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	OwnerID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	GranteeUserID *uuid.UUID `gorm:"type:uuid"`
+	Field         string     `gorm:"type:varchar(100);not null"`
+	AccessedAt    time.Time  `gorm:"not null"`
+}
+
+// TableName возвращает имя таблицы
+func (shareAccessLogModel) TableName() string {
+	return "share_access_log"
+}
+
+// toDomain преобразует модель БД в доменную сущность
+func (m *shareAccessLogModel) toDomain() *sharing.AccessLogEntry {
+	return &sharing.AccessLogEntry{
+		ID:            m.ID,
+		GrantID:       m.GrantID,
+		OwnerID:       m.OwnerID,
+		GranteeUserID: m.GranteeUserID,
+		Field:         m.Field,
+		AccessedAt:    m.AccessedAt,
+	}
+}
+
+// fromDomain преобразует доменную сущность в модель БД
+func (m *shareAccessLogModel) fromDomain(entry *sharing.AccessLogEntry) {
+	m.ID = entry.ID
+	m.GrantID = entry.GrantID
+	m.OwnerID = entry.OwnerID
+	m.GranteeUserID = entry.GranteeUserID
+	m.Field = entry.Field
+	m.AccessedAt = entry.AccessedAt
+}
+
+// ShareAccessLogRepository реализует sharing.AccessLogRepository для PostgreSQL
+type ShareAccessLogRepository struct {
+	db *gorm.DB
+}
+
+// NewShareAccessLogRepository создаёт новый репозиторий журнала обращений
+func NewShareAccessLogRepository(db *gorm.DB) sharing.AccessLogRepository {
+	return &ShareAccessLogRepository{db: db}
+}
+
+// Create добавляет запись в журнал
+func (r *ShareAccessLogRepository) Create(ctx context.Context, entry *sharing.AccessLogEntry) error {
+	model := &shareAccessLogModel{}
+	model.fromDomain(entry)
+
+	return r.db.WithContext(ctx).Create(model).Error
+}
+
+// FindByOwnerID возвращает последние обращения к данным профиля
+func (r *ShareAccessLogRepository) FindByOwnerID(ctx context.Context, ownerID uuid.UUID, grantID *uuid.UUID, limit int) ([]*sharing.AccessLogEntry, error) {
+	query := r.db.WithContext(ctx).Where("owner_id = ?", ownerID)
+	if grantID != nil {
+		query = query.Where("grant_id = ?", *grantID)
+	}
+
+	var models []shareAccessLogModel
+	if err := query.
+		Order("accessed_at DESC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	entries := make([]*sharing.AccessLogEntry, len(models))
+	for i := range models {
+		entries[i] = models[i].toDomain()
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/sharing"
+	"gorm.io/gorm"
+)
+
+// shareScopesJSON представляет JSON для scopes
+type shareScopesJSON []string
+
+// Value реализует driver.Valuer для GORM
+func (s shareScopesJSON) Value() (driver.Value, error) {
+	if s == nil {
+		return json.Marshal([]string{})
+	}
+	return json.Marshal([]string(s))
+}
+
+// Scan реализует sql.Scanner для GORM
+func (s *shareScopesJSON) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(bytes, s)
+}
+
+// shareGrantModel представляет модель выданного доступа в БД
+type shareGrantModel struct {
+	ID                    uuid.UUID       `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	OwnerID               uuid.UUID       `gorm:"type:uuid;not null;index"`
+	GranteeTelegramUserID *int64          `gorm:"index"`
+	TokenHash             *string         `gorm:"type:varchar(64);uniqueIndex"`
+	Scopes                shareScopesJSON `gorm:"type:jsonb;not null"`
+	DataStartDate         *time.Time      `gorm:"type:date"`
+	DataEndDate           *time.Time      `gorm:"type:date"`
+	ExpiresAt             time.Time       `gorm:"not null"`
+	RevokedAt             *time.Time
+	CreatedAt             time.Time `gorm:"not null"`
+}
+
+// TableName возвращает имя таблицы
+func (shareGrantModel) TableName() string {
+	return "share_grants"
+}
+
+// toDomain преобразует модель БД в доменную сущность
+func (m *shareGrantModel) toDomain() *sharing.Grant {
+	scopes := make([]sharing.Scope, len(m.Scopes))
+	for i, scope := range m.Scopes {
+		scopes[i] = sharing.Scope(scope)
+	}
+	return &sharing.Grant{
+		ID:                    m.ID,
+		OwnerID:               m.OwnerID,
+		GranteeTelegramUserID: m.GranteeTelegramUserID,
+		TokenHash:             m.TokenHash,
+		Scopes:                scopes,
+		DataStartDate:         m.DataStartDate,
+		DataEndDate:           m.DataEndDate,
+		ExpiresAt:             m.ExpiresAt,
+		RevokedAt:             m.RevokedAt,
+		CreatedAt:             m.CreatedAt,
+	}
+}
+
+// fromDomain преобразует доменную сущность в модель БД
+func (m *shareGrantModel) fromDomain(grant *sharing.Grant) {
+	m.ID = grant.ID
+	m.OwnerID = grant.OwnerID
+	m.GranteeTelegramUserID = grant.GranteeTelegramUserID
+	m.TokenHash = grant.TokenHash
+	m.Scopes = make(shareScopesJSON, len(grant.Scopes))
+	for i, scope := range grant.Scopes {
+		m.Scopes[i] = string(scope)
+	}
+	m.DataStartDate = grant.DataStartDate
+	m.DataEndDate = grant.DataEndDate
+	m.ExpiresAt = grant.ExpiresAt
+	m.RevokedAt = grant.RevokedAt
+	m.CreatedAt = grant.CreatedAt
+}
+
+// ShareGrantRepository реализует sharing.GrantRepository для PostgreSQL
+type ShareGrantRepository struct {
+	db *gorm.DB
+}
+
+// NewShareGrantRepository создаёт новый репозиторий выданных доступов
+func NewShareGrantRepository(db *gorm.DB) sharing.GrantRepository {
+	return &ShareGrantRepository{db: db}
+}
+
+// Create создаёт новый доступ
+func (r *ShareGrantRepository) Create(ctx context.Context, grant *sharing.Grant) error {
+	model := &shareGrantModel{}
+	model.fromDomain(grant)
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+
+	*grant = *model.toDomain()
+	return nil
+}
+
+// GetByID возвращает доступ по ID
+func (r *ShareGrantRepository) GetByID(ctx context.Context, id uuid.UUID) (*sharing.Grant, error) {
+	return r.first(ctx, "id = ?", id)
+}
+
+// GetByTokenHash возвращает доступ по хэшу токена ссылки
+func (r *ShareGrantRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*sharing.Grant, error) {
+	return r.first(ctx, "token_hash = ?", tokenHash)
+}
+
+// FindByOwnerID возвращает все доступы к данным профиля, новые первыми
+func (r *ShareGrantRepository) FindByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*sharing.Grant, error) {
+	return r.find(ctx, "owner_id = ?", ownerID)
+}
+
+// FindByGrantee возвращает доступы, выданные пользователю Telegram, новые первыми
+func (r *ShareGrantRepository) FindByGrantee(ctx context.Context, telegramUserID int64) ([]*sharing.Grant, error) {
+	return r.find(ctx, "grantee_telegram_user_id = ?", telegramUserID)
+}
+
+// Update обновляет доступ
+func (r *ShareGrantRepository) Update(ctx context.Context, grant *sharing.Grant) error {
+	model := &shareGrantModel{}
+	model.fromDomain(grant)
+
+	return r.db.WithContext(ctx).
+		Model(&shareGrantModel{}).
+		Where("id = ?", grant.ID).
+		Select("*").
+		Updates(model).Error
+}
+
+// first возвращает один доступ по условию
+func (r *ShareGrantRepository) first(ctx context.Context, query string, args ...interface{}) (*sharing.Grant, error) {
+	var model shareGrantModel
+	if err := r.db.WithContext(ctx).
+		Where(query, args...).
+		First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, sharing.ErrGrantNotFound
+		}
+		return nil, err
+	}
+
+	return model.toDomain(), nil
+}
+
+// find возвращает доступы по условию, новые первыми
+func (r *ShareGrantRepository) find(ctx context.Context, query string, args ...interface{}) ([]*sharing.Grant, error) {
+	var models []shareGrantModel
+	if err := r.db.WithContext(ctx).
+		Where(query, args...).
+		Order("created_at DESC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	grants := make([]*sharing.Grant, len(models))
+	for i := range models {
+		grants[i] = models[i].toDomain()
+	}
+
+	return grants, nil
+}
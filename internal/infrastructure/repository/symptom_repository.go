@@ -2,10 +2,13 @@ package repository
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/health-hub-bot-api/internal/domain/symptom"
+	"github.com/health-hub-bot-api/internal/infrastructure/encryption"
 	"gorm.io/gorm"
 )
 
@@ -64,25 +67,43 @@ func (m *symptomModel) fromDomain(s *symptom.SymptomEntry) {
 	m.UpdatedAt = s.UpdatedAt
 }
 
+// encrypt шифрует чувствительные колонки записи
+func (m *symptomModel) encrypt(ctx context.Context, c *encryption.FieldCipher) error {
+	return encryptField(ctx, c, m.UserID, symptomDescriptionField, &m.Description)
+}
+
+// decrypt расшифровывает чувствительные колонки записи
+func (m *symptomModel) decrypt(ctx context.Context, c *encryption.FieldCipher) error {
+	return decryptField(ctx, c, symptomDescriptionField, &m.Description)
+}
+
 // SymptomRepository реализует symptom.Repository для PostgreSQL
 type SymptomRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	cipher *encryption.FieldCipher
 }
 
-// NewSymptomRepository создаёт новый репозиторий симптомов
-func NewSymptomRepository(db *gorm.DB) symptom.Repository {
-	return &SymptomRepository{db: db}
+// NewSymptomRepository создаёт новый репозиторий симптомов.
+// Описания шифруются cipher; nil хранит их открытыми.
+func NewSymptomRepository(db *gorm.DB, cipher *encryption.FieldCipher) symptom.Repository {
+	return &SymptomRepository{db: db, cipher: cipher}
 }
 
 // Create создаёт новую запись симптома
 func (r *SymptomRepository) Create(ctx context.Context, entry *symptom.SymptomEntry) error {
 	model := &symptomModel{}
 	model.fromDomain(entry)
+	if err := model.encrypt(ctx, r.cipher); err != nil {
+		return err
+	}
 
 	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
 		return err
 	}
 
+	if err := model.decrypt(ctx, r.cipher); err != nil {
+		return err
+	}
 	*entry = *model.toDomain()
 	return nil
 }
@@ -99,6 +120,9 @@ func (r *SymptomRepository) GetByID(ctx context.Context, id uuid.UUID) (*symptom
 		return nil, err
 	}
 
+	if err := model.decrypt(ctx, r.cipher); err != nil {
+		return nil, err
+	}
 	return model.toDomain(), nil
 }
 
@@ -138,6 +162,9 @@ func (r *SymptomRepository) FindByFilter(ctx context.Context, filter symptom.Fil
 
 	entries := make([]*symptom.SymptomEntry, len(models))
 	for i := range models {
+		if err := models[i].decrypt(ctx, r.cipher); err != nil {
+			return nil, 0, err
+		}
 		entries[i] = models[i].toDomain()
 	}
 
@@ -148,6 +175,9 @@ func (r *SymptomRepository) FindByFilter(ctx context.Context, filter symptom.Fil
 func (r *SymptomRepository) Update(ctx context.Context, entry *symptom.SymptomEntry) error {
 	model := &symptomModel{}
 	model.fromDomain(entry)
+	if err := model.encrypt(ctx, r.cipher); err != nil {
+		return err
+	}
 
 	return r.db.WithContext(ctx).
 		Model(&symptomModel{}).
@@ -191,3 +221,171 @@ func (r *SymptomRepository) GetWellbeingTrend(ctx context.Context, userID uuid.U
 	return points, nil
 }
 
+
+// GetDailyWellbeing возвращает среднее самочувствие по дням за период.
+// date_time хранится без часового пояса во времени сессии, поэтому перед
+// выделением дня оно переводится в часовой пояс пользователя.
+func (r *SymptomRepository) GetDailyWellbeing(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time, loc *time.Location) ([]symptom.DailyWellbeing, error) {
+	var results []struct {
+		Date         time.Time `gorm:"column:date"`
+		Average      float64   `gorm:"column:average"`
+		EntriesCount int       `gorm:"column:entries_count"`
+	}
+
+	err := r.db.WithContext(ctx).
+		Model(&symptomModel{}).
+		Select("DATE(CAST(date_time AS timestamptz) AT TIME ZONE ?) as date, AVG(wellbeing_scale) as average, COUNT(*) as entries_count", loc.String()).
+		Where("user_id = ? AND date_time >= ? AND date_time <= ?", userID, startDate, endDate).
+		Group("date").
+		Order("date ASC").
+		Find(&results).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	days := make([]symptom.DailyWellbeing, len(results))
+	for i, res := range results {
+		days[i] = symptom.DailyWellbeing{
+			Date:         time.Date(res.Date.Year(), res.Date.Month(), res.Date.Day(), 0, 0, 0, 0, loc),
+			Average:      res.Average,
+			EntriesCount: res.EntriesCount,
+		}
+	}
+
+	return days, nil
+}
+
+// GetEntryTimes возвращает время всех записей пользователя начиная с since
+func (r *SymptomRepository) GetEntryTimes(ctx context.Context, userID uuid.UUID, since time.Time) ([]time.Time, error) {
+	var times []time.Time
+	if err := r.db.WithContext(ctx).
+		Model(&symptomModel{}).
+		Where("user_id = ? AND date_time >= ?", userID, since).
+		Order("date_time ASC").
+		Pluck("date_time", &times).Error; err != nil {
+		return nil, err
+	}
+
+	return times, nil
+}
+
+// suggestionSimilarityThreshold — минимальная триграммная схожесть для подсказки
+const suggestionSimilarityThreshold = 0.3
+
+// GetSuggestions возвращает похожие описания из прошлых записей пользователя.
+// Совпадения по префиксу идут первыми, затем по частоте и давности использования.
+func (r *SymptomRepository) GetSuggestions(ctx context.Context, userID uuid.UUID, prefix string, startDate, endDate *time.Time, limit int) ([]*symptom.SymptomSuggestion, error) {
+	prefix = strings.TrimSpace(prefix)
+	if r.cipher.Enabled() {
+		return r.getRecentSuggestions(ctx, userID, prefix, startDate, endDate, limit)
+	}
+
+	var results []struct {
+		Description string    `gorm:"column:description"`
+		Count       int       `gorm:"column:count"`
+		LastUsedAt  time.Time `gorm:"column:last_used_at"`
+	}
+
+	likePattern := escapeLike(prefix) + "%"
+
+	err := r.db.WithContext(ctx).
+		Raw(`SELECT description, COUNT(*) AS count, MAX(date_time) AS last_used_at
+			FROM symptom_entries
+			WHERE user_id = ? AND (description ILIKE ? OR similarity(description, ?) >= ?)
+				AND (CAST(? AS timestamptz) IS NULL OR date_time >= ?)
+				AND (CAST(? AS timestamptz) IS NULL OR date_time <= ?)
+			GROUP BY description
+			ORDER BY (description ILIKE ?) DESC, count DESC, last_used_at DESC
+			LIMIT ?`,
+			userID, likePattern, prefix, suggestionSimilarityThreshold,
+			startDate, startDate, endDate, endDate, likePattern, limit).
+		Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]*symptom.SymptomSuggestion, len(results))
+	for i, res := range results {
+		suggestions[i] = &symptom.SymptomSuggestion{
+			Description: res.Description,
+			Count:       res.Count,
+			LastUsedAt:  res.LastUsedAt,
+		}
+	}
+
+	return suggestions, nil
+}
+
+// suggestionScanLimit — сколько последних записей просматривается для подсказок,
+// когда описания зашифрованы
+const suggestionScanLimit = 1000
+
+// getRecentSuggestions подбирает подсказки по последним записям, когда описания
+// зашифрованы и не могут сравниваться в БД. Триграммная схожесть недоступна,
+// поэтому вместо неё засчитывается вхождение подстроки без учёта регистра.
+func (r *SymptomRepository) getRecentSuggestions(ctx context.Context, userID uuid.UUID, prefix string, startDate, endDate *time.Time, limit int) ([]*symptom.SymptomSuggestion, error) {
+	query := r.db.WithContext(ctx).
+		Select("description, date_time").
+		Where("user_id = ?", userID)
+	if startDate != nil {
+		query = query.Where("date_time >= ?", *startDate)
+	}
+	if endDate != nil {
+		query = query.Where("date_time <= ?", *endDate)
+	}
+
+	var models []symptomModel
+	if err := query.
+		Order("date_time DESC").
+		Limit(suggestionScanLimit).
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	needle := strings.ToLower(prefix)
+	byDescription := make(map[string]*symptom.SymptomSuggestion)
+	var suggestions []*symptom.SymptomSuggestion
+	for i := range models {
+		if err := models[i].decrypt(ctx, r.cipher); err != nil {
+			return nil, err
+		}
+		description := models[i].Description
+		if !strings.Contains(strings.ToLower(description), needle) {
+			continue
+		}
+
+		suggestion, ok := byDescription[description]
+		if !ok {
+			// Записи идут от новых к старым, поэтому первая встреча — последнее использование
+			suggestion = &symptom.SymptomSuggestion{Description: description, LastUsedAt: models[i].DateTime}
+			byDescription[description] = suggestion
+			suggestions = append(suggestions, suggestion)
+		}
+		suggestion.Count++
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		aPrefix := strings.HasPrefix(strings.ToLower(a.Description), needle)
+		bPrefix := strings.HasPrefix(strings.ToLower(b.Description), needle)
+		if aPrefix != bPrefix {
+			return aPrefix
+		}
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.LastUsedAt.After(b.LastUsedAt)
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	return suggestions, nil
+}
+
+// escapeLike экранирует спецсимволы шаблона LIKE
+func escapeLike(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(s)
+}
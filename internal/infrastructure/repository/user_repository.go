@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"database/sql/driver"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,13 +11,126 @@ import (
 	"gorm.io/gorm"
 )
 
+// notificationPreferencesJSON представляет JSON для notification_preferences
+type notificationPreferencesJSON struct {
+	CheckInTimes    []string `json:"check_in_times"`
+	Channels        []string `json:"channels"`
+	QuietHoursStart *string  `json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd   *string  `json:"quiet_hours_end,omitempty"`
+	Days            []int    `json:"days"`
+}
+
+// Value реализует driver.Valuer для GORM
+func (p notificationPreferencesJSON) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// Scan реализует sql.Scanner для GORM
+func (p *notificationPreferencesJSON) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(bytes, p)
+}
+
+// toDomain преобразует JSON настроек в доменную структуру, подставляя значения по умолчанию
+func (p notificationPreferencesJSON) toDomain() user.NotificationPreferences {
+	prefs := user.DefaultNotificationPreferences()
+	if p.CheckInTimes != nil {
+		prefs.CheckInTimes = p.CheckInTimes
+	}
+	if p.Channels != nil {
+		prefs.Channels = make([]user.NotificationChannel, len(p.Channels))
+		for i, c := range p.Channels {
+			prefs.Channels[i] = user.NotificationChannel(c)
+		}
+	}
+	if p.Days != nil {
+		prefs.Days = p.Days
+	}
+	prefs.QuietHoursStart = p.QuietHoursStart
+	prefs.QuietHoursEnd = p.QuietHoursEnd
+	return prefs
+}
+
+// notificationPreferencesFromDomain преобразует доменные настройки в JSON
+func notificationPreferencesFromDomain(prefs user.NotificationPreferences) notificationPreferencesJSON {
+	channels := make([]string, len(prefs.Channels))
+	for i, c := range prefs.Channels {
+		channels[i] = string(c)
+	}
+	return notificationPreferencesJSON{
+		CheckInTimes:    prefs.CheckInTimes,
+		Channels:        channels,
+		QuietHoursStart: prefs.QuietHoursStart,
+		QuietHoursEnd:   prefs.QuietHoursEnd,
+		Days:            prefs.Days,
+	}
+}
+
+// onboardingJSON представляет JSON для onboarding
+type onboardingJSON struct {
+	CompletedSteps []string   `json:"completed_steps,omitempty"`
+	SkippedSteps   []string   `json:"skipped_steps,omitempty"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+}
+
+// Value реализует driver.Valuer для GORM
+func (o onboardingJSON) Value() (driver.Value, error) {
+	return json.Marshal(o)
+}
+
+// Scan реализует sql.Scanner для GORM
+func (o *onboardingJSON) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(bytes, o)
+}
+
+// toDomain преобразует JSON онбординга в доменную структуру
+func (o onboardingJSON) toDomain() user.Onboarding {
+	onboarding := user.Onboarding{CompletedAt: o.CompletedAt}
+	for _, step := range o.CompletedSteps {
+		onboarding.CompletedSteps = append(onboarding.CompletedSteps, user.OnboardingStep(step))
+	}
+	for _, step := range o.SkippedSteps {
+		onboarding.SkippedSteps = append(onboarding.SkippedSteps, user.OnboardingStep(step))
+	}
+	return onboarding
+}
+
+// onboardingFromDomain преобразует доменный онбординг в JSON
+func onboardingFromDomain(onboarding user.Onboarding) onboardingJSON {
+	o := onboardingJSON{CompletedAt: onboarding.CompletedAt}
+	for _, step := range onboarding.CompletedSteps {
+		o.CompletedSteps = append(o.CompletedSteps, string(step))
+	}
+	for _, step := range onboarding.SkippedSteps {
+		o.SkippedSteps = append(o.SkippedSteps, string(step))
+	}
+	return o
+}
+
 // userModel представляет модель пользователя в БД
 type userModel struct {
 	ID            uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
-	TelegramUserID int64      `gorm:"uniqueIndex;not null"`
+	TelegramUserID *int64     `gorm:"uniqueIndex"` // NULL у подопечных профилей
 	Name          string     `gorm:"not null"`
 	Age           *int
 	Gender        *string    `gorm:"type:varchar(10);check:gender IN ('male','female','other')"`
+	Timezone      string     `gorm:"type:varchar(64);not null;default:'UTC'"`
+	NotificationPreferences notificationPreferencesJSON `gorm:"type:jsonb;not null;default:'{}'"`
+	Onboarding    onboardingJSON `gorm:"type:jsonb;not null;default:'{}'"`
+	OwnerID       *uuid.UUID `gorm:"type:uuid;index"`
 	CreatedAt     time.Time  `gorm:"not null"`
 	UpdatedAt     time.Time  `gorm:"not null"`
 	DeletedAt     *time.Time `gorm:"index"`
@@ -34,12 +149,21 @@ func (m *userModel) toDomain() *user.User {
 		gender = &g
 	}
 
+	var telegramUserID int64
+	if m.TelegramUserID != nil {
+		telegramUserID = *m.TelegramUserID
+	}
+
 	return &user.User{
 		ID:            m.ID,
-		TelegramUserID: m.TelegramUserID,
+		TelegramUserID: telegramUserID,
 		Name:          m.Name,
 		Age:           m.Age,
 		Gender:        gender,
+		Timezone:      m.Timezone,
+		NotificationPreferences: m.NotificationPreferences.toDomain(),
+		Onboarding:    m.Onboarding.toDomain(),
+		OwnerID:       m.OwnerID,
 		CreatedAt:     m.CreatedAt,
 		UpdatedAt:     m.UpdatedAt,
 		DeletedAt:     m.DeletedAt,
@@ -49,13 +173,20 @@ func (m *userModel) toDomain() *user.User {
 // fromDomain преобразует доменную сущность в модель БД
 func (m *userModel) fromDomain(u *user.User) {
 	m.ID = u.ID
-	m.TelegramUserID = u.TelegramUserID
+	if u.TelegramUserID != 0 {
+		telegramUserID := u.TelegramUserID
+		m.TelegramUserID = &telegramUserID
+	}
 	m.Name = u.Name
 	m.Age = u.Age
 	if u.Gender != nil {
 		gender := string(*u.Gender)
 		m.Gender = &gender
 	}
+	m.Timezone = u.Timezone
+	m.NotificationPreferences = notificationPreferencesFromDomain(u.NotificationPreferences)
+	m.Onboarding = onboardingFromDomain(u.Onboarding)
+	m.OwnerID = u.OwnerID
 	m.CreatedAt = u.CreatedAt
 	m.UpdatedAt = u.UpdatedAt
 	m.DeletedAt = u.DeletedAt
@@ -114,15 +245,79 @@ func (r *UserRepository) GetByTelegramUserID(ctx context.Context, telegramUserID
 	return model.toDomain(), nil
 }
 
-// Update обновляет пользователя
-func (r *UserRepository) Update(ctx context.Context, u *user.User) error {
+// FindDependents возвращает подопечные профили владельца аккаунта в порядке создания
+func (r *UserRepository) FindDependents(ctx context.Context, ownerID uuid.UUID) ([]*user.User, error) {
+	var models []userModel
+	if err := r.db.WithContext(ctx).
+		Where("owner_id = ? AND deleted_at IS NULL", ownerID).
+		Order("created_at ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	users := make([]*user.User, len(models))
+	for i := range models {
+		users[i] = models[i].toDomain()
+	}
+
+	return users, nil
+}
+
+// UpdateProfile сохраняет имя, возраст и пол пользователя
+func (r *UserRepository) UpdateProfile(ctx context.Context, u *user.User) error {
 	model := &userModel{}
 	model.fromDomain(u)
 
+	return r.updateColumns(ctx, u, map[string]any{
+		"name":   model.Name,
+		"age":    model.Age,
+		"gender": model.Gender,
+	})
+}
+
+// UpdateTimezone сохраняет часовой пояс пользователя
+func (r *UserRepository) UpdateTimezone(ctx context.Context, u *user.User) error {
+	return r.updateColumns(ctx, u, map[string]any{"timezone": u.Timezone})
+}
+
+// UpdateNotificationPreferences сохраняет настройки уведомлений пользователя
+func (r *UserRepository) UpdateNotificationPreferences(ctx context.Context, u *user.User) error {
+	return r.updateColumns(ctx, u, map[string]any{
+		"notification_preferences": notificationPreferencesFromDomain(u.NotificationPreferences),
+	})
+}
+
+// UpdateOnboarding сохраняет прогресс онбординга пользователя
+func (r *UserRepository) UpdateOnboarding(ctx context.Context, u *user.User) error {
+	return r.updateColumns(ctx, u, map[string]any{"onboarding": onboardingFromDomain(u.Onboarding)})
+}
+
+// updateColumns сохраняет только переданные колонки пользователя и время изменения:
+// запись целиком не перезаписывается, чтобы параллельные изменения других полей не терялись
+func (r *UserRepository) updateColumns(ctx context.Context, u *user.User, columns map[string]any) error {
+	u.UpdatedAt = time.Now()
+	columns["updated_at"] = u.UpdatedAt
 	return r.db.WithContext(ctx).
 		Model(&userModel{}).
 		Where("id = ? AND deleted_at IS NULL", u.ID).
-		Updates(model).Error
+		Updates(columns).Error
+}
+
+// FindWithCheckIns возвращает пользователей с настроенными check-in напоминаниями
+func (r *UserRepository) FindWithCheckIns(ctx context.Context) ([]*user.User, error) {
+	var models []userModel
+	if err := r.db.WithContext(ctx).
+		Where("deleted_at IS NULL AND jsonb_array_length(COALESCE(notification_preferences->'check_in_times', '[]'::jsonb)) > 0").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	users := make([]*user.User, len(models))
+	for i := range models {
+		users[i] = models[i].toDomain()
+	}
+
+	return users, nil
 }
 
 // Delete удаляет пользователя (soft delete)
@@ -134,3 +329,75 @@ func (r *UserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 		Update("deleted_at", &now).Error
 }
 
+
+// GetDeletedByID возвращает удалённого, но ещё не стёртого пользователя по ID
+func (r *UserRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
+	return r.getDeleted(ctx, "id = ?", id)
+}
+
+// GetDeletedByTelegramUserID возвращает удалённого, но ещё не стёртого пользователя по Telegram User ID
+func (r *UserRepository) GetDeletedByTelegramUserID(ctx context.Context, telegramUserID int64) (*user.User, error) {
+	return r.getDeleted(ctx, "telegram_user_id = ?", telegramUserID)
+}
+
+// FindDeletedBefore возвращает пользователей, удалённых раньше before
+func (r *UserRepository) FindDeletedBefore(ctx context.Context, before time.Time) ([]*user.User, error) {
+	var models []userModel
+	if err := r.db.WithContext(ctx).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	users := make([]*user.User, len(models))
+	for i := range models {
+		users[i] = models[i].toDomain()
+	}
+
+	return users, nil
+}
+
+// FindDependentIDs возвращает ID всех подопечных профилей владельца, включая удалённые
+func (r *UserRepository) FindDependentIDs(ctx context.Context, ownerID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := r.db.WithContext(ctx).
+		Model(&userModel{}).
+		Where("owner_id = ?", ownerID).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// Restore снимает пометку об удалении
+func (r *UserRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&userModel{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{"deleted_at": nil, "updated_at": time.Now()}).Error
+}
+
+// Purge безвозвратно удаляет пользователя; связанные данные удаляются каскадно
+func (r *UserRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Where("id = ?", id).
+		Delete(&userModel{}).Error
+}
+
+// getDeleted возвращает удалённого пользователя по условию
+func (r *UserRepository) getDeleted(ctx context.Context, query string, args ...interface{}) (*user.User, error) {
+	var model userModel
+	if err := r.db.WithContext(ctx).
+		Where(query, args...).
+		Where("deleted_at IS NOT NULL").
+		First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.toDomain(), nil
+}
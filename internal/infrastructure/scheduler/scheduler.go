@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Job представляет периодическую фоновую задачу
+type Job interface {
+	// Name возвращает имя задачи для логов
+	Name() string
+
+	// Run выполняет один проход задачи
+	Run(ctx context.Context) error
+}
+
+// entry связывает задачу с интервалом запуска
+type entry struct {
+	job      Job
+	interval time.Duration
+}
+
+// Scheduler запускает зарегистрированные задачи с заданным интервалом
+type Scheduler struct {
+	entries []entry
+	wg      sync.WaitGroup
+}
+
+// New создаёт новый планировщик
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+// Every регистрирует задачу, выполняемую каждые interval
+func (s *Scheduler) Every(interval time.Duration, job Job) {
+	s.entries = append(s.entries, entry{job: job, interval: interval})
+}
+
+// Start запускает все задачи в отдельных горутинах до отмены ctx.
+// Первый проход выполняется сразу после старта.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, e := range s.entries {
+		s.wg.Add(1)
+		go func(e entry) {
+			defer s.wg.Done()
+			s.loop(ctx, e)
+		}(e)
+	}
+}
+
+// Wait ожидает завершения всех задач после отмены контекста
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+// loop выполняет задачу по таймеру; ошибки прохода логируются и не останавливают задачу
+func (s *Scheduler) loop(ctx context.Context, e entry) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := e.job.Run(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("scheduler: job %s failed: %v", e.job.Name(), err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
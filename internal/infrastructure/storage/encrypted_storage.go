@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"context"
+	"io"
+
+	"github.com/health-hub-bot-api/internal/domain/filestorage"
+	"github.com/health-hub-bot-api/internal/infrastructure/encryption"
+)
+
+// EncryptedStorage шифрует файлы перед записью в хранилище. У каждого файла
+// свой ключ, который хранится в заголовке файла зашифрованным мастер-ключом.
+// Файлы, сохранённые до включения шифрования, и внешние ссылки читаются как есть.
+type EncryptedStorage struct {
+	store filestorage.Storage
+	keys  *encryption.Keyring
+}
+
+// NewEncryptedStorage оборачивает хранилище шифрованием. Без мастер-ключей
+// (keys == nil) файлы сохраняются открытыми.
+func NewEncryptedStorage(store filestorage.Storage, keys *encryption.Keyring) *EncryptedStorage {
+	return &EncryptedStorage{store: store, keys: keys}
+}
+
+// Open открывает файл и расшифровывает его содержимое
+func (s *EncryptedStorage) Open(ctx context.Context, fileURL string) (io.ReadCloser, error) {
+	file, err := s.store.Open(ctx, fileURL)
+	if err != nil {
+		return nil, err
+	}
+
+	plain, _, err := s.keys.DecryptStream(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return readCloser{Reader: plain, Closer: file}, nil
+}
+
+// Save шифрует и сохраняет файл под ключом key
+func (s *EncryptedStorage) Save(ctx context.Context, key string, r io.Reader) (string, error) {
+	if s.keys == nil {
+		return s.store.Save(ctx, key, r)
+	}
+
+	encrypted, err := s.keys.EncryptStream(r)
+	if err != nil {
+		return "", err
+	}
+	return s.store.Save(ctx, key, encrypted)
+}
+
+// Delete удаляет файл
+func (s *EncryptedStorage) Delete(ctx context.Context, fileURL string) error {
+	return s.store.Delete(ctx, fileURL)
+}
+
+// Reencrypt перешифровывает файл, если он открыт или его ключ зашифрован
+// не действующим мастер-ключом. Возвращает true, если файл перезаписан.
+func (s *EncryptedStorage) Reencrypt(ctx context.Context, fileURL string) (bool, error) {
+	if s.keys == nil || isRemote(fileURL) {
+		return false, nil
+	}
+
+	file, err := s.store.Open(ctx, fileURL)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	plain, masterKeyID, err := s.keys.DecryptStream(file)
+	if err != nil {
+		return false, err
+	}
+	if masterKeyID == s.keys.ActiveID() {
+		return false, nil
+	}
+
+	// Хранилище пишет во временный файл и переименовывает его,
+	// поэтому файл можно перезаписать, пока он открыт на чтение
+	if _, err := s.Save(ctx, fileURL, plain); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// readCloser объединяет расшифрованный поток с закрытием исходного файла
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
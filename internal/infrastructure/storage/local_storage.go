@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/health-hub-bot-api/internal/domain/filestorage"
+)
+
+// LocalStorage реализует filestorage.Storage в каталоге на диске.
+// Файлы по внешним http(s)-ссылкам не открываются.
+type LocalStorage struct {
+	root string
+}
+
+// NewLocalStorage создаёт хранилище в каталоге root
+func NewLocalStorage(root string) *LocalStorage {
+	return &LocalStorage{root: root}
+}
+
+// Open открывает файл из каталога хранилища; для внешней ссылки возвращает ErrRemoteFile
+func (s *LocalStorage) Open(ctx context.Context, fileURL string) (io.ReadCloser, error) {
+	if isRemote(fileURL) {
+		return nil, filestorage.ErrRemoteFile
+	}
+
+	file, err := os.Open(s.Path(fileURL))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, filestorage.ErrFileNotFound
+	}
+	return file, err
+}
+
+// Save сохраняет файл под ключом key; URL файла совпадает с ключом
+func (s *LocalStorage) Save(ctx context.Context, key string, r io.Reader) (string, error) {
+	path := s.Path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return "", err
+	}
+
+	// Файл пишется во временный и переименовывается, чтобы не оставить обрезанный файл
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+// Delete удаляет файл из каталога хранилища
+func (s *LocalStorage) Delete(ctx context.Context, fileURL string) error {
+	if isRemote(fileURL) {
+		return nil
+	}
+	if err := os.Remove(s.Path(fileURL)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Path возвращает путь файла на диске. Ключ очищается относительно корня,
+// чтобы не выйти за пределы каталога хранилища.
+func (s *LocalStorage) Path(fileURL string) string {
+	return filepath.Join(s.root, filepath.Clean("/"+fileURL))
+}
+
+// isRemote проверяет, что файл задан внешней http(s)-ссылкой
+func isRemote(fileURL string) bool {
+	u, err := url.Parse(fileURL)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https")
+}
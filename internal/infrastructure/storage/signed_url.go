@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// AnalysisFilePath — путь, по которому отдаются файлы анализов по подписанной ссылке
+	AnalysisFilePath = "/files/analyses/"
+	// ExportFilePath — путь, по которому отдаются архивы экспорта по подписанной ссылке
+	ExportFilePath = "/files/exports/"
+)
+
+var ErrInvalidSignature = errors.New("invalid or expired file link signature")
+
+// URLSigner подписывает и проверяет временные ссылки на файлы.
+// Подпись — HMAC-SHA256 от пути, ID и срока действия, поэтому ссылку
+// нельзя продлить или перенести на другой файл.
+type URLSigner struct {
+	key     []byte
+	baseURL string
+}
+
+// NewURLSigner создаёт подписчик ссылок. Ключ производится из секрета,
+// чтобы секрет (например, токен бота) не использовался напрямую.
+// baseURL добавляется к ссылкам, которые открываются вне веб-страниц сервера.
+func NewURLSigner(secret, baseURL string) *URLSigner {
+	return &URLSigner{
+		key:     hmacSHA256([]byte("SignedFileURL"), []byte(secret)),
+		baseURL: baseURL,
+	}
+}
+
+// AnalysisFileURL возвращает относительную подписанную ссылку на файл анализа
+func (s *URLSigner) AnalysisFileURL(analysisID uuid.UUID, expiresAt time.Time) string {
+	return s.sign(AnalysisFilePath, analysisID, expiresAt)
+}
+
+// ExportURL возвращает полную подписанную ссылку на архив экспорта
+func (s *URLSigner) ExportURL(jobID uuid.UUID, expiresAt time.Time) string {
+	return s.baseURL + s.sign(ExportFilePath, jobID, expiresAt)
+}
+
+// Verify проверяет подпись и срок действия ссылки на файл по пути path
+func (s *URLSigner) Verify(path string, id uuid.UUID, expires, signature string, now time.Time) error {
+	expiresUnix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || !now.Before(time.Unix(expiresUnix, 0)) {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.signature(path, id, expires))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// sign возвращает относительную подписанную ссылку
+func (s *URLSigner) sign(path string, id uuid.UUID, expiresAt time.Time) string {
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	query := url.Values{
+		"expires":   {expires},
+		"signature": {s.signature(path, id, expires)},
+	}
+	return path + id.String() + "?" + query.Encode()
+}
+
+// signature вычисляет подпись ссылки
+func (s *URLSigner) signature(path string, id uuid.UUID, expires string) string {
+	return hex.EncodeToString(hmacSHA256(s.key, []byte(fmt.Sprintf("%s%s:%s", path, id, expires))))
+}
+
+// hmacSHA256 вычисляет HMAC-SHA256
+func hmacSHA256(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
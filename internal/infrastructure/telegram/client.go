@@ -0,0 +1,179 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/health-hub-bot-api/internal/domain/notification"
+)
+
+const apiBaseURL = "https://api.telegram.org"
+
+// maxDownloadSize — предел размера файла, который Bot API отдаёт через getFile
+const maxDownloadSize = 20 << 20
+
+// ErrFileTooLarge возвращается для файлов, которые Bot API не позволяет скачать
+var ErrFileTooLarge = errors.New("telegram file is too large to download")
+
+// Client представляет клиент Telegram Bot API
+type Client struct {
+	botToken   string
+	httpClient *http.Client
+}
+
+// NewClient создаёт новый клиент Bot API
+func NewClient(botToken string) *Client {
+	return &Client{
+		botToken:   botToken,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// inlineKeyboardButton представляет кнопку inline-клавиатуры
+type inlineKeyboardButton struct {
+	Text         string `json:"text"`
+	CallbackData string `json:"callback_data,omitempty"`
+}
+
+// inlineKeyboardMarkup представляет inline-клавиатуру
+type inlineKeyboardMarkup struct {
+	InlineKeyboard [][]inlineKeyboardButton `json:"inline_keyboard"`
+}
+
+// sendMessageRequest представляет параметры метода sendMessage
+type sendMessageRequest struct {
+	ChatID      int64                 `json:"chat_id"`
+	Text        string                `json:"text"`
+	ReplyMarkup *inlineKeyboardMarkup `json:"reply_markup,omitempty"`
+}
+
+// answerCallbackQueryRequest представляет параметры метода answerCallbackQuery
+type answerCallbackQueryRequest struct {
+	CallbackQueryID string `json:"callback_query_id"`
+	Text            string `json:"text,omitempty"`
+}
+
+// getFileRequest представляет параметры метода getFile
+type getFileRequest struct {
+	FileID string `json:"file_id"`
+}
+
+// file представляет результат метода getFile
+type file struct {
+	FileID   string `json:"file_id"`
+	FileSize int64  `json:"file_size"`
+	FilePath string `json:"file_path"`
+}
+
+// apiResponse представляет ответ Bot API
+type apiResponse struct {
+	OK          bool            `json:"ok"`
+	Description string          `json:"description"`
+	Result      json.RawMessage `json:"result"`
+}
+
+// Send реализует notification.Notifier: отправляет сообщение с кнопками, по одной в ряд
+func (c *Client) Send(ctx context.Context, chatID int64, message notification.Message) error {
+	req := sendMessageRequest{
+		ChatID: chatID,
+		Text:   message.Text,
+	}
+	if len(message.Buttons) > 0 {
+		keyboard := &inlineKeyboardMarkup{}
+		for _, b := range message.Buttons {
+			keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, []inlineKeyboardButton{{
+				Text:         b.Text,
+				CallbackData: b.CallbackData,
+			}})
+		}
+		req.ReplyMarkup = keyboard
+	}
+
+	return c.call(ctx, "sendMessage", req)
+}
+
+// AnswerCallbackQuery отвечает на нажатие inline-кнопки; text показывается
+// всплывающим уведомлением, пустой text только убирает индикатор загрузки
+func (c *Client) AnswerCallbackQuery(ctx context.Context, callbackQueryID, text string) error {
+	return c.call(ctx, "answerCallbackQuery", answerCallbackQueryRequest{
+		CallbackQueryID: callbackQueryID,
+		Text:            text,
+	})
+}
+
+// DownloadFile скачивает файл, присланный пользователем (документ или фото)
+func (c *Client) DownloadFile(ctx context.Context, fileID string) (io.ReadCloser, error) {
+	var f file
+	if err := c.callResult(ctx, "getFile", getFileRequest{FileID: fileID}, &f); err != nil {
+		return nil, err
+	}
+	if f.FileSize > maxDownloadSize {
+		return nil, ErrFileTooLarge
+	}
+
+	url := fmt.Sprintf("%s/file/bot%s/%s", apiBaseURL, c.botToken, f.FilePath)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("telegram download: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("telegram download: unexpected status %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+// call выполняет JSON-запрос к методу Bot API
+func (c *Client) call(ctx context.Context, method string, payload interface{}) error {
+	return c.callResult(ctx, method, payload, nil)
+}
+
+// callResult выполняет JSON-запрос к методу Bot API и разбирает результат в result, если он задан
+func (c *Client) callResult(ctx context.Context, method string, payload interface{}, result interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/bot%s/%s", apiBaseURL, c.botToken, method)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("telegram %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	return decodeResponse(method, resp, result)
+}
+
+// decodeResponse проверяет ответ Bot API и разбирает результат в result, если он задан
+func decodeResponse(method string, resp *http.Response, result interface{}) error {
+	var response apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return fmt.Errorf("telegram %s: failed to decode response: %w", method, err)
+	}
+	if !response.OK {
+		return fmt.Errorf("telegram %s: %s", method, response.Description)
+	}
+	if result != nil {
+		if err := json.Unmarshal(response.Result, result); err != nil {
+			return fmt.Errorf("telegram %s: failed to decode result: %w", method, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package telegram
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidInitData = errors.New("invalid telegram init data")
+	ErrInitDataExpired = errors.New("telegram init data expired")
+)
+
+// WebAppUser представляет пользователя из initData Telegram WebApp
+type WebAppUser struct {
+	ID           int64  `json:"id"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	Username     string `json:"username"`
+	LanguageCode string `json:"language_code"`
+}
+
+// InitData представляет проверенные данные запуска Telegram WebApp
+type InitData struct {
+	User     WebAppUser
+	AuthDate time.Time
+	Raw      string
+}
+
+// ValidateInitData проверяет подпись initData по токену бота и возвращает данные пользователя.
+// Алгоритм описан в документации Telegram: https://core.telegram.org/bots/webapps#validating-data-received-via-the-mini-app
+func ValidateInitData(raw, botToken string, maxAge time.Duration) (*InitData, error) {
+	values, err := url.ParseQuery(raw)
+	if err != nil {
+		return nil, ErrInvalidInitData
+	}
+
+	hash := values.Get("hash")
+	if hash == "" {
+		return nil, ErrInvalidInitData
+	}
+
+	// Строка для проверки: пары key=value без hash, отсортированные по ключу
+	pairs := make([]string, 0, len(values))
+	for key := range values {
+		if key == "hash" {
+			continue
+		}
+		pairs = append(pairs, key+"="+values.Get(key))
+	}
+	sort.Strings(pairs)
+	dataCheckString := strings.Join(pairs, "\n")
+
+	secret := hmacSHA256([]byte("WebAppData"), []byte(botToken))
+	expected := hex.EncodeToString(hmacSHA256(secret, []byte(dataCheckString)))
+	if !hmac.Equal([]byte(expected), []byte(hash)) {
+		return nil, ErrInvalidInitData
+	}
+
+	authDateUnix, err := strconv.ParseInt(values.Get("auth_date"), 10, 64)
+	if err != nil {
+		return nil, ErrInvalidInitData
+	}
+	authDate := time.Unix(authDateUnix, 0)
+	if maxAge > 0 && time.Since(authDate) > maxAge {
+		return nil, ErrInitDataExpired
+	}
+
+	var webAppUser WebAppUser
+	if err := json.Unmarshal([]byte(values.Get("user")), &webAppUser); err != nil || webAppUser.ID == 0 {
+		return nil, ErrInvalidInitData
+	}
+
+	return &InitData{
+		User:     webAppUser,
+		AuthDate: authDate,
+		Raw:      raw,
+	}, nil
+}
+
+// hmacSHA256 вычисляет HMAC-SHA256
+func hmacSHA256(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
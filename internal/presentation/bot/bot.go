@@ -0,0 +1,255 @@
+package bot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/google/uuid"
+	analysisapp "github.com/health-hub-bot-api/internal/application/analysis"
+	auditapp "github.com/health-hub-bot-api/internal/application/audit"
+	consentapp "github.com/health-hub-bot-api/internal/application/consent"
+	dashboardapp "github.com/health-hub-bot-api/internal/application/dashboard"
+	doctorvisitapp "github.com/health-hub-bot-api/internal/application/doctorvisit"
+	reminderapp "github.com/health-hub-bot-api/internal/application/reminder"
+	symptomapp "github.com/health-hub-bot-api/internal/application/symptom"
+	userapp "github.com/health-hub-bot-api/internal/application/user"
+	"github.com/health-hub-bot-api/internal/domain/audit"
+	"github.com/health-hub-bot-api/internal/domain/consent"
+	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+	"github.com/health-hub-bot-api/internal/domain/notification"
+	"github.com/health-hub-bot-api/internal/domain/reminder"
+	"github.com/health-hub-bot-api/internal/domain/user"
+	"github.com/health-hub-bot-api/internal/infrastructure/telegram"
+)
+
+// ErrMalformedUpdate возвращается, если тело запроса не является обновлением Bot API
+var ErrMalformedUpdate = errors.New("malformed telegram update")
+
+// privateChat — тип личного чата с ботом; в группах бот не отвечает,
+// чтобы не раскрывать медицинские данные другим участникам
+const privateChat = "private"
+
+// Messenger отправляет ответы пользователю через Bot API
+type Messenger interface {
+	notification.Notifier
+
+	// AnswerCallbackQuery отвечает на нажатие inline-кнопки
+	AnswerCallbackQuery(ctx context.Context, callbackQueryID, text string) error
+
+	// DownloadFile скачивает файл, присланный пользователем
+	DownloadFile(ctx context.Context, fileID string) (io.ReadCloser, error)
+}
+
+// Bot обрабатывает обновления Telegram: команды, нажатия кнопок напоминаний
+// и загрузку файлов анализов. Бот вызывает те же use case, что и GraphQL API,
+// и работает с собственным профилем владельца аккаунта.
+type Bot struct {
+	userRepo        user.Repository
+	doctorVisitRepo doctorvisit.Repository
+	reminderRepo    reminder.Repository
+	updates         notification.UpdateRepository
+	consents        *consentapp.ConsentUseCase
+	onboarding      *userapp.OnboardingUseCase
+	createSymptom   *symptomapp.CreateSymptomUseCase
+	dashboard       *dashboardapp.GetDashboardUseCase
+	handleAction    *reminderapp.HandleActionUseCase
+	sendReport      *doctorvisitapp.SendReportUseCase
+	uploadAnalysis  *analysisapp.UploadAnalysisUseCase
+	record          *auditapp.RecordUseCase
+	messenger       Messenger
+}
+
+// NewBot создаёт обработчик обновлений бота
+func NewBot(
+	userRepo user.Repository,
+	doctorVisitRepo doctorvisit.Repository,
+	reminderRepo reminder.Repository,
+	updates notification.UpdateRepository,
+	consents *consentapp.ConsentUseCase,
+	onboarding *userapp.OnboardingUseCase,
+	createSymptom *symptomapp.CreateSymptomUseCase,
+	dashboard *dashboardapp.GetDashboardUseCase,
+	handleAction *reminderapp.HandleActionUseCase,
+	sendReport *doctorvisitapp.SendReportUseCase,
+	uploadAnalysis *analysisapp.UploadAnalysisUseCase,
+	record *auditapp.RecordUseCase,
+	messenger Messenger,
+) *Bot {
+	return &Bot{
+		userRepo:        userRepo,
+		doctorVisitRepo: doctorVisitRepo,
+		reminderRepo:    reminderRepo,
+		updates:         updates,
+		consents:        consents,
+		onboarding:      onboarding,
+		createSymptom:   createSymptom,
+		dashboard:       dashboard,
+		handleAction:    handleAction,
+		sendReport:      sendReport,
+		uploadAnalysis:  uploadAnalysis,
+		record:          record,
+		messenger:       messenger,
+	}
+}
+
+// HandleUpdate разбирает обновление Bot API в исходном виде и обрабатывает его.
+// Обновления других типов, сообщения не из личного чата и повторная доставка
+// уже принятого обновления пропускаются.
+func (b *Bot) HandleUpdate(ctx context.Context, raw []byte) error {
+	var update telegram.Update
+	if err := json.Unmarshal(raw, &update); err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformedUpdate, err)
+	}
+
+	// Обновление отмечается до обработки: при ошибке вебхук всё равно отвечает 200,
+	// и Telegram повторяет доставку, только если не дождался ответа
+	claimed, err := b.updates.Claim(ctx, update.UpdateID)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	// Хэш обновления сохраняется как подтверждение согласия, данного кнопкой в боте
+	sum := sha256.Sum256(raw)
+	evidence := hex.EncodeToString(sum[:])
+
+	switch {
+	case update.CallbackQuery != nil:
+		return b.handleCallback(ctx, update.CallbackQuery, evidence)
+	case update.Message != nil:
+		return b.handleMessage(ctx, update.Message)
+	default:
+		return nil
+	}
+}
+
+// handleMessage направляет сообщение обработчику команды или загрузки файла
+func (b *Bot) handleMessage(ctx context.Context, msg *telegram.Message) error {
+	if msg.From == nil || msg.From.IsBot || msg.Chat.Type != privateChat {
+		return nil
+	}
+	u, err := b.account(ctx, msg.Chat.ID, *msg.From)
+	if err != nil || u == nil {
+		return err
+	}
+
+	if err := b.routeMessage(ctx, u, msg); err != nil {
+		// Пользователь получает общий ответ, подробности остаются в логе
+		if replyErr := b.reply(ctx, msg.Chat.ID, failureText); replyErr != nil {
+			log.Printf("bot: failed to send failure reply: %v", replyErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// routeMessage выбирает обработчик по команде или вложению
+func (b *Bot) routeMessage(ctx context.Context, u *user.User, msg *telegram.Message) error {
+	if msg.Document != nil || len(msg.Photo) > 0 {
+		return b.handleUpload(ctx, u, msg)
+	}
+
+	command, args := parseCommand(msg.Text)
+	switch command {
+	case commandStart:
+		return b.start(ctx, u, msg.Chat.ID)
+	case commandToday:
+		return b.today(ctx, u, msg.Chat.ID)
+	case commandLog:
+		return b.logSymptom(ctx, u, msg.Chat.ID, args)
+	case commandReport:
+		return b.report(ctx, u, msg.Chat.ID)
+	default:
+		return b.reply(ctx, msg.Chat.ID, helpText)
+	}
+}
+
+// account возвращает аккаунт отправителя и создаёт его при первом обращении, как
+// AuthMiddleware. Для аккаунта, ожидающего удаления, бот отвечает подсказкой и возвращает nil.
+func (b *Bot) account(ctx context.Context, chatID int64, from telegram.User) (*user.User, error) {
+	u, err := b.userRepo.GetByTelegramUserID(ctx, from.ID)
+	if err != nil {
+		return nil, err
+	}
+	if u != nil {
+		return u, nil
+	}
+
+	deleted, err := b.userRepo.GetDeletedByTelegramUserID(ctx, from.ID)
+	if err != nil {
+		return nil, err
+	}
+	if deleted != nil {
+		return nil, b.reply(ctx, chatID, "Аккаунт запланирован к удалению. Восстановить его можно в приложении.")
+	}
+
+	u = user.NewUser(from.ID, from.FirstName)
+	if err := b.userRepo.Create(ctx, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// completeFirstAction закрывает шаг онбординга «первая запись», если он текущий.
+// Запись уже сохранена, поэтому ошибка только логируется.
+func (b *Bot) completeFirstAction(ctx context.Context, u *user.User) {
+	if step := u.Onboarding.CurrentStep(); step == nil || *step != user.OnboardingStepFirstAction {
+		return
+	}
+	if _, err := b.onboarding.Execute(ctx, u.ID, user.OnboardingStepFirstAction, false); err != nil {
+		log.Printf("bot: failed to complete onboarding first action: %v", err)
+	}
+}
+
+// requireConsent проверяет согласие на обработку данных перед изменением данных,
+// как ConsentGate для мутаций GraphQL. Без согласия бот предлагает его дать и возвращает false.
+func (b *Bot) requireConsent(ctx context.Context, u *user.User, chatID int64) (bool, error) {
+	if err := b.consents.Check(ctx, u.ID); err != nil {
+		if errors.Is(err, consent.ErrConsentRequired) {
+			return false, b.askConsent(ctx, u, chatID)
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// reply отправляет текстовый ответ в чат
+func (b *Bot) reply(ctx context.Context, chatID int64, text string) error {
+	return b.messenger.Send(ctx, chatID, notification.Message{Text: text})
+}
+
+// actor возвращает участника журнала аудита для действия владельца аккаунта с профилем ownerID
+func actor(accountID, ownerID uuid.UUID) audit.Actor {
+	if accountID != ownerID {
+		return audit.Actor{Kind: audit.ActorAccountHolder, UserID: &accountID}
+	}
+	return audit.Actor{Kind: audit.ActorOwner, UserID: &accountID}
+}
+
+// recordRead записывает чтение данных в журнал аудита. Если запись не удалась,
+// данные не отправляются, а пользователь получает сообщение об ошибке.
+func (b *Bot) recordRead(ctx context.Context, u *user.User, chatID int64, operation, entityType string, entityID *uuid.UUID) (bool, error) {
+	entry := audit.NewEntry(u.ID, actor(u.ID, u.ID), audit.ActionRead, operation, entityType, entityID, nil)
+	if err := b.record.Execute(ctx, entry); err != nil {
+		log.Printf("audit: failed to record %s: %v", operation, err)
+		return false, b.reply(ctx, chatID, failureText)
+	}
+	return true, nil
+}
+
+// recordChange записывает изменение данных в журнал аудита; ошибка только
+// логируется, так как изменение уже сохранено
+func (b *Bot) recordChange(ctx context.Context, accountID, ownerID uuid.UUID, action audit.Action, operation, entityType string, entityID *uuid.UUID, fields []string) {
+	entry := audit.NewEntry(ownerID, actor(accountID, ownerID), action, operation, entityType, entityID, fields)
+	if err := b.record.Execute(ctx, entry); err != nil {
+		log.Printf("audit: failed to record %s: %v", operation, err)
+	}
+}
@@ -0,0 +1,892 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	analysisapp "github.com/health-hub-bot-api/internal/application/analysis"
+	auditapp "github.com/health-hub-bot-api/internal/application/audit"
+	consentapp "github.com/health-hub-bot-api/internal/application/consent"
+	dashboardapp "github.com/health-hub-bot-api/internal/application/dashboard"
+	doctorvisitapp "github.com/health-hub-bot-api/internal/application/doctorvisit"
+	engagementapp "github.com/health-hub-bot-api/internal/application/engagement"
+	medicationapp "github.com/health-hub-bot-api/internal/application/medication"
+	reminderapp "github.com/health-hub-bot-api/internal/application/reminder"
+	symptomapp "github.com/health-hub-bot-api/internal/application/symptom"
+	userapp "github.com/health-hub-bot-api/internal/application/user"
+	"github.com/health-hub-bot-api/internal/domain/analysis"
+	"github.com/health-hub-bot-api/internal/domain/audit"
+	"github.com/health-hub-bot-api/internal/domain/consent"
+	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+	"github.com/health-hub-bot-api/internal/domain/engagement"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/notification"
+	"github.com/health-hub-bot-api/internal/domain/reminder"
+	"github.com/health-hub-bot-api/internal/domain/symptom"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+const (
+	testSecret        = "webhook-secret"
+	testPolicyVersion = "1"
+	// testTelegramID — отправитель и личный чат во всех фикстурах testdata
+	testTelegramID = 123456789
+	// testReminderID — напоминание из фикстуры reminder_callback.json
+	testReminderID = "0b9d6a52-3f0e-4c53-9a8e-6f1d2c7e4b11"
+)
+
+// store хранит данные всех репозиториев в памяти. Сводка для /today читает
+// репозитории параллельно, поэтому доступ защищён мьютексом.
+type store struct {
+	mu          sync.Mutex
+	users       map[uuid.UUID]*user.User
+	consents    []*consent.Consent
+	symptoms    []*symptom.SymptomEntry
+	analyses    []*analysis.Analysis
+	medications map[uuid.UUID]*medication.Medication
+	intakes     map[uuid.UUID]*medication.MedicationIntake
+	visits      map[uuid.UUID]*doctorvisit.DoctorVisit
+	reminders   map[uuid.UUID]*reminder.Reminder
+	audit       []*audit.Entry
+	files       map[string][]byte
+	updates     map[int64]bool
+}
+
+func newStore() *store {
+	return &store{
+		users:       make(map[uuid.UUID]*user.User),
+		medications: make(map[uuid.UUID]*medication.Medication),
+		intakes:     make(map[uuid.UUID]*medication.MedicationIntake),
+		visits:      make(map[uuid.UUID]*doctorvisit.DoctorVisit),
+		reminders:   make(map[uuid.UUID]*reminder.Reminder),
+		files:       make(map[string][]byte),
+		updates:     make(map[int64]bool),
+	}
+}
+
+// Репозитории реализуют только методы, которые вызывают сценарии бота;
+// вызов остальных методов встроенного интерфейса завершит тест паникой.
+
+type memUsers struct {
+	user.Repository
+	s *store
+}
+
+func (r memUsers) Create(ctx context.Context, u *user.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	stored := *u
+	r.s.users[u.ID] = &stored
+	return nil
+}
+
+func (r memUsers) GetByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	u, ok := r.s.users[id]
+	if !ok {
+		return nil, nil
+	}
+	found := *u
+	return &found, nil
+}
+
+func (r memUsers) GetByTelegramUserID(ctx context.Context, telegramUserID int64) (*user.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, u := range r.s.users {
+		if u.TelegramUserID == telegramUserID && u.DeletedAt == nil {
+			found := *u
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r memUsers) GetDeletedByTelegramUserID(ctx context.Context, telegramUserID int64) (*user.User, error) {
+	return nil, nil
+}
+
+func (r memUsers) UpdateOnboarding(ctx context.Context, u *user.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if stored, ok := r.s.users[u.ID]; ok {
+		stored.Onboarding = u.Onboarding
+		stored.UpdatedAt = u.UpdatedAt
+	}
+	return nil
+}
+
+type memConsents struct {
+	consent.Repository
+	s *store
+}
+
+func (r memConsents) Create(ctx context.Context, c *consent.Consent) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.consents = append(r.s.consents, c)
+	return nil
+}
+
+func (r memConsents) GetLatest(ctx context.Context, userID uuid.UUID) (*consent.Consent, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for i := len(r.s.consents) - 1; i >= 0; i-- {
+		if r.s.consents[i].UserID == userID {
+			return r.s.consents[i], nil
+		}
+	}
+	return nil, nil
+}
+
+type memSymptoms struct {
+	symptom.Repository
+	s *store
+}
+
+func (r memSymptoms) Create(ctx context.Context, entry *symptom.SymptomEntry) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.symptoms = append(r.s.symptoms, entry)
+	return nil
+}
+
+func (r memSymptoms) FindByFilter(ctx context.Context, filter symptom.Filter, limit, offset int) ([]*symptom.SymptomEntry, int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var entries []*symptom.SymptomEntry
+	for _, entry := range r.s.symptoms {
+		if entry.UserID == filter.UserID {
+			entries = append(entries, entry)
+		}
+	}
+	total := len(entries)
+	entries = entries[min(offset, total):min(offset+limit, total)]
+	return entries, total, nil
+}
+
+func (r memSymptoms) GetEntryTimes(ctx context.Context, userID uuid.UUID, since time.Time) ([]time.Time, error) {
+	entries, _, err := r.FindByFilter(ctx, symptom.Filter{UserID: userID}, math.MaxInt32, 0)
+	times := make([]time.Time, len(entries))
+	for i, entry := range entries {
+		times[i] = entry.DateTime
+	}
+	return times, err
+}
+
+func (r memSymptoms) GetWellbeingTrend(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]symptom.WellbeingDataPoint, error) {
+	return nil, nil
+}
+
+func (r memSymptoms) GetDailyWellbeing(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time, loc *time.Location) ([]symptom.DailyWellbeing, error) {
+	return nil, nil
+}
+
+type memAnalyses struct {
+	analysis.Repository
+	s *store
+}
+
+func (r memAnalyses) Create(ctx context.Context, a *analysis.Analysis) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.analyses = append(r.s.analyses, a)
+	return nil
+}
+
+func (r memAnalyses) FindByFilter(ctx context.Context, filter analysis.Filter, limit, offset int) ([]*analysis.Analysis, int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var analyses []*analysis.Analysis
+	for _, a := range r.s.analyses {
+		if a.UserID == filter.UserID {
+			analyses = append(analyses, a)
+		}
+	}
+	total := len(analyses)
+	analyses = analyses[min(offset, total):min(offset+limit, total)]
+	return analyses, total, nil
+}
+
+func (r memAnalyses) GetUpcomingReminders(ctx context.Context, userID uuid.UUID, beforeDate time.Time) ([]*analysis.Analysis, error) {
+	return nil, nil
+}
+
+type memMedications struct {
+	medication.Repository
+	s *store
+}
+
+func (r memMedications) GetByID(ctx context.Context, id uuid.UUID) (*medication.Medication, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.medications[id], nil
+}
+
+func (r memMedications) FindByUserID(ctx context.Context, userID uuid.UUID, activeOnly bool) ([]*medication.Medication, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var medications []*medication.Medication
+	for _, m := range r.s.medications {
+		if m.UserID == userID && (m.IsActive || !activeOnly) {
+			medications = append(medications, m)
+		}
+	}
+	return medications, nil
+}
+
+type memIntakes struct {
+	medication.IntakeRepository
+	s *store
+}
+
+func (r memIntakes) GetByID(ctx context.Context, id uuid.UUID) (*medication.MedicationIntake, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	intake, ok := r.s.intakes[id]
+	if !ok {
+		return nil, nil
+	}
+	loaded := *intake
+	return &loaded, nil
+}
+
+func (r memIntakes) Update(ctx context.Context, intake *medication.MedicationIntake) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.intakes[intake.ID] = intake
+	return nil
+}
+
+func (r memIntakes) UpdateMark(ctx context.Context, intake *medication.MedicationIntake, from medication.IntakeStatus, stockDelta float64) (bool, *float64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if stored, ok := r.s.intakes[intake.ID]; !ok || stored.Status != from {
+		return false, nil, nil
+	}
+	r.s.intakes[intake.ID] = intake
+	return true, nil, nil
+}
+
+func (r memIntakes) FindByMedicationAndDate(ctx context.Context, medicationID uuid.UUID, date time.Time) ([]*medication.MedicationIntake, error) {
+	return r.FindByMedicationAndPeriod(ctx, medicationID, date, date.Add(24*time.Hour))
+}
+
+func (r memIntakes) FindByMedicationAndPeriod(ctx context.Context, medicationID uuid.UUID, startDate, endDate time.Time) ([]*medication.MedicationIntake, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var intakes []*medication.MedicationIntake
+	for _, intake := range r.s.intakes {
+		if intake.MedicationID == medicationID &&
+			!intake.ScheduledTime.Before(startDate) && intake.ScheduledTime.Before(endDate) {
+			intakes = append(intakes, intake)
+		}
+	}
+	return intakes, nil
+}
+
+func (r memIntakes) FindByUserAndPeriod(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]*medication.MedicationIntake, error) {
+	return nil, nil
+}
+
+type memCourses struct {
+	medication.CourseRepository
+}
+
+func (memCourses) FindEndedBetween(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]*medication.Course, error) {
+	return nil, nil
+}
+
+type memVisits struct {
+	doctorvisit.Repository
+	s *store
+}
+
+func (r memVisits) GetByID(ctx context.Context, id uuid.UUID) (*doctorvisit.DoctorVisit, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	visit, ok := r.s.visits[id]
+	if !ok {
+		return nil, doctorvisit.ErrVisitNotFound
+	}
+	return visit, nil
+}
+
+func (r memVisits) GetUpcomingVisits(ctx context.Context, userID uuid.UUID, beforeDate time.Time) ([]*doctorvisit.DoctorVisit, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var visits []*doctorvisit.DoctorVisit
+	for _, visit := range r.s.visits {
+		if visit.UserID == userID && visit.VisitDate.After(time.Now()) && visit.VisitDate.Before(beforeDate) {
+			visits = append(visits, visit)
+		}
+	}
+	return visits, nil
+}
+
+func (r memVisits) Update(ctx context.Context, visit *doctorvisit.DoctorVisit) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.visits[visit.ID] = visit
+	return nil
+}
+
+type memReminders struct {
+	reminder.Repository
+	s *store
+}
+
+func (r memReminders) Create(ctx context.Context, rem *reminder.Reminder) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.reminders[rem.ID] = rem
+	return nil
+}
+
+func (r memReminders) GetByID(ctx context.Context, id uuid.UUID) (*reminder.Reminder, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	rem, ok := r.s.reminders[id]
+	if !ok {
+		return nil, reminder.ErrReminderNotFound
+	}
+	return rem, nil
+}
+
+func (r memReminders) DeletePendingByRelated(ctx context.Context, reminderType reminder.Type, relatedID uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for id, rem := range r.s.reminders {
+		if rem.Type == reminderType && rem.RelatedID != nil && *rem.RelatedID == relatedID && !rem.IsSent {
+			delete(r.s.reminders, id)
+		}
+	}
+	return nil
+}
+
+type memMilestones struct {
+	engagement.MilestoneRepository
+}
+
+func (memMilestones) Record(ctx context.Context, milestone *engagement.Milestone) (bool, error) {
+	return false, nil
+}
+
+type memAudit struct {
+	audit.Repository
+	s *store
+}
+
+func (r memAudit) Create(ctx context.Context, entry *audit.Entry) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.audit = append(r.s.audit, entry)
+	return nil
+}
+
+type memFiles struct {
+	s *store
+}
+
+func (f memFiles) Open(ctx context.Context, fileURL string) (io.ReadCloser, error) {
+	f.s.mu.Lock()
+	defer f.s.mu.Unlock()
+	return io.NopCloser(bytes.NewReader(f.s.files[fileURL])), nil
+}
+
+func (f memFiles) Save(ctx context.Context, key string, r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	f.s.mu.Lock()
+	defer f.s.mu.Unlock()
+	f.s.files[key] = data
+	return key, nil
+}
+
+func (f memFiles) Delete(ctx context.Context, fileURL string) error {
+	f.s.mu.Lock()
+	defer f.s.mu.Unlock()
+	delete(f.s.files, fileURL)
+	return nil
+}
+
+type memUpdates struct {
+	s *store
+}
+
+func (r memUpdates) Claim(ctx context.Context, updateID int64) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if r.s.updates[updateID] {
+		return false, nil
+	}
+	r.s.updates[updateID] = true
+	return true, nil
+}
+
+func (r memUpdates) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+// sentMessage — сообщение, отправленное ботом в чат
+type sentMessage struct {
+	chatID  int64
+	message notification.Message
+}
+
+// fakeMessenger запоминает ответы бота вместо вызова Bot API
+type fakeMessenger struct {
+	mu        sync.Mutex
+	sent      []sentMessage
+	answers   []string
+	downloads []string
+}
+
+func (m *fakeMessenger) Send(ctx context.Context, chatID int64, message notification.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, sentMessage{chatID: chatID, message: message})
+	return nil
+}
+
+func (m *fakeMessenger) AnswerCallbackQuery(ctx context.Context, callbackQueryID, text string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.answers = append(m.answers, text)
+	return nil
+}
+
+func (m *fakeMessenger) DownloadFile(ctx context.Context, fileID string) (io.ReadCloser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.downloads = append(m.downloads, fileID)
+	return io.NopCloser(strings.NewReader("content of " + fileID)), nil
+}
+
+// testEnv — бот с репозиториями в памяти и обработчик вебхука
+type testEnv struct {
+	s         *store
+	messenger *fakeMessenger
+	bot       *Bot
+	handler   http.Handler
+}
+
+// newTestEnv собирает бота из тех же use case, что и cmd/server
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	s := newStore()
+	users := memUsers{s: s}
+	symptoms := memSymptoms{s: s}
+	analyses := memAnalyses{s: s}
+	medications := memMedications{s: s}
+	intakes := memIntakes{s: s}
+	visits := memVisits{s: s}
+	reminders := memReminders{s: s}
+	files := memFiles{s: s}
+	messenger := &fakeMessenger{}
+
+	consents := consentapp.NewConsentUseCase(memConsents{s: s}, testPolicyVersion)
+	streaks := engagementapp.NewStreakService(users, symptoms, intakes, memMilestones{}, reminders)
+	sendReport := doctorvisitapp.NewSendReportUseCase(
+		doctorvisitapp.NewGenerateReportUseCase(visits, symptoms, analyses, medications, intakes, memCourses{}),
+		users, messenger)
+
+	b := NewBot(
+		users,
+		visits,
+		reminders,
+		memUpdates{s: s},
+		consents,
+		userapp.NewOnboardingUseCase(users, consents, symptoms, analyses, medications),
+		symptomapp.NewCreateSymptomUseCase(symptoms, streaks),
+		dashboardapp.NewGetDashboardUseCase(symptoms, analyses, medications, intakes, visits, streaks),
+		reminderapp.NewHandleActionUseCase(
+			reminders,
+			users,
+			reminderapp.NewSnoozeReminderUseCase(reminders, nil),
+			reminderapp.NewCompleteAnalysisReminderUseCase(analyses, reminders),
+			sendReport,
+			medicationapp.NewMarkIntakeUseCase(medications, intakes, reminders),
+		),
+		sendReport,
+		analysisapp.NewUploadAnalysisUseCase(analyses, files),
+		auditapp.NewRecordUseCase(memAudit{s: s}),
+		messenger,
+	)
+
+	return &testEnv{s: s, messenger: messenger, bot: b, handler: WebhookHandler(b, testSecret)}
+}
+
+// account создаёт аккаунт отправителя фикстур с пройденными шагами онбординга
+// и, если consented, согласием на действующую версию политики
+func (e *testEnv) account(t *testing.T, consented bool, completed ...user.OnboardingStep) *user.User {
+	t.Helper()
+
+	u := user.NewUser(testTelegramID, "Анна")
+	u.Onboarding.CompletedSteps = completed
+	e.s.users[u.ID] = u
+	if consented {
+		c, err := consent.NewConsent(u.ID, testPolicyVersion, consent.ChannelWebApp, "")
+		if err != nil {
+			t.Fatalf("NewConsent: %v", err)
+		}
+		e.s.consents = append(e.s.consents, c)
+	}
+	return u
+}
+
+// fixture читает обновление из testdata
+func fixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	raw, err := os.ReadFile(filepath.Join("testdata", name+".json"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	return raw
+}
+
+// post отправляет тело в обработчик вебхука с секретом secret
+func (e *testEnv) post(body []byte, secret string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, WebhookPath, bytes.NewReader(body))
+	if secret != "" {
+		req.Header.Set(secretTokenHeader, secret)
+	}
+	rec := httptest.NewRecorder()
+	e.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// replay доставляет фикстуру через вебхук и проверяет ответ 200
+func (e *testEnv) replay(t *testing.T, name string) {
+	t.Helper()
+
+	if rec := e.post(fixture(t, name), testSecret); rec.Code != http.StatusOK {
+		t.Fatalf("%s: status = %d, want %d", name, rec.Code, http.StatusOK)
+	}
+}
+
+// lastText возвращает текст последнего сообщения в чат отправителя фикстур
+func (e *testEnv) lastText(t *testing.T) string {
+	t.Helper()
+
+	if len(e.messenger.sent) == 0 {
+		t.Fatal("bot sent no messages")
+	}
+	last := e.messenger.sent[len(e.messenger.sent)-1]
+	if last.chatID != testTelegramID {
+		t.Fatalf("message sent to chat %d, want %d", last.chatID, testTelegramID)
+	}
+	return last.message.Text
+}
+
+// auditOperations возвращает операции, записанные в журнал аудита
+func (e *testEnv) auditOperations() []string {
+	operations := make([]string, len(e.s.audit))
+	for i, entry := range e.s.audit {
+		operations[i] = entry.Operation
+	}
+	return operations
+}
+
+func TestStartCreatesAccountAndAsksConsent(t *testing.T) {
+	e := newTestEnv(t)
+
+	e.replay(t, "start")
+
+	if len(e.s.users) != 1 {
+		t.Fatalf("users = %d, want 1", len(e.s.users))
+	}
+	for _, u := range e.s.users {
+		if u.TelegramUserID != testTelegramID || u.Name != "Анна" {
+			t.Errorf("user = %d %q, want %d %q", u.TelegramUserID, u.Name, testTelegramID, "Анна")
+		}
+	}
+
+	if len(e.messenger.sent) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(e.messenger.sent))
+	}
+	buttons := e.messenger.sent[0].message.Buttons
+	if len(buttons) != 1 || buttons[0].CallbackData != "consent:accept:1" {
+		t.Errorf("buttons = %+v, want consent:accept:1", buttons)
+	}
+}
+
+func TestConsentCallbackAcceptsPolicy(t *testing.T) {
+	e := newTestEnv(t)
+	u := e.account(t, false)
+
+	e.replay(t, "consent_callback")
+
+	if len(e.s.consents) != 1 {
+		t.Fatalf("consents = %d, want 1", len(e.s.consents))
+	}
+	c := e.s.consents[0]
+	sum := sha256.Sum256(fixture(t, "consent_callback"))
+	if c.Channel != consent.ChannelBot || c.PolicyVersion != testPolicyVersion || c.EvidenceHash != hex.EncodeToString(sum[:]) {
+		t.Errorf("consent = %s %s %s, want bot consent to version 1 with update hash", c.Channel, c.PolicyVersion, c.EvidenceHash)
+	}
+
+	if step := e.s.users[u.ID].Onboarding.CurrentStep(); step == nil || *step != user.OnboardingStepProfile {
+		t.Errorf("onboarding step = %v, want profile", step)
+	}
+	if len(e.messenger.answers) != 1 || e.messenger.answers[0] != "Спасибо, согласие принято" {
+		t.Errorf("callback answers = %q", e.messenger.answers)
+	}
+	if text := e.lastText(t); !strings.Contains(text, "Укажите возраст и пол") {
+		t.Errorf("reply = %q, want profile hint", text)
+	}
+}
+
+func TestTodayRendersPlan(t *testing.T) {
+	e := newTestEnv(t)
+	e.account(t, true, user.OnboardingStepConsent, user.OnboardingStepProfile, user.OnboardingStepFirstAction)
+
+	e.replay(t, "today")
+
+	if text := e.lastText(t); !strings.Contains(text, "На сегодня приёмов лекарств нет.") {
+		t.Errorf("reply = %q, want empty plan", text)
+	}
+	if ops := e.auditOperations(); len(ops) != 1 || ops[0] != "botToday" {
+		t.Errorf("audit = %q, want botToday", ops)
+	}
+}
+
+func TestLogCreatesEntryAndCompletesFirstAction(t *testing.T) {
+	e := newTestEnv(t)
+	u := e.account(t, true, user.OnboardingStepConsent, user.OnboardingStepProfile)
+
+	e.replay(t, "log")
+
+	if len(e.s.symptoms) != 1 {
+		t.Fatalf("symptom entries = %d, want 1", len(e.s.symptoms))
+	}
+	entry := e.s.symptoms[0]
+	if entry.UserID != u.ID || entry.Description != "болит голова после обеда" || entry.WellbeingScale != 4 {
+		t.Errorf("entry = %q %d, want «болит голова после обеда» 4", entry.Description, entry.WellbeingScale)
+	}
+	if text := e.lastText(t); text != "Записал: «болит голова после обеда», самочувствие 4 из 10." {
+		t.Errorf("reply = %q", text)
+	}
+	if !e.s.users[u.ID].Onboarding.IsCompleted() {
+		t.Error("onboarding first action is not completed after /log")
+	}
+}
+
+func TestLogWithoutConsentAsksConsent(t *testing.T) {
+	e := newTestEnv(t)
+	e.account(t, false)
+
+	e.replay(t, "log")
+
+	if len(e.s.symptoms) != 0 {
+		t.Errorf("symptom entries = %d, want 0", len(e.s.symptoms))
+	}
+	if len(e.messenger.sent) != 1 || len(e.messenger.sent[0].message.Buttons) != 1 {
+		t.Errorf("sent = %+v, want consent request", e.messenger.sent)
+	}
+}
+
+func TestRedeliveredUpdateIsHandledOnce(t *testing.T) {
+	e := newTestEnv(t)
+	e.account(t, true, user.OnboardingStepConsent, user.OnboardingStepProfile, user.OnboardingStepFirstAction)
+
+	e.replay(t, "log")
+	e.replay(t, "log")
+	e.replay(t, "document")
+	e.replay(t, "document")
+
+	if len(e.s.symptoms) != 1 {
+		t.Errorf("symptom entries = %d, want 1", len(e.s.symptoms))
+	}
+	if len(e.s.analyses) != 1 {
+		t.Errorf("analyses = %d, want 1", len(e.s.analyses))
+	}
+	if len(e.messenger.sent) != 2 {
+		t.Errorf("sent %d messages, want 2", len(e.messenger.sent))
+	}
+}
+
+func TestReportSendsUpcomingVisitReport(t *testing.T) {
+	e := newTestEnv(t)
+	u := e.account(t, true, user.OnboardingStepConsent, user.OnboardingStepProfile, user.OnboardingStepFirstAction)
+	visit := doctorvisit.NewDoctorVisit(u.ID, time.Now().Add(72*time.Hour))
+	e.s.visits[visit.ID] = visit
+
+	e.replay(t, "report")
+
+	if len(e.messenger.sent) == 0 {
+		t.Fatal("report was not sent")
+	}
+	if visit.ReportGeneratedAt == nil {
+		t.Error("visit report was not generated")
+	}
+	if ops := e.auditOperations(); len(ops) != 1 || ops[0] != "botReport" {
+		t.Errorf("audit = %q, want botReport", ops)
+	}
+}
+
+func TestReportWithoutVisits(t *testing.T) {
+	e := newTestEnv(t)
+	e.account(t, true, user.OnboardingStepConsent, user.OnboardingStepProfile, user.OnboardingStepFirstAction)
+
+	e.replay(t, "report")
+
+	if text := e.lastText(t); !strings.HasPrefix(text, "Запланированных визитов к врачу нет.") {
+		t.Errorf("reply = %q", text)
+	}
+}
+
+func TestReminderCallbackMarksIntakeTaken(t *testing.T) {
+	e := newTestEnv(t)
+	u := e.account(t, true, user.OnboardingStepConsent, user.OnboardingStepProfile, user.OnboardingStepFirstAction)
+
+	med := medication.NewMedication(u.ID, "Ибупрофен", "200 мг", medication.ScheduleTypeDaily,
+		medication.ScheduleDetails{Times: []string{"09:00"}}, time.Now().AddDate(0, 0, -1))
+	e.s.medications[med.ID] = med
+	intake := medication.NewMedicationIntake(med.ID, time.Now().Add(-time.Hour))
+	e.s.intakes[intake.ID] = intake
+	rem := reminder.NewReminder(u.ID, reminder.TypeMedication, &intake.ID, intake.ScheduledTime, "Пора принять Ибупрофен, 200 мг")
+	rem.ID = uuid.MustParse(testReminderID)
+	rem.MarkSent()
+	e.s.reminders[rem.ID] = rem
+
+	e.replay(t, "reminder_callback")
+
+	if !e.s.intakes[intake.ID].IsTaken() {
+		t.Error("intake is not marked taken")
+	}
+	if len(e.messenger.answers) != 1 || e.messenger.answers[0] != "Приём отмечен" {
+		t.Errorf("callback answers = %q", e.messenger.answers)
+	}
+	if ops := e.auditOperations(); len(ops) != 1 || ops[0] != "botReminderAction" {
+		t.Errorf("audit = %q, want botReminderAction", ops)
+	}
+}
+
+func TestReminderCallbackForUnknownReminder(t *testing.T) {
+	e := newTestEnv(t)
+	e.account(t, true, user.OnboardingStepConsent, user.OnboardingStepProfile, user.OnboardingStepFirstAction)
+
+	e.replay(t, "reminder_callback")
+
+	if len(e.messenger.answers) != 1 || e.messenger.answers[0] != "Напоминание не найдено" {
+		t.Errorf("callback answers = %q", e.messenger.answers)
+	}
+}
+
+func TestUploadsSaveAnalyses(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		fileID   string
+		name     string
+		fileType analysis.FileType
+		ext      string
+	}{
+		{
+			fixture:  "document",
+			fileID:   "BQACAgIAAxkBAAIBCGjzQ1Xk9mVbX2o3Yw8fJ2pQe4L1AAKwYAACq7yZSzq1d2lH8cW2NgQ",
+			name:     "Общий анализ крови",
+			fileType: analysis.FileTypePDF,
+			ext:      ".pdf",
+		},
+		{
+			// Из нескольких размеров фото скачивается наибольший
+			fixture:  "photo",
+			fileID:   "AgACAgIAAxkBAAIBCWjzQ2h1u8k9pQ0sXn4rT7fYc2LdAAJ6ZDIbq7yZS1b0e3Fv6QwSAQADAgADeQADNgQ",
+			name:     "Биохимия, лист 1",
+			fileType: analysis.FileTypeImage,
+			ext:      ".jpg",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			e := newTestEnv(t)
+			u := e.account(t, true, user.OnboardingStepConsent, user.OnboardingStepProfile)
+
+			e.replay(t, tt.fixture)
+
+			if len(e.messenger.downloads) != 1 || e.messenger.downloads[0] != tt.fileID {
+				t.Errorf("downloads = %q, want %s", e.messenger.downloads, tt.fileID)
+			}
+			if len(e.s.analyses) != 1 {
+				t.Fatalf("analyses = %d, want 1", len(e.s.analyses))
+			}
+			a := e.s.analyses[0]
+			if a.UserID != u.ID || a.Name != tt.name || a.Type != analysis.TypeOther || a.FileType != tt.fileType {
+				t.Errorf("analysis = %q %s %s, want %q other %s", a.Name, a.Type, a.FileType, tt.name, tt.fileType)
+			}
+			if !strings.HasSuffix(a.FileURL, tt.ext) || string(e.s.files[a.FileURL]) != "content of "+tt.fileID {
+				t.Errorf("file %q is not saved with extension %s", a.FileURL, tt.ext)
+			}
+			if !e.s.users[u.ID].Onboarding.IsCompleted() {
+				t.Error("onboarding first action is not completed after upload")
+			}
+			if text := e.lastText(t); !strings.HasPrefix(text, "Сохранил анализ «"+tt.name+"»") {
+				t.Errorf("reply = %q", text)
+			}
+		})
+	}
+}
+
+func TestWebhookRejectsWrongSecret(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret string
+	}{
+		{name: "missing", secret: ""},
+		{name: "wrong", secret: "not-the-secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestEnv(t)
+
+			if rec := e.post(fixture(t, "start"), tt.secret); rec.Code != http.StatusForbidden {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusForbidden)
+			}
+			if len(e.s.users) != 0 || len(e.messenger.sent) != 0 || len(e.s.updates) != 0 {
+				t.Error("update was handled without a valid secret")
+			}
+		})
+	}
+}
+
+func TestWebhookRejectsMalformedUpdate(t *testing.T) {
+	e := newTestEnv(t)
+
+	if rec := e.post([]byte("{not json"), testSecret); rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestWebhookWithoutConfiguredSecret(t *testing.T) {
+	e := newTestEnv(t)
+	handler := WebhookHandler(e.bot, "")
+
+	req := httptest.NewRequest(http.MethodPost, WebhookPath, bytes.NewReader(fixture(t, "start")))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
@@ -0,0 +1,119 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	reminderapp "github.com/health-hub-bot-api/internal/application/reminder"
+	"github.com/health-hub-bot-api/internal/domain/audit"
+	"github.com/health-hub-bot-api/internal/domain/consent"
+	"github.com/health-hub-bot-api/internal/domain/reminder"
+	"github.com/health-hub-bot-api/internal/domain/user"
+	"github.com/health-hub-bot-api/internal/infrastructure/telegram"
+)
+
+// consentCallbackPrefix отличает кнопку согласия от кнопок напоминаний ("rem:...")
+const consentCallbackPrefix = "consent:accept:"
+
+// consentCallbackData кодирует принятие версии политики для inline-кнопки
+func consentCallbackData(policyVersion string) string {
+	return consentCallbackPrefix + policyVersion
+}
+
+// reminderActionAnswers — ответы на кнопки напоминаний при ожидаемых ошибках
+var reminderActionAnswers = map[error]string{
+	reminder.ErrInvalidCallbackData: "Кнопка устарела",
+	reminder.ErrReminderNotFound:    "Напоминание не найдено",
+	reminder.ErrUnauthorized:        "Напоминание не найдено",
+	reminder.ErrUnsupportedAction:   "Это действие недоступно",
+}
+
+// handleCallback обрабатывает нажатие inline-кнопки. На каждое нажатие
+// отправляется ответ, иначе клиент Telegram показывает индикатор загрузки.
+func (b *Bot) handleCallback(ctx context.Context, query *telegram.CallbackQuery, evidence string) error {
+	if query.From.IsBot || query.Message == nil || query.Message.Chat.Type != privateChat {
+		return b.messenger.AnswerCallbackQuery(ctx, query.ID, "")
+	}
+	chatID := query.Message.Chat.ID
+
+	u, err := b.account(ctx, chatID, query.From)
+	if err == nil && u != nil {
+		var answer string
+		if version, ok := strings.CutPrefix(query.Data, consentCallbackPrefix); ok {
+			answer, err = b.acceptConsent(ctx, u, chatID, version, evidence)
+		} else {
+			answer, err = b.reminderAction(ctx, u, chatID, query.Data)
+		}
+		if err == nil {
+			return b.messenger.AnswerCallbackQuery(ctx, query.ID, answer)
+		}
+	}
+
+	if answerErr := b.messenger.AnswerCallbackQuery(ctx, query.ID, failureText); answerErr != nil {
+		log.Printf("bot: failed to answer callback query: %v", answerErr)
+	}
+	return err
+}
+
+// acceptConsent принимает политику по кнопке в боте, закрывает шаг онбординга
+// «согласие» и присылает подсказку к следующему шагу
+func (b *Bot) acceptConsent(ctx context.Context, u *user.User, chatID int64, policyVersion, evidence string) (string, error) {
+	status, err := b.consents.Accept(ctx, u.ID, policyVersion, consent.ChannelBot, evidence)
+	if errors.Is(err, consent.ErrPolicyVersionMismatch) {
+		return "Политика обновилась, отправьте /start ещё раз", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	b.recordChange(ctx, u.ID, u.ID, audit.ActionCreate, "botAcceptConsent", audit.EntityConsent, &status.Consent.ID,
+		[]string{"channel", "policyVersion"})
+
+	// Повторное согласие после смены политики не меняет пройденный онбординг
+	if step := u.Onboarding.CurrentStep(); step != nil && *step == user.OnboardingStepConsent {
+		if u, err = b.onboarding.Execute(ctx, u.ID, user.OnboardingStepConsent, false); err != nil {
+			return "", err
+		}
+	}
+	return "Спасибо, согласие принято", b.start(ctx, u, chatID)
+}
+
+// reminderAction выполняет действие кнопки напоминания через тот же use case,
+// что и мутации GraphQL, и возвращает короткий ответ для пользователя
+func (b *Bot) reminderAction(ctx context.Context, u *user.User, chatID int64, data string) (string, error) {
+	action, reminderID, err := reminder.ParseCallbackData(data)
+	if err != nil {
+		return reminderActionAnswers[reminder.ErrInvalidCallbackData], nil
+	}
+	// Запись самочувствия в боте делается командой /log
+	if action == reminder.ActionLogSymptom {
+		return "", b.reply(ctx, chatID, logUsageText)
+	}
+	if ok, err := b.requireConsent(ctx, u, chatID); !ok {
+		return "", err
+	}
+
+	answer, err := b.handleAction.Execute(ctx, reminderapp.HandleActionInput{
+		UserID:     u.ID,
+		ReminderID: reminderID,
+		Action:     action,
+	})
+	for known, text := range reminderActionAnswers {
+		if errors.Is(err, known) {
+			return text, nil
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// Напоминание может относиться к подопечному профилю: запись журнала — на его имя
+	ownerID := u.ID
+	if rem, err := b.reminderRepo.GetByID(ctx, reminderID); err == nil {
+		ownerID = rem.UserID
+	}
+	b.recordChange(ctx, u.ID, ownerID, audit.ActionUpdate, "botReminderAction", audit.EntityReminder, &reminderID,
+		[]string{"action"})
+	return answer, nil
+}
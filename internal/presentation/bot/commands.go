@@ -0,0 +1,246 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	dashboardapp "github.com/health-hub-bot-api/internal/application/dashboard"
+	doctorvisitapp "github.com/health-hub-bot-api/internal/application/doctorvisit"
+	symptomapp "github.com/health-hub-bot-api/internal/application/symptom"
+	"github.com/health-hub-bot-api/internal/domain/audit"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/notification"
+	"github.com/health-hub-bot-api/internal/domain/symptom"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+// Команды бота
+const (
+	commandStart  = "/start"
+	commandToday  = "/today"
+	commandLog    = "/log"
+	commandReport = "/report"
+)
+
+const (
+	// reportVisitsHorizon — насколько вперёд /report ищет ближайший визит к врачу
+	reportVisitsHorizon = 90 * 24 * time.Hour
+
+	dateLayout     = "02.01.2006"
+	dateTimeLayout = "02.01.2006 15:04"
+	timeLayout     = "15:04"
+)
+
+const (
+	helpText = "Что я умею:\n" +
+		"/today — план приёма лекарств на сегодня и ближайший визит\n" +
+		"/log <описание> <самочувствие 1–10> — записать самочувствие\n" +
+		"/report — прислать отчёт к ближайшему визиту к врачу\n" +
+		"Пришлите PDF или фото результатов — я сохраню их как анализ."
+
+	logUsageText = "Формат: /log <описание> <самочувствие от 1 до 10>\nНапример: /log болит голова 4"
+
+	failureText = "Не получилось выполнить запрос, попробуйте позже."
+)
+
+// intakeStatusLabels — подписи статусов приёма в плане на сегодня
+var intakeStatusLabels = map[medication.IntakeStatus]string{
+	medication.IntakeStatusPlanned: "запланирован",
+	medication.IntakeStatusTaken:   "принят",
+	medication.IntakeStatusSkipped: "пропущен",
+	medication.IntakeStatusMissed:  "не отмечен",
+	medication.IntakeStatusLate:    "принят с опозданием",
+}
+
+// parseCommand выделяет команду и её аргументы из текста сообщения.
+// Упоминание бота в команде (/today@health_bot) отбрасывается.
+func parseCommand(text string) (string, string) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "/") {
+		return "", text
+	}
+	command, args := text, ""
+	if i := strings.IndexFunc(text, unicode.IsSpace); i >= 0 {
+		command, args = text[:i], text[i:]
+	}
+	command, _, _ = strings.Cut(command, "@")
+	return strings.ToLower(command), strings.TrimSpace(args)
+}
+
+// start приветствует пользователя и подсказывает текущий шаг онбординга
+func (b *Bot) start(ctx context.Context, u *user.User, chatID int64) error {
+	if ok, err := b.requireConsent(ctx, u, chatID); !ok {
+		return err
+	}
+
+	step := u.Onboarding.CurrentStep()
+	switch {
+	case step != nil && *step == user.OnboardingStepProfile:
+		return b.reply(ctx, chatID, fmt.Sprintf("Здравствуйте, %s! Укажите возраст и пол в профиле приложения — "+
+			"так отчёты для врача будут точнее.\n\n%s", u.Name, helpText))
+	case step != nil && *step == user.OnboardingStepFirstAction:
+		return b.reply(ctx, chatID, fmt.Sprintf("Здравствуйте, %s! Начните с первой записи: "+
+			"отправьте /log с описанием самочувствия или пришлите файл анализа.\n\n%s", u.Name, helpText))
+	default:
+		return b.reply(ctx, chatID, fmt.Sprintf("Здравствуйте, %s!\n\n%s", u.Name, helpText))
+	}
+}
+
+// today присылает план приёма лекарств на сегодня, ближайший визит и серию дней дневника
+func (b *Bot) today(ctx context.Context, u *user.User, chatID int64) error {
+	d, err := b.dashboard.Execute(ctx, dashboardapp.GetDashboardInput{
+		UserID:        u.ID,
+		RecentLimit:   1,
+		WellbeingDays: 1,
+		Location:      u.Location(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if ok, err := b.recordRead(ctx, u, chatID, "botToday", audit.EntityDashboard, nil); !ok {
+		return err
+	}
+	return b.reply(ctx, chatID, renderToday(d, u.Location()))
+}
+
+// logSymptom создаёт запись самочувствия из аргументов "<описание> <оценка>"
+func (b *Bot) logSymptom(ctx context.Context, u *user.User, chatID int64, args string) error {
+	description, scale, ok := parseLogArgs(args)
+	if !ok {
+		return b.reply(ctx, chatID, logUsageText)
+	}
+	if ok, err := b.requireConsent(ctx, u, chatID); !ok {
+		return err
+	}
+
+	entry, err := b.createSymptom.Execute(ctx, symptomapp.CreateSymptomInput{
+		UserID:         u.ID,
+		DateTime:       time.Now(),
+		Description:    description,
+		WellbeingScale: scale,
+	})
+	if errors.Is(err, symptom.ErrInvalidWellbeingScale) {
+		return b.reply(ctx, chatID, logUsageText)
+	}
+	if err != nil {
+		return err
+	}
+
+	b.recordChange(ctx, u.ID, u.ID, audit.ActionCreate, "botLog", audit.EntitySymptomEntry, &entry.ID,
+		[]string{"description", "wellbeingScale"})
+	b.completeFirstAction(ctx, u)
+	return b.reply(ctx, chatID, fmt.Sprintf("Записал: «%s», самочувствие %d из 10.", entry.Description, entry.WellbeingScale))
+}
+
+// parseLogArgs разбирает аргументы /log: оценка самочувствия — последнее слово
+func parseLogArgs(args string) (string, int, bool) {
+	i := strings.LastIndexAny(args, " \t\n")
+	if i < 0 {
+		return "", 0, false
+	}
+	scale, err := strconv.Atoi(args[i+1:])
+	if err != nil {
+		return "", 0, false
+	}
+	description := strings.TrimSpace(args[:i])
+	if description == "" {
+		return "", 0, false
+	}
+	return description, scale, true
+}
+
+// report отправляет отчёт к ближайшему визиту к врачу
+func (b *Bot) report(ctx context.Context, u *user.User, chatID int64) error {
+	if ok, err := b.requireConsent(ctx, u, chatID); !ok {
+		return err
+	}
+
+	visits, err := b.doctorVisitRepo.GetUpcomingVisits(ctx, u.ID, time.Now().Add(reportVisitsHorizon))
+	if err != nil {
+		return err
+	}
+	if len(visits) == 0 {
+		return b.reply(ctx, chatID, "Запланированных визитов к врачу нет. Добавьте визит в приложении, и я подготовлю отчёт к нему.")
+	}
+	visit := visits[0]
+
+	if ok, err := b.recordRead(ctx, u, chatID, "botReport", audit.EntityDoctorVisitReport, &visit.ID); !ok {
+		return err
+	}
+	return b.sendReport.Execute(ctx, doctorvisitapp.SendReportInput{VisitID: visit.ID, UserID: u.ID})
+}
+
+// askConsent присылает текст согласия с кнопкой принятия текущей версии политики
+func (b *Bot) askConsent(ctx context.Context, u *user.User, chatID int64) error {
+	status, err := b.consents.Status(ctx, u.ID)
+	if err != nil {
+		return err
+	}
+	return b.messenger.Send(ctx, chatID, notification.Message{
+		Text: fmt.Sprintf("Здравствуйте, %s! Чтобы вести дневник здоровья, нужно ваше согласие на обработку "+
+			"персональных данных, включая сведения о здоровье (политика версии %s). "+
+			"Текст политики доступен в приложении.", u.Name, status.PolicyVersion),
+		Buttons: []notification.Button{{Text: "Принимаю", CallbackData: consentCallbackData(status.PolicyVersion)}},
+	})
+}
+
+// renderToday формирует текст плана на сегодня во временной зоне пользователя
+func renderToday(d *dashboardapp.Dashboard, loc *time.Location) string {
+	type line struct {
+		at   time.Time
+		text string
+	}
+	var lines []line
+	for _, item := range d.TodayIntakes {
+		for _, intake := range item.Intakes {
+			lines = append(lines, line{
+				at: intake.ScheduledTime,
+				text: fmt.Sprintf("• %s — %s, %s (%s)", intake.ScheduledTime.In(loc).Format(timeLayout),
+					item.Medication.Name, item.Medication.Dosage, intakeStatusLabels[intake.Status]),
+			})
+		}
+	}
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].at.Before(lines[j].at) })
+
+	var b strings.Builder
+	if len(lines) == 0 {
+		b.WriteString("На сегодня приёмов лекарств нет.\n")
+	} else {
+		b.WriteString("Приёмы лекарств на сегодня:\n")
+		for _, l := range lines {
+			b.WriteString(l.text + "\n")
+		}
+	}
+
+	if v := d.NextDoctorVisit; v != nil {
+		fmt.Fprintf(&b, "\nБлижайший визит: %s", v.VisitDate.In(loc).Format(dateTimeLayout))
+		if doctor := visitDoctor(v.Specialty, v.DoctorName); doctor != "" {
+			b.WriteString(" — " + doctor)
+		}
+		b.WriteString("\n")
+	}
+
+	if d.Stats.DiaryStreak > 0 {
+		fmt.Fprintf(&b, "\nДней подряд с записями в дневнике: %d\n", d.Stats.DiaryStreak)
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// visitDoctor возвращает специальность и имя врача, если они указаны
+func visitDoctor(specialty, name *string) string {
+	var parts []string
+	for _, value := range []*string{specialty, name} {
+		if value != nil && *value != "" {
+			parts = append(parts, *value)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
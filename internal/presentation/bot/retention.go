@@ -0,0 +1,41 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/health-hub-bot-api/internal/domain/notification"
+)
+
+// updateRetention — сколько хранится отметка о принятом обновлении. Telegram
+// хранит недоставленные обновления не дольше суток, поэтому двух суток достаточно.
+const updateRetention = 48 * time.Hour
+
+// UpdateRetention удаляет отметки о принятых обновлениях, повторная доставка
+// которых уже невозможна
+type UpdateRetention struct {
+	updates notification.UpdateRepository
+}
+
+// NewUpdateRetention создаёт задачу очистки отметок о принятых обновлениях
+func NewUpdateRetention(updates notification.UpdateRepository) *UpdateRetention {
+	return &UpdateRetention{updates: updates}
+}
+
+// Name возвращает имя задачи для планировщика
+func (r *UpdateRetention) Name() string {
+	return "bot-update-retention"
+}
+
+// Run удаляет устаревшие отметки
+func (r *UpdateRetention) Run(ctx context.Context) error {
+	deleted, err := r.updates.DeleteBefore(ctx, time.Now().Add(-updateRetention))
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		log.Printf("bot: deleted %d expired update marks", deleted)
+	}
+	return nil
+}
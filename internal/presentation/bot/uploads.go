@@ -0,0 +1,122 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	analysisapp "github.com/health-hub-bot-api/internal/application/analysis"
+	"github.com/health-hub-bot-api/internal/domain/analysis"
+	"github.com/health-hub-bot-api/internal/domain/audit"
+	"github.com/health-hub-bot-api/internal/domain/user"
+	"github.com/health-hub-bot-api/internal/infrastructure/telegram"
+)
+
+// maxUploadSize — предел размера файла, который Bot API позволяет скачать
+const maxUploadSize = 20 << 20
+
+const (
+	unsupportedFileText = "Я сохраняю результаты анализов в PDF, JPG или PNG. Пришлите файл в одном из этих форматов."
+	fileTooLargeText    = "Файл больше 20 МБ, его не получится сохранить через бота. Загрузите его в приложении."
+)
+
+// upload описывает присланный файл анализа
+type upload struct {
+	fileID   string
+	fileName string
+	fileType analysis.FileType
+	ext      string
+	size     int64
+}
+
+// imageExts — расширения изображений по MIME-типу документа
+var imageExts = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
+
+// uploadFromMessage определяет файл анализа во вложении; для фото выбирается
+// наибольший размер. Возвращает false для неподдерживаемых форматов.
+func uploadFromMessage(msg *telegram.Message) (upload, bool) {
+	if doc := msg.Document; doc != nil {
+		u := upload{fileID: doc.FileID, fileName: doc.FileName, size: doc.FileSize}
+		switch ext, isImage := imageExts[doc.MimeType]; {
+		case doc.MimeType == "application/pdf":
+			u.fileType, u.ext = analysis.FileTypePDF, ".pdf"
+		case isImage:
+			u.fileType, u.ext = analysis.FileTypeImage, ext
+		default:
+			return upload{}, false
+		}
+		return u, true
+	}
+
+	if len(msg.Photo) == 0 {
+		return upload{}, false
+	}
+	largest := msg.Photo[0]
+	for _, size := range msg.Photo[1:] {
+		if size.Width*size.Height > largest.Width*largest.Height {
+			largest = size
+		}
+	}
+	// Telegram пережимает фото в JPEG
+	return upload{fileID: largest.FileID, fileType: analysis.FileTypeImage, ext: ".jpg", size: largest.FileSize}, true
+}
+
+// analysisName возвращает название анализа: подпись к файлу, имя файла или дату загрузки
+func analysisName(msg *telegram.Message, file upload, takenAt time.Time) string {
+	if caption := strings.TrimSpace(msg.Caption); caption != "" {
+		return caption
+	}
+	if name := strings.TrimSuffix(file.fileName, path.Ext(file.fileName)); name != "" {
+		return name
+	}
+	return "Анализ от " + takenAt.Format(dateLayout)
+}
+
+// handleUpload сохраняет присланный документ или фото как анализ типа «другое»
+// с сегодняшней датой; тип и дату пользователь уточняет в приложении
+func (b *Bot) handleUpload(ctx context.Context, u *user.User, msg *telegram.Message) error {
+	file, ok := uploadFromMessage(msg)
+	if !ok {
+		return b.reply(ctx, msg.Chat.ID, unsupportedFileText)
+	}
+	if file.size > maxUploadSize {
+		return b.reply(ctx, msg.Chat.ID, fileTooLargeText)
+	}
+	if ok, err := b.requireConsent(ctx, u, msg.Chat.ID); !ok {
+		return err
+	}
+
+	body, err := b.messenger.DownloadFile(ctx, file.fileID)
+	if errors.Is(err, telegram.ErrFileTooLarge) {
+		return b.reply(ctx, msg.Chat.ID, fileTooLargeText)
+	}
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	takenAt := time.Now().In(u.Location())
+	a, err := b.uploadAnalysis.Execute(ctx, analysisapp.UploadAnalysisInput{
+		UserID:    u.ID,
+		Type:      analysis.TypeOther,
+		Name:      analysisName(msg, file, takenAt),
+		DateTaken: takenAt,
+		FileType:  file.fileType,
+		Ext:       file.ext,
+		File:      body,
+	})
+	if err != nil {
+		return err
+	}
+
+	b.recordChange(ctx, u.ID, u.ID, audit.ActionCreate, "botUpload", audit.EntityAnalysis, &a.ID,
+		[]string{"file", "name"})
+	b.completeFirstAction(ctx, u)
+	return b.reply(ctx, msg.Chat.ID, fmt.Sprintf("Сохранил анализ «%s». Тип и дату сдачи можно уточнить в приложении.", a.Name))
+}
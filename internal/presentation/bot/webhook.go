@@ -0,0 +1,46 @@
+package bot
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+)
+
+// WebhookPath — адрес, на который Telegram присылает обновления бота
+const WebhookPath = "/telegram/webhook"
+
+// secretTokenHeader — заголовок с секретом, заданным в setWebhook (secret_token)
+const secretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// maxUpdateSize ограничивает тело запроса с обновлением
+const maxUpdateSize = 1 << 20
+
+// WebhookHandler принимает обновления Telegram. Запросы без верного секрета
+// отклоняются; на обработанное обновление всегда отвечает 200, даже если
+// обработка не удалась, чтобы Telegram не присылал его повторно.
+func WebhookHandler(bot *Bot, secret string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		token := req.Header.Get(secretTokenHeader)
+		if secret == "" || subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		raw, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxUpdateSize))
+		if err != nil {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		if err := bot.HandleUpdate(req.Context(), raw); err != nil {
+			if errors.Is(err, ErrMalformedUpdate) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			log.Printf("bot: failed to handle update: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,215 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+
+	gqlgen "github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/graphql/generated"
+	auditapp "github.com/health-hub-bot-api/internal/application/audit"
+	consentapp "github.com/health-hub-bot-api/internal/application/consent"
+	sharingapp "github.com/health-hub-bot-api/internal/application/sharing"
+	"github.com/health-hub-bot-api/internal/domain/analysis"
+	"github.com/health-hub-bot-api/internal/domain/audit"
+	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+	"github.com/health-hub-bot-api/internal/domain/export"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/symptom"
+	"github.com/health-hub-bot-api/internal/domain/user"
+)
+
+// auditTarget описывает, как записать операцию API в журнал аудита
+type auditTarget struct {
+	action audit.Action
+	entity string
+	// idArg — аргумент с ID сущности
+	idArg string
+	// resultID извлекает ID сущности из результата, если его нет в аргументах
+	resultID func(any) *uuid.UUID
+}
+
+// auditedOperations перечисляет операции, которые записываются в журнал:
+// все мутации и чтения медицинских данных
+var auditedOperations = map[string]auditTarget{
+	// Чтения
+	"dashboard":          {action: audit.ActionRead, entity: audit.EntityDashboard},
+	"symptoms":           {action: audit.ActionRead, entity: audit.EntitySymptomEntry},
+	"symptom":            {action: audit.ActionRead, entity: audit.EntitySymptomEntry, idArg: "id"},
+	"symptomSuggestions": {action: audit.ActionRead, entity: audit.EntitySymptomEntry},
+
+	"analyses": {action: audit.ActionRead, entity: audit.EntityAnalysis},
+	"analysis": {action: audit.ActionRead, entity: audit.EntityAnalysis, idArg: "id"},
+
+	"medications":          {action: audit.ActionRead, entity: audit.EntityMedication},
+	"medication":           {action: audit.ActionRead, entity: audit.EntityMedication, idArg: "id"},
+	"medicationIntakes":    {action: audit.ActionRead, entity: audit.EntityMedicationIntake, idArg: "medicationId"},
+	"medicationCompliance": {action: audit.ActionRead, entity: audit.EntityMedication, idArg: "medicationId"},
+	"medicationHistory":    {action: audit.ActionRead, entity: audit.EntityMedication},
+
+	"doctorVisits":      {action: audit.ActionRead, entity: audit.EntityDoctorVisit},
+	"doctorVisit":       {action: audit.ActionRead, entity: audit.EntityDoctorVisit, idArg: "id"},
+	"doctorVisitReport": {action: audit.ActionRead, entity: audit.EntityDoctorVisitReport, idArg: "visitId"},
+
+	"symptomMedicationCorrelation": {action: audit.ActionRead, entity: audit.EntityMedication, idArg: "medicationId"},
+
+	// Профиль
+	"updateUserProfile":             {action: audit.ActionUpdate, entity: audit.EntityUser, resultID: resultID(func(u *user.User) uuid.UUID { return u.ID })},
+	"setTimezone":                   {action: audit.ActionUpdate, entity: audit.EntityUser, resultID: resultID(func(u *user.User) uuid.UUID { return u.ID })},
+	"updateNotificationPreferences": {action: audit.ActionUpdate, entity: audit.EntityUser, resultID: resultID(func(u *user.User) uuid.UUID { return u.ID })},
+	"createDependentProfile":        {action: audit.ActionCreate, entity: audit.EntityUser, resultID: resultID(func(u *user.User) uuid.UUID { return u.ID })},
+	"deleteDependentProfile":        {action: audit.ActionDelete, entity: audit.EntityUser, idArg: "id"},
+	"deleteAccount":                 {action: audit.ActionDelete, entity: audit.EntityUser},
+	"restoreAccount":                {action: audit.ActionUpdate, entity: audit.EntityUser, resultID: resultID(func(u *user.User) uuid.UUID { return u.ID })},
+	"acceptConsent":                 {action: audit.ActionCreate, entity: audit.EntityConsent, resultID: resultID(func(s *consentapp.Status) uuid.UUID { return s.Consent.ID })},
+	"withdrawConsent":               {action: audit.ActionUpdate, entity: audit.EntityConsent, resultID: resultID(func(s *consentapp.Status) uuid.UUID { return s.Consent.ID })},
+	"completeOnboardingStep":        {action: audit.ActionUpdate, entity: audit.EntityUser, resultID: resultID(func(u *user.User) uuid.UUID { return u.ID })},
+
+	// Напоминания
+	"snoozeReminder":           {action: audit.ActionUpdate, entity: audit.EntityReminder, idArg: "id"},
+	"completeAnalysisReminder": {action: audit.ActionUpdate, entity: audit.EntityAnalysis, idArg: "analysisId"},
+
+	// Симптомы
+	"createSymptomEntry": {action: audit.ActionCreate, entity: audit.EntitySymptomEntry, resultID: resultID(func(e *symptom.SymptomEntry) uuid.UUID { return e.ID })},
+	"updateSymptomEntry": {action: audit.ActionUpdate, entity: audit.EntitySymptomEntry, idArg: "id"},
+	"deleteSymptomEntry": {action: audit.ActionDelete, entity: audit.EntitySymptomEntry, idArg: "id"},
+
+	// Анализы
+	"createAnalysis": {action: audit.ActionCreate, entity: audit.EntityAnalysis, resultID: resultID(func(a *analysis.Analysis) uuid.UUID { return a.ID })},
+	"updateAnalysis": {action: audit.ActionUpdate, entity: audit.EntityAnalysis, idArg: "id"},
+	"deleteAnalysis": {action: audit.ActionDelete, entity: audit.EntityAnalysis, idArg: "id"},
+
+	// Лекарства
+	"createMedication":     {action: audit.ActionCreate, entity: audit.EntityMedication, resultID: resultID(func(m *medication.Medication) uuid.UUID { return m.ID })},
+	"updateMedication":     {action: audit.ActionUpdate, entity: audit.EntityMedication, idArg: "id"},
+	"deleteMedication":     {action: audit.ActionDelete, entity: audit.EntityMedication, idArg: "id"},
+	"markMedicationIntake": {action: audit.ActionCreate, entity: audit.EntityMedicationIntake, resultID: resultID(func(i *medication.MedicationIntake) uuid.UUID { return i.ID })},
+	"logAsNeededIntake":    {action: audit.ActionCreate, entity: audit.EntityMedicationIntake, resultID: resultID(func(r *generated.AsNeededIntakeResult) uuid.UUID { return r.Intake.ID })},
+	"setAsNeededLimits":    {action: audit.ActionUpdate, entity: audit.EntityMedication, idArg: "medicationId"},
+	"refillMedication":     {action: audit.ActionUpdate, entity: audit.EntityMedication, idArg: "medicationId"},
+	"stopMedication":       {action: audit.ActionUpdate, entity: audit.EntityMedication, idArg: "id"},
+
+	// Визиты к врачу
+	"createDoctorVisit":         {action: audit.ActionCreate, entity: audit.EntityDoctorVisit, resultID: resultID(func(v *doctorvisit.DoctorVisit) uuid.UUID { return v.ID })},
+	"updateDoctorVisit":         {action: audit.ActionUpdate, entity: audit.EntityDoctorVisit, idArg: "id"},
+	"deleteDoctorVisit":         {action: audit.ActionDelete, entity: audit.EntityDoctorVisit, idArg: "id"},
+	"generateDoctorVisitReport": {action: audit.ActionRead, entity: audit.EntityDoctorVisitReport, idArg: "visitId"},
+	"sendDoctorVisitReport":     {action: audit.ActionRead, entity: audit.EntityDoctorVisitReport, idArg: "visitId"},
+
+	// Доступы
+	"createShareGrant": {action: audit.ActionCreate, entity: audit.EntityShareGrant, resultID: resultID(func(r *sharingapp.CreateGrantResult) uuid.UUID { return r.Grant.ID })},
+	"revokeShareGrant": {action: audit.ActionUpdate, entity: audit.EntityShareGrant, idArg: "id"},
+	"createReportLink": {action: audit.ActionCreate, entity: audit.EntityReportLink, resultID: resultID(func(r *sharingapp.CreateReportLinkResult) uuid.UUID { return r.Link.ID })},
+	"revokeReportLink": {action: audit.ActionUpdate, entity: audit.EntityReportLink, idArg: "id"},
+
+	// Экспорт и импорт
+	"requestDataExport": {action: audit.ActionCreate, entity: audit.EntityDataExport, resultID: resultID(func(j *export.Job) uuid.UUID { return j.ID })},
+	"importData":        {action: audit.ActionCreate, entity: audit.EntityDataImport},
+}
+
+// resultID возвращает функцию, которая достаёт ID сущности из результата резолвера
+func resultID[T any](id func(*T) uuid.UUID) func(any) *uuid.UUID {
+	return func(result any) *uuid.UUID {
+		value, ok := result.(*T)
+		if !ok || value == nil {
+			return nil
+		}
+		entityID := id(value)
+		return &entityID
+	}
+}
+
+// currentActor определяет, кто выполняет запрос к данным профиля ownerID
+func currentActor(ctx context.Context, ownerID uuid.UUID) audit.Actor {
+	if grant := currentGrant(ctx); grant != nil {
+		grantID := grant.ID
+		if granteeID, _ := ctx.Value(granteeContextKey).(*uuid.UUID); granteeID != nil {
+			return audit.Actor{Kind: audit.ActorGrantee, UserID: granteeID, CredentialID: &grantID}
+		}
+		return audit.Actor{Kind: audit.ActorShareLink, CredentialID: &grantID}
+	}
+
+	accountID, err := currentAccountID(ctx)
+	if err != nil {
+		accountID = ownerID
+	}
+	if accountID != ownerID {
+		return audit.Actor{Kind: audit.ActorAccountHolder, UserID: &accountID}
+	}
+	return audit.Actor{Kind: audit.ActorOwner, UserID: &accountID}
+}
+
+// auditFields возвращает имена переданных аргументов и полей input без их значений
+func auditFields(args map[string]any, idArg string) []string {
+	var fields []string
+	for name, value := range args {
+		if name == idArg {
+			continue
+		}
+		if input, ok := value.(map[string]any); ok && name == "input" {
+			for field := range input {
+				fields = append(fields, field)
+			}
+			continue
+		}
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// AuditTrail записывает в журнал аудита каждую успешную мутацию и каждое чтение
+// медицинских данных из auditedOperations. Чтение без записи в журнал
+// не возвращает данные; ошибка записи после мутации только логируется,
+// так как изменение уже сохранено.
+func AuditTrail(record *auditapp.RecordUseCase) gqlgen.FieldMiddleware {
+	return func(ctx context.Context, next gqlgen.Resolver) (any, error) {
+		fc := gqlgen.GetFieldContext(ctx)
+		if fc == nil || (fc.Object != "Query" && fc.Object != "Mutation") {
+			return next(ctx)
+		}
+		target, ok := auditedOperations[fc.Field.Name]
+		if !ok {
+			return next(ctx)
+		}
+
+		ownerID, err := currentUserID(ctx)
+		if err != nil {
+			return next(ctx)
+		}
+		args := fc.Field.ArgumentMap(gqlgen.GetOperationContext(ctx).Variables)
+
+		result, err := next(ctx)
+		if err != nil {
+			return result, err
+		}
+
+		var entityID *uuid.UUID
+		if target.idArg != "" {
+			if value, ok := args[target.idArg].(string); ok {
+				if parsed, parseErr := uuid.Parse(value); parseErr == nil {
+					entityID = &parsed
+				}
+			}
+		} else if target.resultID != nil {
+			entityID = target.resultID(result)
+		}
+
+		var fields []string
+		if target.action != audit.ActionRead {
+			fields = auditFields(args, target.idArg)
+		}
+
+		entry := audit.NewEntry(ownerID, currentActor(ctx, ownerID), target.action, fc.Field.Name, target.entity, entityID, fields)
+		if err := record.Execute(ctx, entry); err != nil {
+			log.Printf("audit: failed to record %s: %v", fc.Field.Name, err)
+			if target.action == audit.ActionRead {
+				return nil, errors.New("internal error")
+			}
+		}
+
+		return result, nil
+	}
+}
@@ -0,0 +1,168 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	gqlgen "github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+	userapp "github.com/health-hub-bot-api/internal/application/user"
+	"github.com/health-hub-bot-api/internal/domain/user"
+	"github.com/health-hub-bot-api/internal/infrastructure/telegram"
+)
+
+// initDataMaxAge ограничивает срок жизни initData Telegram WebApp
+const initDataMaxAge = 24 * time.Hour
+
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+type contextKey string
+
+const (
+	userIDContextKey          contextKey = "userID"
+	accountIDContextKey       contextKey = "accountID"
+	pendingDeletionContextKey contextKey = "pendingDeletion"
+)
+
+// pendingDeletionFields — поля, доступные аккаунту, который ожидает удаления
+var pendingDeletionFields = map[string]bool{
+	"accountDeletion": true,
+	"restoreAccount":  true,
+}
+
+// profileHeader выбирает профиль (свой или подопечного), с данными которого работает запрос
+const profileHeader = "X-Profile-ID"
+
+// WithUserID сохраняет ID текущего пользователя в контексте
+func WithUserID(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, userIDContextKey, userID)
+}
+
+// WithProfile сохраняет в контексте владельца аккаунта и выбранный им профиль
+func WithProfile(ctx context.Context, accountID, profileID uuid.UUID) context.Context {
+	return WithUserID(context.WithValue(ctx, accountIDContextKey, accountID), profileID)
+}
+
+// currentUserID возвращает ID текущего профиля из контекста: все данные
+// (симптомы, анализы, лекарства, визиты) читаются и пишутся от его имени
+func currentUserID(ctx context.Context) (uuid.UUID, error) {
+	userID, ok := ctx.Value(userIDContextKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return uuid.Nil, ErrUnauthenticated
+	}
+	return userID, nil
+}
+
+// currentAccountID возвращает ID владельца аккаунта Telegram; без выбранного
+// профиля он совпадает с текущим пользователем
+func currentAccountID(ctx context.Context) (uuid.UUID, error) {
+	if accountID, ok := ctx.Value(accountIDContextKey).(uuid.UUID); ok && accountID != uuid.Nil {
+		return accountID, nil
+	}
+	return currentUserID(ctx)
+}
+
+// isPendingDeletion проверяет, что запрос выполняется от аккаунта, который ожидает удаления
+func isPendingDeletion(ctx context.Context) bool {
+	pending, _ := ctx.Value(pendingDeletionContextKey).(bool)
+	return pending
+}
+
+// DeletionGate ограничивает аккаунт, который ожидает удаления, полями
+// просмотра и отмены удаления
+func DeletionGate() gqlgen.RootFieldMiddleware {
+	return func(ctx context.Context, next gqlgen.RootResolver) gqlgen.Marshaler {
+		if !isPendingDeletion(ctx) {
+			return next(ctx)
+		}
+
+		field := gqlgen.GetRootFieldContext(ctx)
+		if strings.HasPrefix(field.Field.Name, "__") || pendingDeletionFields[field.Field.Name] {
+			return next(ctx)
+		}
+
+		gqlgen.AddError(ctx, user.ErrAccountPendingDeletion)
+		return gqlgen.Null
+	}
+}
+
+// AuthMiddleware аутентифицирует запросы Telegram WebApp по заголовку
+// "Authorization: tma <initData>". Пользователь создаётся при первом обращении.
+// Запросы без заголовка пропускаются без пользователя в контексте.
+// Заголовок X-Profile-ID переключает запрос на подопечный профиль владельца.
+func AuthMiddleware(userRepo user.Repository, profiles *userapp.ProfilesUseCase, botToken string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			header := req.Header.Get("Authorization")
+			if !strings.HasPrefix(header, "tma ") {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			rawInitData := strings.TrimPrefix(header, "tma ")
+			initData, err := telegram.ValidateInitData(rawInitData, botToken, initDataMaxAge)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			ctx := req.Context()
+			u, err := userRepo.GetByTelegramUserID(ctx, initData.User.ID)
+			if err != nil {
+				log.Printf("auth: failed to load user: %v", err)
+				http.Error(w, "internal error", http.StatusInternalServerError)
+				return
+			}
+			if u == nil {
+				// Аккаунт, ожидающий удаления, не пересоздаётся: до безвозвратного
+				// удаления с ним можно только восстановить аккаунт (см. DeletionGate)
+				deleted, err := userRepo.GetDeletedByTelegramUserID(ctx, initData.User.ID)
+				if err != nil {
+					log.Printf("auth: failed to load user: %v", err)
+					http.Error(w, "internal error", http.StatusInternalServerError)
+					return
+				}
+				if deleted != nil {
+					ctx = context.WithValue(WithProfile(ctx, deleted.ID, deleted.ID), pendingDeletionContextKey, true)
+					next.ServeHTTP(w, req.WithContext(ctx))
+					return
+				}
+			}
+			if u == nil {
+				u = user.NewUser(initData.User.ID, initData.User.FirstName)
+				if err := userRepo.Create(ctx, u); err != nil {
+					log.Printf("auth: failed to create user: %v", err)
+					http.Error(w, "internal error", http.StatusInternalServerError)
+					return
+				}
+			}
+
+			profileID := u.ID
+			if value := req.Header.Get(profileHeader); value != "" {
+				requested, err := uuid.Parse(value)
+				if err != nil {
+					http.Error(w, user.ErrProfileNotFound.Error(), http.StatusForbidden)
+					return
+				}
+				profile, err := profiles.Resolve(ctx, u.ID, requested)
+				if errors.Is(err, user.ErrProfileNotFound) {
+					http.Error(w, err.Error(), http.StatusForbidden)
+					return
+				}
+				if err != nil {
+					log.Printf("auth: failed to load profile: %v", err)
+					http.Error(w, "internal error", http.StatusInternalServerError)
+					return
+				}
+				profileID = profile.ID
+			}
+
+			ctx = withConsentEvidence(ctx, req, rawInitData)
+			next.ServeHTTP(w, req.WithContext(WithProfile(ctx, u.ID, profileID)))
+		})
+	}
+}
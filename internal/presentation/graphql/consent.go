@@ -0,0 +1,80 @@
+package graphql
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+
+	gqlgen "github.com/99designs/gqlgen/graphql"
+	consentapp "github.com/health-hub-bot-api/internal/application/consent"
+	"github.com/health-hub-bot-api/internal/domain/consent"
+)
+
+const consentEvidenceContextKey contextKey = "consentEvidence"
+
+// consentExemptMutations — мутации, доступные без согласия: само согласие,
+// удаление и восстановление аккаунта, выгрузка данных и отзыв выданных доступов
+var consentExemptMutations = map[string]bool{
+	"acceptConsent":     true,
+	"withdrawConsent":   true,
+	"deleteAccount":     true,
+	"restoreAccount":    true,
+	"requestDataExport": true,
+	"revokeShareGrant":  true,
+	"revokeReportLink":  true,
+}
+
+// withConsentEvidence сохраняет в контексте SHA-256 от IP-адреса клиента и initData
+// запроса: он записывается вместе с согласием как подтверждение, что его дал пользователь
+func withConsentEvidence(ctx context.Context, req *http.Request, initData string) context.Context {
+	ip, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		ip = req.RemoteAddr
+	}
+	sum := sha256.Sum256([]byte(ip + "\n" + initData))
+	return context.WithValue(ctx, consentEvidenceContextKey, hex.EncodeToString(sum[:]))
+}
+
+// consentEvidence возвращает подтверждение согласия из контекста или пустую строку
+func consentEvidence(ctx context.Context) string {
+	evidence, _ := ctx.Value(consentEvidenceContextKey).(string)
+	return evidence
+}
+
+// ConsentGate запрещает мутации, изменяющие данные, пока у владельца аккаунта нет
+// согласия на текущую версию политики. Согласие проверяется на каждый запрос,
+// поэтому после смены версии политики или отзыва согласия запрет действует сразу.
+func ConsentGate(consents *consentapp.ConsentUseCase) gqlgen.RootFieldMiddleware {
+	return func(ctx context.Context, next gqlgen.RootResolver) gqlgen.Marshaler {
+		field := gqlgen.GetRootFieldContext(ctx)
+		if field.Object != "Mutation" || consentExemptMutations[field.Field.Name] {
+			return next(ctx)
+		}
+		// Запросы по выдаче и аккаунт, ожидающий удаления, ограничиваются ShareGate и DeletionGate
+		if currentGrant(ctx) != nil || isPendingDeletion(ctx) {
+			return next(ctx)
+		}
+
+		accountID, err := currentAccountID(ctx)
+		if err != nil {
+			return next(ctx)
+		}
+
+		err = consents.Check(ctx, accountID)
+		if errors.Is(err, consent.ErrConsentRequired) {
+			gqlgen.AddError(ctx, err)
+			return gqlgen.Null
+		}
+		if err != nil {
+			log.Printf("consent: failed to check consent: %v", err)
+			gqlgen.AddError(ctx, errors.New("internal error"))
+			return gqlgen.Null
+		}
+
+		return next(ctx)
+	}
+}
@@ -0,0 +1,68 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+
+	gqlgen "github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/internal/domain/interaction"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+)
+
+const interactionsContextKey contextKey = "interactions"
+
+// interactionsCache хранит предупреждения о сочетаниях в пределах одной операции,
+// чтобы список лекарств проверялся одним запросом на пользователя, а не на каждое лекарство
+type interactionsCache struct {
+	mu     sync.Mutex
+	byUser map[uuid.UUID]*userInteractions
+}
+
+// userInteractions — предупреждения по активным лекарствам одного пользователя
+type userInteractions struct {
+	once     sync.Once
+	warnings map[uuid.UUID][]interaction.Warning
+	err      error
+}
+
+// InteractionsCache создаёт кэш предупреждений о сочетаниях для каждой операции
+func InteractionsCache() gqlgen.OperationMiddleware {
+	return func(ctx context.Context, next gqlgen.OperationHandler) gqlgen.ResponseHandler {
+		cache := &interactionsCache{byUser: make(map[uuid.UUID]*userInteractions)}
+		return next(context.WithValue(ctx, interactionsContextKey, cache))
+	}
+}
+
+// interactionWarnings возвращает предупреждения о сочетаниях лекарства с другими
+// активными лекарствами пользователя. По выдаче доступа в предупреждения попадают
+// только лекарства, видимые в её периоде данных
+func (r *Resolver) interactionWarnings(ctx context.Context, med *medication.Medication) ([]interaction.Warning, error) {
+	visible := grantVisibleMedication(ctx)
+	cache, _ := ctx.Value(interactionsContextKey).(*interactionsCache)
+	if cache == nil || !med.IsActive {
+		return r.checkInteractionsUC.Execute(ctx, med, visible)
+	}
+
+	cache.mu.Lock()
+	entry, ok := cache.byUser[med.UserID]
+	if !ok {
+		entry = &userInteractions{}
+		cache.byUser[med.UserID] = entry
+	}
+	cache.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.warnings, entry.err = r.checkInteractionsUC.ExecuteForUser(ctx, med.UserID, visible)
+	})
+	if entry.err != nil {
+		return nil, entry.err
+	}
+
+	// Лекарство, созданное в этой же операции после заполнения кэша, проверяется отдельно
+	warnings, ok := entry.warnings[med.ID]
+	if !ok {
+		return r.checkInteractionsUC.Execute(ctx, med, visible)
+	}
+	return warnings, nil
+}
@@ -0,0 +1,70 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	"github.com/health-hub-bot-api/graphql/generated"
+	doctorvisitapp "github.com/health-hub-bot-api/internal/application/doctorvisit"
+	"github.com/health-hub-bot-api/internal/domain/analysis"
+	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/symptom"
+)
+
+// reportItemsLimit ограничивает число записей каждого раздела в отчёте к визиту
+const reportItemsLimit = 1000
+
+// visitReport собирает отчёт к визиту за период [start, end]. В отличие от
+// generateDoctorVisitReport данные отчёта в визите не сохраняются, поэтому
+// просмотр отчёта, в том числе по выдаче, ничего не меняет.
+func (r *Resolver) visitReport(ctx context.Context, visit *doctorvisit.DoctorVisit, start, end time.Time) (*generated.DoctorVisitReport, error) {
+	symptoms, _, err := r.symptomRepo.FindByFilter(ctx, symptom.Filter{
+		UserID:    visit.UserID,
+		StartDate: &start,
+		EndDate:   &end,
+	}, reportItemsLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	trendData, err := r.symptomRepo.GetWellbeingTrend(ctx, visit.UserID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	trend := doctorvisitapp.WellbeingTrend(trendData)
+
+	analyses, _, err := r.analysisRepo.FindByFilter(ctx, analysis.Filter{
+		UserID:    visit.UserID,
+		StartDate: &start,
+		EndDate:   &end,
+	}, reportItemsLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// В отчёт попадают лекарства, курс которых пересекается с периодом отчёта
+	all, err := r.medicationRepo.FindByUserID(ctx, visit.UserID, false)
+	if err != nil {
+		return nil, err
+	}
+	medications := make([]*medication.Medication, 0, len(all))
+	for _, m := range all {
+		if m.StartDate.After(end) || (m.EndDate != nil && m.EndDate.Before(start)) {
+			continue
+		}
+		medications = append(medications, m)
+	}
+
+	return &generated.DoctorVisitReport{
+		VisitID:        visit.ID.String(),
+		VisitDate:      visit.VisitDate,
+		Period:         &doctorvisit.DateRange{StartDate: start, EndDate: end},
+		Symptoms:       symptoms,
+		WellbeingTrend: &trend,
+		Analyses:       analyses,
+		Medications:    medications,
+		Questions:      visit.Questions,
+		GeneratedAt:    time.Now(),
+	}, nil
+}
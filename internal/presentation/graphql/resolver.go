@@ -1,13 +1,57 @@
 package graphql
 
 import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/health-hub-bot-api/graphql/generated"
+	analysisapp "github.com/health-hub-bot-api/internal/application/analysis"
+	analyticsapp "github.com/health-hub-bot-api/internal/application/analytics"
+	auditapp "github.com/health-hub-bot-api/internal/application/audit"
+	consentapp "github.com/health-hub-bot-api/internal/application/consent"
+	dashboardapp "github.com/health-hub-bot-api/internal/application/dashboard"
+	doctorvisitapp "github.com/health-hub-bot-api/internal/application/doctorvisit"
+	engagementapp "github.com/health-hub-bot-api/internal/application/engagement"
+	exportapp "github.com/health-hub-bot-api/internal/application/export"
+	medicationapp "github.com/health-hub-bot-api/internal/application/medication"
+	reminderapp "github.com/health-hub-bot-api/internal/application/reminder"
+	sharingapp "github.com/health-hub-bot-api/internal/application/sharing"
+	symptomapp "github.com/health-hub-bot-api/internal/application/symptom"
+	userapp "github.com/health-hub-bot-api/internal/application/user"
 	"github.com/health-hub-bot-api/internal/domain/analysis"
 	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+	"github.com/health-hub-bot-api/internal/domain/engagement"
+	"github.com/health-hub-bot-api/internal/domain/export"
+	"github.com/health-hub-bot-api/internal/domain/filestorage"
+	"github.com/health-hub-bot-api/internal/domain/interaction"
 	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/notification"
+	"github.com/health-hub-bot-api/internal/domain/reminder"
+	"github.com/health-hub-bot-api/internal/domain/sharing"
 	"github.com/health-hub-bot-api/internal/domain/symptom"
 	"github.com/health-hub-bot-api/internal/domain/user"
 )
 
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+
+	defaultSuggestionsLimit = 5
+	maxSuggestionsLimit     = 20
+
+	defaultRecentLimit = 5
+	maxRecentLimit     = 50
+
+	defaultAccessLogLimit = 50
+	maxAccessLogLimit     = 200
+
+	defaultAuditLogLimit = 50
+	maxAuditLogLimit     = 200
+)
+
 // Resolver содержит зависимости для GraphQL resolvers
 type Resolver struct {
 	// Repositories
@@ -17,8 +61,60 @@ type Resolver struct {
 	medicationRepo  medication.Repository
 	intakeRepo      medication.IntakeRepository
 	doctorVisitRepo doctorvisit.Repository
+	reminderRepo    reminder.Repository
+	milestoneRepo   engagement.MilestoneRepository
+	courseRepo      medication.CourseRepository
+
+	shareGrantRepo     sharing.GrantRepository
+	shareAccessLogRepo sharing.AccessLogRepository
+	reportLinkRepo     sharing.ReportLinkRepository
+	exportJobRepo      export.JobRepository
+
+	// Services (use cases)
+	profilesUC                 *userapp.ProfilesUseCase
+	accountDeletionUC          *userapp.AccountDeletionUseCase
+	consentUC                  *consentapp.ConsentUseCase
+	onboardingUC               *userapp.OnboardingUseCase
+	correlationUC              *analyticsapp.SymptomMedicationCorrelationUseCase
+	dashboardUC                *dashboardapp.GetDashboardUseCase
+	streakService              *engagementapp.StreakService
+	createSymptomUC            *symptomapp.CreateSymptomUseCase
+	updateSymptomUC            *symptomapp.UpdateSymptomUseCase
+	deleteSymptomUC            *symptomapp.DeleteSymptomUseCase
+	uploadAnalysisUC           *analysisapp.UploadAnalysisUseCase
+	updateAnalysisUC           *analysisapp.UpdateAnalysisUseCase
+	deleteAnalysisUC           *analysisapp.DeleteAnalysisUseCase
+	snoozeReminderUC           *reminderapp.SnoozeReminderUseCase
+	completeAnalysisReminderUC *reminderapp.CompleteAnalysisReminderUseCase
+	createVisitUC              *doctorvisitapp.CreateVisitUseCase
+	updateVisitUC              *doctorvisitapp.UpdateVisitUseCase
+	deleteVisitUC              *doctorvisitapp.DeleteVisitUseCase
+	generateReportUC           *doctorvisitapp.GenerateReportUseCase
+	sendReportUC               *doctorvisitapp.SendReportUseCase
+	createMedicationUC         *medicationapp.CreateMedicationUseCase
+	updateMedicationUC         *medicationapp.UpdateMedicationUseCase
+	refillMedicationUC         *medicationapp.RefillMedicationUseCase
+	deleteMedicationUC         *medicationapp.DeleteMedicationUseCase
+	endCourseUC                *medicationapp.EndCourseUseCase
+	courseHistoryUC            *medicationapp.GetCourseHistoryUseCase
+	checkInteractionsUC        *medicationapp.CheckInteractionsUseCase
+	markIntakeUC               *medicationapp.MarkIntakeUseCase
+	complianceUC               *medicationapp.GetComplianceUseCase
+	logAsNeededIntakeUC        *medicationapp.LogAsNeededIntakeUseCase
+	setAsNeededLimitsUC        *medicationapp.SetAsNeededLimitsUseCase
+	createShareGrantUC         *sharingapp.CreateGrantUseCase
+	revokeShareGrantUC         *sharingapp.RevokeGrantUseCase
+	shareAccessUC              *sharingapp.AccessUseCase
+	createReportLinkUC         *sharingapp.CreateReportLinkUseCase
+	revokeReportLinkUC         *sharingapp.RevokeReportLinkUseCase
+	requestExportUC            *exportapp.RequestExportUseCase
+	importArchiveUC            *exportapp.ImportArchiveUseCase
+	getAuditLogUC              *auditapp.GetAuditLogUseCase
 
-	// Services (use cases) будут добавлены позже
+	// reportLinkBaseURL — адрес веб-страницы отчёта, к которому добавляется токен ссылки
+	reportLinkBaseURL string
+	// exportLinks выдаёт подписанные ссылки на готовые архивы выгрузки
+	exportLinks exportapp.DownloadLinks
 }
 
 // NewResolver создаёт новый resolver
@@ -29,13 +125,199 @@ func NewResolver(
 	medicationRepo medication.Repository,
 	intakeRepo medication.IntakeRepository,
 	doctorVisitRepo doctorvisit.Repository,
+	reminderRepo reminder.Repository,
+	milestoneRepo engagement.MilestoneRepository,
+	courseRepo medication.CourseRepository,
+	shareGrantRepo sharing.GrantRepository,
+	shareAccessLogRepo sharing.AccessLogRepository,
+	reportLinkRepo sharing.ReportLinkRepository,
+	exportJobRepo export.JobRepository,
+	notifier notification.Notifier,
+	files filestorage.Storage,
+	visitReminders doctorvisitapp.VisitReminderSyncer,
+	profilesUC *userapp.ProfilesUseCase,
+	accountDeletionUC *userapp.AccountDeletionUseCase,
+	consentUC *consentapp.ConsentUseCase,
+	onboardingUC *userapp.OnboardingUseCase,
+	snoozeReminderUC *reminderapp.SnoozeReminderUseCase,
+	endCourseUC *medicationapp.EndCourseUseCase,
+	interactionSource interaction.Source,
+	shareAccessUC *sharingapp.AccessUseCase,
+	createReportLinkUC *sharingapp.CreateReportLinkUseCase,
+	importArchiveUC *exportapp.ImportArchiveUseCase,
+	getAuditLogUC *auditapp.GetAuditLogUseCase,
+	reportLinkBaseURL string,
+	exportLinks exportapp.DownloadLinks,
 ) *Resolver {
+	streakService := engagementapp.NewStreakService(userRepo, symptomRepo, intakeRepo, milestoneRepo, reminderRepo)
+	generateReportUC := doctorvisitapp.NewGenerateReportUseCase(doctorVisitRepo, symptomRepo, analysisRepo, medicationRepo, intakeRepo, courseRepo)
+
 	return &Resolver{
-		userRepo:        userRepo,
-		symptomRepo:     symptomRepo,
-		analysisRepo:    analysisRepo,
-		medicationRepo:  medicationRepo,
-		intakeRepo:      intakeRepo,
-		doctorVisitRepo: doctorVisitRepo,
+		userRepo:                   userRepo,
+		symptomRepo:                symptomRepo,
+		analysisRepo:               analysisRepo,
+		medicationRepo:             medicationRepo,
+		intakeRepo:                 intakeRepo,
+		doctorVisitRepo:            doctorVisitRepo,
+		reminderRepo:               reminderRepo,
+		milestoneRepo:              milestoneRepo,
+		courseRepo:                 courseRepo,
+		shareGrantRepo:             shareGrantRepo,
+		shareAccessLogRepo:         shareAccessLogRepo,
+		reportLinkRepo:             reportLinkRepo,
+		exportJobRepo:              exportJobRepo,
+		profilesUC:                 profilesUC,
+		accountDeletionUC:          accountDeletionUC,
+		consentUC:                  consentUC,
+		onboardingUC:               onboardingUC,
+		correlationUC:              analyticsapp.NewSymptomMedicationCorrelationUseCase(symptomRepo, medicationRepo, intakeRepo, userRepo),
+		dashboardUC:                dashboardapp.NewGetDashboardUseCase(symptomRepo, analysisRepo, medicationRepo, intakeRepo, doctorVisitRepo, streakService),
+		streakService:              streakService,
+		createSymptomUC:            symptomapp.NewCreateSymptomUseCase(symptomRepo, streakService),
+		updateSymptomUC:            symptomapp.NewUpdateSymptomUseCase(symptomRepo),
+		deleteSymptomUC:            symptomapp.NewDeleteSymptomUseCase(symptomRepo),
+		uploadAnalysisUC:           analysisapp.NewUploadAnalysisUseCase(analysisRepo, files),
+		updateAnalysisUC:           analysisapp.NewUpdateAnalysisUseCase(analysisRepo, files),
+		deleteAnalysisUC:           analysisapp.NewDeleteAnalysisUseCase(analysisRepo, reminderRepo, files),
+		snoozeReminderUC:           snoozeReminderUC,
+		completeAnalysisReminderUC: reminderapp.NewCompleteAnalysisReminderUseCase(analysisRepo, reminderRepo),
+		createVisitUC:              doctorvisitapp.NewCreateVisitUseCase(doctorVisitRepo, visitReminders),
+		updateVisitUC:              doctorvisitapp.NewUpdateVisitUseCase(doctorVisitRepo, visitReminders),
+		deleteVisitUC:              doctorvisitapp.NewDeleteVisitUseCase(doctorVisitRepo, reminderRepo),
+		generateReportUC:           generateReportUC,
+		sendReportUC:               doctorvisitapp.NewSendReportUseCase(generateReportUC, userRepo, notifier),
+		createMedicationUC:         medicationapp.NewCreateMedicationUseCase(medicationRepo),
+		updateMedicationUC:         medicationapp.NewUpdateMedicationUseCase(medicationRepo, endCourseUC),
+		refillMedicationUC:         medicationapp.NewRefillMedicationUseCase(medicationRepo, reminderRepo),
+		deleteMedicationUC:         medicationapp.NewDeleteMedicationUseCase(medicationRepo, intakeRepo, reminderRepo),
+		endCourseUC:                endCourseUC,
+		courseHistoryUC:            medicationapp.NewGetCourseHistoryUseCase(medicationRepo, courseRepo),
+		checkInteractionsUC:        medicationapp.NewCheckInteractionsUseCase(medicationRepo, interactionSource),
+		markIntakeUC:               medicationapp.NewMarkIntakeUseCase(medicationRepo, intakeRepo, reminderRepo),
+		complianceUC:               medicationapp.NewGetComplianceUseCase(medicationRepo, intakeRepo),
+		logAsNeededIntakeUC:        medicationapp.NewLogAsNeededIntakeUseCase(medicationRepo, intakeRepo),
+		setAsNeededLimitsUC:        medicationapp.NewSetAsNeededLimitsUseCase(medicationRepo),
+		createShareGrantUC:         sharingapp.NewCreateGrantUseCase(shareGrantRepo),
+		revokeShareGrantUC:         sharingapp.NewRevokeGrantUseCase(shareGrantRepo),
+		shareAccessUC:              shareAccessUC,
+		createReportLinkUC:         createReportLinkUC,
+		revokeReportLinkUC:         sharingapp.NewRevokeReportLinkUseCase(reportLinkRepo),
+		requestExportUC:            exportapp.NewRequestExportUseCase(exportJobRepo),
+		importArchiveUC:            importArchiveUC,
+		getAuditLogUC:              getAuditLogUC,
+		reportLinkBaseURL:          reportLinkBaseURL,
+		exportLinks:                exportLinks,
+	}
+}
+
+// parseID преобразует GraphQL ID в UUID
+func parseID(id string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid id %q: %w", id, err)
+	}
+	return parsed, nil
+}
+
+// optionalID преобразует необязательный UUID в GraphQL ID
+func optionalID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+// analysisFileExts — расширения файлов анализов по MIME-типу
+var analysisFileExts = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+}
+
+// analysisFileFormat определяет тип и расширение загруженного файла анализа
+// по MIME-типу, а если клиент его не передал — по имени файла
+func analysisFileFormat(contentType, filename string) (analysis.FileType, string, error) {
+	ext, ok := analysisFileExts[strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))]
+	if !ok {
+		switch ext = strings.ToLower(path.Ext(filename)); ext {
+		case ".pdf", ".jpg", ".png":
+		case ".jpeg":
+			ext = ".jpg"
+		default:
+			return "", "", analysis.ErrUnsupportedFile
+		}
+	}
+	if ext == ".pdf" {
+		return analysis.FileTypePDF, ext, nil
+	}
+	return analysis.FileTypeImage, ext, nil
+}
+
+// scheduleDetailsFromInput преобразует GraphQL расписание в доменное
+func scheduleDetailsFromInput(input *generated.ScheduleDetailsInput) medication.ScheduleDetails {
+	details := medication.ScheduleDetails{
+		Times: input.Times,
+		Days:  input.Days,
+	}
+	if input.IntervalHours != nil {
+		details.IntervalHours = *input.IntervalHours
+	}
+	if input.EveryNDays != nil {
+		details.EveryNDays = *input.EveryNDays
+	}
+	if input.Cycle != nil {
+		details.Cycle = &medication.ScheduleCycle{DaysOn: input.Cycle.DaysOn, DaysOff: input.Cycle.DaysOff}
+	}
+	for _, step := range input.DoseSteps {
+		doseStep := medication.DoseStep{Dose: step.Dose}
+		if step.Days != nil {
+			doseStep.Days = *step.Days
+		}
+		details.DoseSteps = append(details.DoseSteps, doseStep)
+	}
+	return details
+}
+
+// dosageDetailsFromInput преобразует GraphQL структурированную дозу в доменную
+func dosageDetailsFromInput(input *generated.DosageDetailsInput) *medication.DosageDetails {
+	if input == nil {
+		return nil
+	}
+	return &medication.DosageDetails{
+		Amount: input.Amount,
+		Unit:   input.Unit,
+		Form:   input.Form,
+	}
+}
+
+// pageBounds возвращает размер страницы и смещение с учётом значений по умолчанию
+func pageBounds(limit, offset *int) (int, int) {
+	n := defaultPageLimit
+	if limit != nil && *limit > 0 {
+		n = min(*limit, maxPageLimit)
+	}
+	skip := 0
+	if offset != nil && *offset > 0 {
+		skip = *offset
+	}
+	return n, skip
+}
+
+// pageCursor кодирует позицию элемента в выборке
+func pageCursor(position int) string {
+	return strconv.Itoa(position)
+}
+
+// newPageInfo описывает страницу из count элементов со смещением offset в выборке из total
+func newPageInfo(offset, count, total int) *generated.PageInfo {
+	info := &generated.PageInfo{
+		HasNextPage:     offset+count < total,
+		HasPreviousPage: offset > 0,
+	}
+	if count > 0 {
+		start, end := pageCursor(offset), pageCursor(offset+count-1)
+		info.StartCursor, info.EndCursor = &start, &end
 	}
+	return info
 }
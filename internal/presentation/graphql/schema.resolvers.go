@@ -7,245 +7,1774 @@ package graphql
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
 	"github.com/health-hub-bot-api/graphql/generated"
+	analysisapp "github.com/health-hub-bot-api/internal/application/analysis"
+	analyticsapp "github.com/health-hub-bot-api/internal/application/analytics"
+	consentapp "github.com/health-hub-bot-api/internal/application/consent"
+	dashboardapp "github.com/health-hub-bot-api/internal/application/dashboard"
+	doctorvisitapp "github.com/health-hub-bot-api/internal/application/doctorvisit"
+	medicationapp "github.com/health-hub-bot-api/internal/application/medication"
+	reminderapp "github.com/health-hub-bot-api/internal/application/reminder"
+	sharingapp "github.com/health-hub-bot-api/internal/application/sharing"
+	symptomapp "github.com/health-hub-bot-api/internal/application/symptom"
+	userapp "github.com/health-hub-bot-api/internal/application/user"
 	"github.com/health-hub-bot-api/internal/domain/analysis"
+	"github.com/health-hub-bot-api/internal/domain/analytics"
+	"github.com/health-hub-bot-api/internal/domain/audit"
+	"github.com/health-hub-bot-api/internal/domain/consent"
 	"github.com/health-hub-bot-api/internal/domain/doctorvisit"
+	"github.com/health-hub-bot-api/internal/domain/engagement"
+	"github.com/health-hub-bot-api/internal/domain/export"
+	"github.com/health-hub-bot-api/internal/domain/interaction"
 	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/reminder"
+	"github.com/health-hub-bot-api/internal/domain/sharing"
 	"github.com/health-hub-bot-api/internal/domain/symptom"
 	"github.com/health-hub-bot-api/internal/domain/user"
 )
 
 // ID is the resolver for the id field.
 func (r *analysisResolver) ID(ctx context.Context, obj *analysis.Analysis) (string, error) {
-	panic(fmt.Errorf("not implemented: ID - id"))
+	return obj.ID.String(), nil
 }
 
 // UserID is the resolver for the userId field.
 func (r *analysisResolver) UserID(ctx context.Context, obj *analysis.Analysis) (string, error) {
-	panic(fmt.Errorf("not implemented: UserID - userId"))
+	return obj.UserID.String(), nil
 }
 
 // Type is the resolver for the type field.
 func (r *analysisResolver) Type(ctx context.Context, obj *analysis.Analysis) (generated.AnalysisType, error) {
-	panic(fmt.Errorf("not implemented: Type - type"))
+	return generated.AnalysisType(strings.ToUpper(string(obj.Type))), nil
+}
+
+// FollowUpAnalysisID is the resolver for the followUpAnalysisId field.
+func (r *analysisResolver) FollowUpAnalysisID(ctx context.Context, obj *analysis.Analysis) (*string, error) {
+	return optionalID(obj.FollowUpAnalysisID), nil
+}
+
+// ID is the resolver for the id field.
+func (r *auditLogEntryResolver) ID(ctx context.Context, obj *audit.Entry) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// ActorKind is the resolver for the actorKind field.
+func (r *auditLogEntryResolver) ActorKind(ctx context.Context, obj *audit.Entry) (audit.ActorKind, error) {
+	return obj.Actor.Kind, nil
+}
+
+// ActorUserID is the resolver for the actorUserId field.
+func (r *auditLogEntryResolver) ActorUserID(ctx context.Context, obj *audit.Entry) (*string, error) {
+	return optionalID(obj.Actor.UserID), nil
+}
+
+// CredentialID is the resolver for the credentialId field.
+func (r *auditLogEntryResolver) CredentialID(ctx context.Context, obj *audit.Entry) (*string, error) {
+	return optionalID(obj.Actor.CredentialID), nil
+}
+
+// EntityID is the resolver for the entityId field.
+func (r *auditLogEntryResolver) EntityID(ctx context.Context, obj *audit.Entry) (*string, error) {
+	return optionalID(obj.EntityID), nil
+}
+
+// URL is the resolver for the url field.
+func (r *createReportLinkResultResolver) URL(ctx context.Context, obj *sharingapp.CreateReportLinkResult) (string, error) {
+	return r.reportLinkBaseURL + obj.Token, nil
+}
+
+// ID is the resolver for the id field.
+func (r *dataExportResolver) ID(ctx context.Context, obj *export.Job) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// DownloadURL is the resolver for the downloadUrl field.
+func (r *dataExportResolver) DownloadURL(ctx context.Context, obj *export.Job) (*string, error) {
+	if !obj.IsDownloadable(time.Now()) {
+		return nil, nil
+	}
+	url := r.exportLinks.ExportURL(obj.ID, *obj.ExpiresAt)
+	return &url, nil
 }
 
 // ID is the resolver for the id field.
 func (r *doctorVisitResolver) ID(ctx context.Context, obj *doctorvisit.DoctorVisit) (string, error) {
-	panic(fmt.Errorf("not implemented: ID - id"))
+	return obj.ID.String(), nil
 }
 
 // UserID is the resolver for the userId field.
 func (r *doctorVisitResolver) UserID(ctx context.Context, obj *doctorvisit.DoctorVisit) (string, error) {
-	panic(fmt.Errorf("not implemented: UserID - userId"))
+	return obj.UserID.String(), nil
 }
 
 // ReportData is the resolver for the reportData field.
 func (r *doctorVisitResolver) ReportData(ctx context.Context, obj *doctorvisit.DoctorVisit) (*string, error) {
-	panic(fmt.Errorf("not implemented: ReportData - reportData"))
+	// Данные отчёта ссылаются на записи вне периода выдачи, поэтому по выдаче они не видны
+	if currentGrant(ctx) != nil {
+		return nil, nil
+	}
+	data, err := obj.GetReportDataJSON()
+	if err != nil || data == nil {
+		return nil, err
+	}
+	value := string(data)
+	return &value, nil
+}
+
+// OtherMedicationID is the resolver for the otherMedicationId field.
+func (r *interactionWarningResolver) OtherMedicationID(ctx context.Context, obj *interaction.Warning) (string, error) {
+	return obj.OtherMedicationID.String(), nil
+}
+
+// Disclaimer is the resolver for the disclaimer field.
+func (r *interactionWarningResolver) Disclaimer(ctx context.Context, obj *interaction.Warning) (string, error) {
+	return interaction.Disclaimer, nil
 }
 
 // ID is the resolver for the id field.
 func (r *medicationResolver) ID(ctx context.Context, obj *medication.Medication) (string, error) {
-	panic(fmt.Errorf("not implemented: ID - id"))
+	return obj.ID.String(), nil
 }
 
 // UserID is the resolver for the userId field.
 func (r *medicationResolver) UserID(ctx context.Context, obj *medication.Medication) (string, error) {
-	panic(fmt.Errorf("not implemented: UserID - userId"))
+	return obj.UserID.String(), nil
+}
+
+// MinDoseIntervalMinutes is the resolver for the minDoseIntervalMinutes field.
+func (r *medicationResolver) MinDoseIntervalMinutes(ctx context.Context, obj *medication.Medication) (*int, error) {
+	if obj.MinDoseInterval == nil {
+		return nil, nil
+	}
+	minutes := int(*obj.MinDoseInterval / time.Minute)
+	return &minutes, nil
+}
+
+// RunsOutOn is the resolver for the runsOutOn field.
+func (r *medicationResolver) RunsOutOn(ctx context.Context, obj *medication.Medication) (*time.Time, error) {
+	if !obj.TracksStock() {
+		return nil, nil
+	}
+	u, err := r.userRepo.GetByID(ctx, obj.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, nil
+	}
+	return obj.RunsOutOn(time.Now(), u.Location()), nil
+}
+
+// InteractionWarnings is the resolver for the interactionWarnings field.
+func (r *medicationResolver) InteractionWarnings(ctx context.Context, obj *medication.Medication) ([]*interaction.Warning, error) {
+	warnings, err := r.interactionWarnings(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*interaction.Warning, len(warnings))
+	for i := range warnings {
+		result[i] = &warnings[i]
+	}
+	return result, nil
+}
+
+// ID is the resolver for the id field.
+func (r *medicationCourseResolver) ID(ctx context.Context, obj *medication.Course) (*string, error) {
+	// У текущего курса ещё нет записи в истории
+	if obj.IsOngoing() {
+		return nil, nil
+	}
+	return optionalID(&obj.ID), nil
+}
+
+// MedicationID is the resolver for the medicationId field.
+func (r *medicationCourseResolver) MedicationID(ctx context.Context, obj *medication.Course) (string, error) {
+	return obj.MedicationID.String(), nil
+}
+
+// ReplacedByID is the resolver for the replacedById field.
+func (r *medicationCourseResolver) ReplacedByID(ctx context.Context, obj *medication.Course) (*string, error) {
+	return optionalID(obj.ReplacedByID), nil
 }
 
 // ID is the resolver for the id field.
 func (r *medicationIntakeResolver) ID(ctx context.Context, obj *medication.MedicationIntake) (string, error) {
-	panic(fmt.Errorf("not implemented: ID - id"))
+	return obj.ID.String(), nil
 }
 
 // MedicationID is the resolver for the medicationId field.
 func (r *medicationIntakeResolver) MedicationID(ctx context.Context, obj *medication.MedicationIntake) (string, error) {
-	panic(fmt.Errorf("not implemented: MedicationID - medicationId"))
+	return obj.MedicationID.String(), nil
+}
+
+// ID is the resolver for the id field.
+func (r *milestoneResolver) ID(ctx context.Context, obj *engagement.Milestone) (string, error) {
+	return obj.ID.String(), nil
 }
 
 // UpdateUserProfile is the resolver for the updateUserProfile field.
 func (r *mutationResolver) UpdateUserProfile(ctx context.Context, input generated.UpdateUserProfileInput) (*user.User, error) {
-	panic(fmt.Errorf("not implemented: UpdateUserProfile - updateUserProfile"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := r.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, user.ErrUserNotFound
+	}
+
+	var name string
+	if input.Name != nil {
+		name = *input.Name
+	}
+	u.UpdateProfile(name, input.Age, input.Gender)
+	if err := r.userRepo.UpdateProfile(ctx, u); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// SetTimezone is the resolver for the setTimezone field.
+func (r *mutationResolver) SetTimezone(ctx context.Context, timezone string) (*user.User, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := r.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, user.ErrUserNotFound
+	}
+
+	if err := u.SetTimezone(timezone); err != nil {
+		return nil, err
+	}
+	if err := r.userRepo.UpdateTimezone(ctx, u); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// UpdateNotificationPreferences is the resolver for the updateNotificationPreferences field.
+func (r *mutationResolver) UpdateNotificationPreferences(ctx context.Context, input generated.NotificationPreferencesInput) (*user.User, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := r.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, user.ErrUserNotFound
+	}
+
+	prefs := user.NotificationPreferences{
+		CheckInTimes:    input.CheckInTimes,
+		Channels:        input.Channels,
+		QuietHoursStart: input.QuietHoursStart,
+		QuietHoursEnd:   input.QuietHoursEnd,
+		Days:            input.Days,
+	}
+	if err := u.UpdateNotificationPreferences(prefs); err != nil {
+		return nil, err
+	}
+	if err := r.userRepo.UpdateNotificationPreferences(ctx, u); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// CreateDependentProfile is the resolver for the createDependentProfile field.
+func (r *mutationResolver) CreateDependentProfile(ctx context.Context, input generated.CreateDependentProfileInput) (*user.User, error) {
+	accountID, err := currentAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.profilesUC.CreateDependent(ctx, userapp.CreateDependentInput{
+		AccountID: accountID,
+		Name:      input.Name,
+		Age:       input.Age,
+		Gender:    input.Gender,
+	})
+}
+
+// DeleteDependentProfile is the resolver for the deleteDependentProfile field.
+func (r *mutationResolver) DeleteDependentProfile(ctx context.Context, id string) (bool, error) {
+	accountID, err := currentAccountID(ctx)
+	if err != nil {
+		return false, err
+	}
+	profileID, err := parseID(id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.profilesUC.DeleteDependent(ctx, accountID, profileID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DeleteAccount is the resolver for the deleteAccount field.
+func (r *mutationResolver) DeleteAccount(ctx context.Context) (*userapp.AccountDeletion, error) {
+	accountID, err := currentAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.accountDeletionUC.Delete(ctx, accountID)
+}
+
+// RestoreAccount is the resolver for the restoreAccount field.
+func (r *mutationResolver) RestoreAccount(ctx context.Context) (*user.User, error) {
+	accountID, err := currentAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.accountDeletionUC.Restore(ctx, accountID)
+}
+
+// AcceptConsent is the resolver for the acceptConsent field.
+func (r *mutationResolver) AcceptConsent(ctx context.Context, policyVersion string, channel *consent.Channel) (*consentapp.Status, error) {
+	accountID, err := currentAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := consent.ChannelWebApp
+	if channel != nil {
+		ch = *channel
+	}
+
+	return r.consentUC.Accept(ctx, accountID, policyVersion, ch, consentEvidence(ctx))
+}
+
+// WithdrawConsent is the resolver for the withdrawConsent field.
+func (r *mutationResolver) WithdrawConsent(ctx context.Context) (*consentapp.Status, error) {
+	accountID, err := currentAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.consentUC.Withdraw(ctx, accountID)
+}
+
+// CompleteOnboardingStep is the resolver for the completeOnboardingStep field.
+func (r *mutationResolver) CompleteOnboardingStep(ctx context.Context, step user.OnboardingStep, skip *bool) (*user.User, error) {
+	accountID, err := currentAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.onboardingUC.Execute(ctx, accountID, step, skip != nil && *skip)
+}
+
+// SnoozeReminder is the resolver for the snoozeReminder field.
+func (r *mutationResolver) SnoozeReminder(ctx context.Context, id string, minutes *int) (*reminder.Reminder, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reminderID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	input := reminderapp.SnoozeReminderInput{UserID: userID, ReminderID: reminderID}
+	if minutes != nil {
+		duration := time.Duration(*minutes) * time.Minute
+		input.Duration = &duration
+	}
+
+	return r.snoozeReminderUC.Execute(ctx, input)
+}
+
+// CompleteAnalysisReminder is the resolver for the completeAnalysisReminder field.
+func (r *mutationResolver) CompleteAnalysisReminder(ctx context.Context, analysisID string, newAnalysisID *string) (*analysis.Analysis, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	id, err := parseID(analysisID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := reminderapp.CompleteAnalysisReminderInput{UserID: userID, AnalysisID: id}
+	if newAnalysisID != nil {
+		newID, err := parseID(*newAnalysisID)
+		if err != nil {
+			return nil, err
+		}
+		input.NewAnalysisID = &newID
+	}
+
+	return r.completeAnalysisReminderUC.Execute(ctx, input)
 }
 
 // CreateSymptomEntry is the resolver for the createSymptomEntry field.
 func (r *mutationResolver) CreateSymptomEntry(ctx context.Context, input generated.CreateSymptomEntryInput) (*symptom.SymptomEntry, error) {
-	panic(fmt.Errorf("not implemented: CreateSymptomEntry - createSymptomEntry"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Фото пока не сохраняется, как и в CreateSymptomUseCase
+	return r.createSymptomUC.Execute(ctx, symptomapp.CreateSymptomInput{
+		UserID:                 userID,
+		DateTime:               input.DateTime,
+		Description:            input.Description,
+		WellbeingScale:         input.WellbeingScale,
+		Temperature:            input.Temperature,
+		BloodPressureSystolic:  input.BloodPressureSystolic,
+		BloodPressureDiastolic: input.BloodPressureDiastolic,
+		Pulse:                  input.Pulse,
+	})
 }
 
 // UpdateSymptomEntry is the resolver for the updateSymptomEntry field.
 func (r *mutationResolver) UpdateSymptomEntry(ctx context.Context, id string, input generated.UpdateSymptomEntryInput) (*symptom.SymptomEntry, error) {
-	panic(fmt.Errorf("not implemented: UpdateSymptomEntry - updateSymptomEntry"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entryID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.updateSymptomUC.Execute(ctx, symptomapp.UpdateSymptomInput{
+		UserID:                 userID,
+		EntryID:                entryID,
+		DateTime:               input.DateTime,
+		Description:            input.Description,
+		WellbeingScale:         input.WellbeingScale,
+		Temperature:            input.Temperature,
+		BloodPressureSystolic:  input.BloodPressureSystolic,
+		BloodPressureDiastolic: input.BloodPressureDiastolic,
+		Pulse:                  input.Pulse,
+	})
 }
 
 // DeleteSymptomEntry is the resolver for the deleteSymptomEntry field.
 func (r *mutationResolver) DeleteSymptomEntry(ctx context.Context, id string) (bool, error) {
-	panic(fmt.Errorf("not implemented: DeleteSymptomEntry - deleteSymptomEntry"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return false, err
+	}
+	entryID, err := parseID(id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.deleteSymptomUC.Execute(ctx, userID, entryID); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 // CreateAnalysis is the resolver for the createAnalysis field.
 func (r *mutationResolver) CreateAnalysis(ctx context.Context, input generated.CreateAnalysisInput) (*analysis.Analysis, error) {
-	panic(fmt.Errorf("not implemented: CreateAnalysis - createAnalysis"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fileType, ext, err := analysisFileFormat(input.File.ContentType, input.File.Filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.uploadAnalysisUC.Execute(ctx, analysisapp.UploadAnalysisInput{
+		UserID:           userID,
+		Type:             analysis.Type(strings.ToLower(string(input.Type))),
+		Name:             input.Name,
+		DateTaken:        input.DateTaken,
+		FileType:         fileType,
+		Ext:              ext,
+		File:             input.File.File,
+		NextReminderDate: input.NextReminderDate,
+	})
 }
 
 // UpdateAnalysis is the resolver for the updateAnalysis field.
 func (r *mutationResolver) UpdateAnalysis(ctx context.Context, id string, input generated.UpdateAnalysisInput) (*analysis.Analysis, error) {
-	panic(fmt.Errorf("not implemented: UpdateAnalysis - updateAnalysis"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	analysisID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	update := analysisapp.UpdateAnalysisInput{
+		UserID:           userID,
+		AnalysisID:       analysisID,
+		Name:             input.Name,
+		DateTaken:        input.DateTaken,
+		NextReminderDate: input.NextReminderDate,
+	}
+	if input.Type != nil {
+		t := analysis.Type(strings.ToLower(string(*input.Type)))
+		update.Type = &t
+	}
+	if input.File != nil {
+		update.FileType, update.Ext, err = analysisFileFormat(input.File.ContentType, input.File.Filename)
+		if err != nil {
+			return nil, err
+		}
+		update.File = input.File.File
+	}
+
+	return r.updateAnalysisUC.Execute(ctx, update)
 }
 
 // DeleteAnalysis is the resolver for the deleteAnalysis field.
 func (r *mutationResolver) DeleteAnalysis(ctx context.Context, id string) (bool, error) {
-	panic(fmt.Errorf("not implemented: DeleteAnalysis - deleteAnalysis"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return false, err
+	}
+	analysisID, err := parseID(id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.deleteAnalysisUC.Execute(ctx, userID, analysisID); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 // CreateMedication is the resolver for the createMedication field.
-func (r *mutationResolver) CreateMedication(ctx context.Context, input generated.CreateMedicationInput) (*medication.Medication, error) {
-	panic(fmt.Errorf("not implemented: CreateMedication - createMedication"))
+func (r *mutationResolver) CreateMedication(ctx context.Context, input generated.CreateMedicationInput) (*generated.CreateMedicationResult, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	med, err := r.createMedicationUC.Execute(ctx, medicationapp.CreateMedicationInput{
+		UserID:          userID,
+		Name:            input.Name,
+		Dosage:          input.Dosage,
+		DosageDetails:   dosageDetailsFromInput(input.DosageDetails),
+		StockQuantity:   input.StockQuantity,
+		ScheduleType:    input.ScheduleType,
+		ScheduleDetails: scheduleDetailsFromInput(input.ScheduleDetails),
+		StartDate:       input.StartDate,
+		EndDate:         input.EndDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Предупреждения не мешают сохранению лекарства и возвращаются вместе с ним
+	warnings, err := r.interactionWarnings(ctx, med)
+	if err != nil {
+		return nil, err
+	}
+	result := &generated.CreateMedicationResult{
+		Medication:          med,
+		InteractionWarnings: make([]*interaction.Warning, len(warnings)),
+	}
+	for i := range warnings {
+		result.InteractionWarnings[i] = &warnings[i]
+	}
+	return result, nil
 }
 
 // UpdateMedication is the resolver for the updateMedication field.
 func (r *mutationResolver) UpdateMedication(ctx context.Context, id string, input generated.UpdateMedicationInput) (*medication.Medication, error) {
-	panic(fmt.Errorf("not implemented: UpdateMedication - updateMedication"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	medID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var scheduleDetails *medication.ScheduleDetails
+	if input.ScheduleDetails != nil {
+		details := scheduleDetailsFromInput(input.ScheduleDetails)
+		scheduleDetails = &details
+	}
+
+	return r.updateMedicationUC.Execute(ctx, medicationapp.UpdateMedicationInput{
+		UserID:          userID,
+		MedicationID:    medID,
+		Name:            input.Name,
+		Dosage:          input.Dosage,
+		DosageDetails:   dosageDetailsFromInput(input.DosageDetails),
+		StockQuantity:   input.StockQuantity,
+		ScheduleType:    input.ScheduleType,
+		ScheduleDetails: scheduleDetails,
+		StartDate:       input.StartDate,
+		EndDate:         input.EndDate,
+		IsActive:        input.IsActive,
+	})
 }
 
 // DeleteMedication is the resolver for the deleteMedication field.
 func (r *mutationResolver) DeleteMedication(ctx context.Context, id string) (bool, error) {
-	panic(fmt.Errorf("not implemented: DeleteMedication - deleteMedication"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return false, err
+	}
+	medID, err := parseID(id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.deleteMedicationUC.Execute(ctx, userID, medID); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 // MarkMedicationIntake is the resolver for the markMedicationIntake field.
 func (r *mutationResolver) MarkMedicationIntake(ctx context.Context, input generated.MarkMedicationIntakeInput) (*medication.MedicationIntake, error) {
-	panic(fmt.Errorf("not implemented: MarkMedicationIntake - markMedicationIntake"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	medicationID, err := parseID(input.MedicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// isTaken поддерживается для старых клиентов, не передающих status
+	var status medication.IntakeStatus
+	switch {
+	case input.Status != nil:
+		status = *input.Status
+	case input.IsTaken != nil && *input.IsTaken:
+		status = medication.IntakeStatusTaken
+	case input.IsTaken != nil:
+		status = medication.IntakeStatusPlanned
+	default:
+		return nil, fmt.Errorf("either status or isTaken must be provided")
+	}
+
+	return r.markIntakeUC.Execute(ctx, medicationapp.MarkIntakeInput{
+		UserID:        userID,
+		MedicationID:  medicationID,
+		ScheduledTime: input.ScheduledTime,
+		Status:        status,
+		SkipReason:    input.SkipReason,
+		ActualDose:    input.ActualDose,
+		Notes:         input.Notes,
+	})
+}
+
+// LogAsNeededIntake is the resolver for the logAsNeededIntake field.
+func (r *mutationResolver) LogAsNeededIntake(ctx context.Context, medicationID string, takenAt *time.Time, dose *string, reason *string) (*generated.AsNeededIntakeResult, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	medID, err := parseID(medicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.logAsNeededIntakeUC.Execute(ctx, medicationapp.LogAsNeededIntakeInput{
+		UserID:       userID,
+		MedicationID: medID,
+		TakenAt:      takenAt,
+		Dose:         dose,
+		Reason:       reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	warnings := make([]*medication.DoseWarning, len(result.Warnings))
+	for i := range result.Warnings {
+		warnings[i] = &result.Warnings[i]
+	}
+
+	return &generated.AsNeededIntakeResult{
+		Intake:   result.Intake,
+		Warnings: warnings,
+	}, nil
+}
+
+// SetAsNeededLimits is the resolver for the setAsNeededLimits field.
+func (r *mutationResolver) SetAsNeededLimits(ctx context.Context, medicationID string, maxDosesPer24h *int, minIntervalMinutes *int) (*medication.Medication, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	medID, err := parseID(medicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var minInterval *time.Duration
+	if minIntervalMinutes != nil {
+		d := time.Duration(*minIntervalMinutes) * time.Minute
+		minInterval = &d
+	}
+
+	return r.setAsNeededLimitsUC.Execute(ctx, medicationapp.SetAsNeededLimitsInput{
+		UserID:         userID,
+		MedicationID:   medID,
+		MaxDosesPer24h: maxDosesPer24h,
+		MinInterval:    minInterval,
+	})
+}
+
+// RefillMedication is the resolver for the refillMedication field.
+func (r *mutationResolver) RefillMedication(ctx context.Context, medicationID string, quantity float64) (*medication.Medication, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	medID, err := parseID(medicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.refillMedicationUC.Execute(ctx, medicationapp.RefillMedicationInput{
+		UserID:       userID,
+		MedicationID: medID,
+		Quantity:     quantity,
+	})
+}
+
+// StopMedication is the resolver for the stopMedication field.
+func (r *mutationResolver) StopMedication(ctx context.Context, id string, reason medication.CourseEndReason, replacedByID *string) (*medication.Medication, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	medID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var replacedBy *uuid.UUID
+	if replacedByID != nil {
+		parsed, err := parseID(*replacedByID)
+		if err != nil {
+			return nil, err
+		}
+		replacedBy = &parsed
+	}
+
+	return r.endCourseUC.Execute(ctx, medicationapp.EndCourseInput{
+		UserID:       userID,
+		MedicationID: medID,
+		Reason:       reason,
+		ReplacedByID: replacedBy,
+	})
 }
 
 // CreateDoctorVisit is the resolver for the createDoctorVisit field.
 func (r *mutationResolver) CreateDoctorVisit(ctx context.Context, input generated.CreateDoctorVisitInput) (*doctorvisit.DoctorVisit, error) {
-	panic(fmt.Errorf("not implemented: CreateDoctorVisit - createDoctorVisit"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.createVisitUC.Execute(ctx, doctorvisitapp.CreateVisitInput{
+		UserID:     userID,
+		VisitDate:  input.VisitDate,
+		DoctorName: input.DoctorName,
+		Specialty:  input.Specialty,
+		Questions:  input.Questions,
+	})
 }
 
 // UpdateDoctorVisit is the resolver for the updateDoctorVisit field.
 func (r *mutationResolver) UpdateDoctorVisit(ctx context.Context, id string, input generated.UpdateDoctorVisitInput) (*doctorvisit.DoctorVisit, error) {
-	panic(fmt.Errorf("not implemented: UpdateDoctorVisit - updateDoctorVisit"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	visitID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.updateVisitUC.Execute(ctx, doctorvisitapp.UpdateVisitInput{
+		VisitID:    visitID,
+		UserID:     userID,
+		VisitDate:  input.VisitDate,
+		DoctorName: input.DoctorName,
+		Specialty:  input.Specialty,
+		Questions:  input.Questions,
+	})
 }
 
 // DeleteDoctorVisit is the resolver for the deleteDoctorVisit field.
 func (r *mutationResolver) DeleteDoctorVisit(ctx context.Context, id string) (bool, error) {
-	panic(fmt.Errorf("not implemented: DeleteDoctorVisit - deleteDoctorVisit"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return false, err
+	}
+	visitID, err := parseID(id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.deleteVisitUC.Execute(ctx, userID, visitID); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 // GenerateDoctorVisitReport is the resolver for the generateDoctorVisitReport field.
 func (r *mutationResolver) GenerateDoctorVisitReport(ctx context.Context, visitID string, startDate *time.Time, endDate *time.Time) (*generated.DoctorVisitReport, error) {
-	panic(fmt.Errorf("not implemented: GenerateDoctorVisitReport - generateDoctorVisitReport"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	id, err := parseID(visitID)
+	if err != nil {
+		return nil, err
+	}
+
+	visit, err := r.doctorVisitRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if visit.UserID != userID {
+		return nil, doctorvisit.ErrUnauthorized
+	}
+
+	end := visit.VisitDate
+	if endDate != nil {
+		end = *endDate
+	}
+	start := end.Add(-doctorvisitapp.DefaultReportPeriod)
+	if startDate != nil {
+		start = *startDate
+	}
+
+	// Данные отчёта сохраняются в визите, ответ собирается так же, как в doctorVisitReport
+	if _, err := r.generateReportUC.Execute(ctx, doctorvisitapp.GenerateReportInput{
+		VisitID:   visit.ID,
+		UserID:    userID,
+		StartDate: start,
+		EndDate:   end,
+	}); err != nil {
+		return nil, err
+	}
+
+	return r.visitReport(ctx, visit, start, end)
+}
+
+// SendDoctorVisitReport is the resolver for the sendDoctorVisitReport field.
+func (r *mutationResolver) SendDoctorVisitReport(ctx context.Context, visitID string) (bool, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return false, err
+	}
+	id, err := parseID(visitID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.sendReportUC.Execute(ctx, doctorvisitapp.SendReportInput{VisitID: id, UserID: userID}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateShareGrant is the resolver for the createShareGrant field.
+func (r *mutationResolver) CreateShareGrant(ctx context.Context, input generated.CreateShareGrantInput) (*sharingapp.CreateGrantResult, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var grantee *int64
+	if input.GranteeTelegramUserID != nil {
+		telegramUserID, err := strconv.ParseInt(*input.GranteeTelegramUserID, 10, 64)
+		if err != nil {
+			return nil, sharing.ErrInvalidGrant
+		}
+		grantee = &telegramUserID
+	}
+
+	return r.createShareGrantUC.Execute(ctx, sharingapp.CreateGrantInput{
+		OwnerID:               userID,
+		GranteeTelegramUserID: grantee,
+		Scopes:                input.Scopes,
+		DataStartDate:         input.DataStartDate,
+		DataEndDate:           input.DataEndDate,
+		ExpiresAt:             input.ExpiresAt,
+	})
+}
+
+// RevokeShareGrant is the resolver for the revokeShareGrant field.
+func (r *mutationResolver) RevokeShareGrant(ctx context.Context, id string) (*sharing.Grant, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	grantID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.revokeShareGrantUC.Execute(ctx, userID, grantID)
+}
+
+// CreateReportLink is the resolver for the createReportLink field.
+func (r *mutationResolver) CreateReportLink(ctx context.Context, input generated.CreateReportLinkInput) (*sharingapp.CreateReportLinkResult, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	visitID, err := parseID(input.VisitID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ttl *time.Duration
+	if input.ExpiresInHours != nil {
+		hours := time.Duration(*input.ExpiresInHours) * time.Hour
+		ttl = &hours
+	}
+
+	return r.createReportLinkUC.Execute(ctx, sharingapp.CreateReportLinkInput{
+		OwnerID:   userID,
+		VisitID:   visitID,
+		StartDate: input.StartDate,
+		EndDate:   input.EndDate,
+		TTL:       ttl,
+		MaxViews:  input.MaxViews,
+		PIN:       input.Pin,
+	})
+}
+
+// RevokeReportLink is the resolver for the revokeReportLink field.
+func (r *mutationResolver) RevokeReportLink(ctx context.Context, id string) (*sharing.ReportLink, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	linkID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.revokeReportLinkUC.Execute(ctx, userID, linkID)
+}
+
+// RequestDataExport is the resolver for the requestDataExport field.
+func (r *mutationResolver) RequestDataExport(ctx context.Context) (*export.Job, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.requestExportUC.Execute(ctx, userID)
+}
+
+// ImportData is the resolver for the importData field.
+func (r *mutationResolver) ImportData(ctx context.Context, file graphql.Upload) (*export.ImportSummary, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.importArchiveUC.Execute(ctx, userID, file.File)
 }
 
 // Me is the resolver for the me field.
 func (r *queryResolver) Me(ctx context.Context) (*user.User, error) {
-	panic(fmt.Errorf("not implemented: Me - me"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.userRepo.GetByID(ctx, userID)
+}
+
+// Profiles is the resolver for the profiles field.
+func (r *queryResolver) Profiles(ctx context.Context) ([]*user.User, error) {
+	accountID, err := currentAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.profilesUC.List(ctx, accountID)
+}
+
+// AccountDeletion is the resolver for the accountDeletion field.
+func (r *queryResolver) AccountDeletion(ctx context.Context) (*userapp.AccountDeletion, error) {
+	accountID, err := currentAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.accountDeletionUC.Status(ctx, accountID)
+}
+
+// Consent is the resolver for the consent field.
+func (r *queryResolver) Consent(ctx context.Context) (*consentapp.Status, error) {
+	accountID, err := currentAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.consentUC.Status(ctx, accountID)
+}
+
+// Dashboard is the resolver for the dashboard field.
+func (r *queryResolver) Dashboard(ctx context.Context, period *generated.WellbeingPeriod, recentLimit *int) (*generated.Dashboard, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	n := defaultRecentLimit
+	if recentLimit != nil && *recentLimit > 0 {
+		n = min(*recentLimit, maxRecentLimit)
+	}
+
+	wellbeingDays := 7
+	if period != nil && *period == generated.WellbeingPeriodMonth {
+		wellbeingDays = 30
+	}
+
+	u, err := r.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, user.ErrUserNotFound
+	}
+
+	d, err := r.dashboardUC.Execute(ctx, dashboardapp.GetDashboardInput{
+		UserID:        userID,
+		RecentLimit:   n,
+		WellbeingDays: wellbeingDays,
+		Location:      u.Location(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	todayIntakes := make([]*generated.TodayIntake, len(d.TodayIntakes))
+	for i, t := range d.TodayIntakes {
+		todayIntakes[i] = &generated.TodayIntake{Medication: t.Medication, Intakes: t.Intakes}
+	}
+
+	series := make([]*symptom.DailyWellbeing, len(d.WellbeingSeries))
+	for i := range d.WellbeingSeries {
+		series[i] = &d.WellbeingSeries[i]
+	}
+
+	return &generated.Dashboard{
+		RecentSymptoms:            d.RecentSymptoms,
+		TodayIntakes:              todayIntakes,
+		NextDoctorVisit:           d.NextDoctorVisit,
+		UpcomingAnalysisReminders: d.UpcomingAnalysisReminders,
+		WellbeingSeries:           series,
+		Stats: &generated.DashboardStats{
+			SymptomEntriesCount: d.Stats.SymptomEntriesCount,
+			AverageWellbeing:    d.Stats.AverageWellbeing,
+			ActiveMedications:   d.Stats.ActiveMedications,
+			DiaryStreak:         d.Stats.DiaryStreak,
+		},
+	}, nil
+}
+
+// Streaks is the resolver for the streaks field.
+func (r *queryResolver) Streaks(ctx context.Context) (*generated.Streaks, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	streaks, err := r.streakService.GetStreaks(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &generated.Streaks{
+		SymptomLogging:      &streaks.SymptomLogging,
+		MedicationAdherence: &streaks.MedicationAdherence,
+	}, nil
+}
+
+// Milestones is the resolver for the milestones field.
+func (r *queryResolver) Milestones(ctx context.Context) ([]*engagement.Milestone, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.milestoneRepo.FindByUserID(ctx, userID)
 }
 
 // Symptoms is the resolver for the symptoms field.
 func (r *queryResolver) Symptoms(ctx context.Context, filter *generated.SymptomFilter, limit *int, offset *int) (*generated.SymptomConnection, error) {
-	panic(fmt.Errorf("not implemented: Symptoms - symptoms"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	f := symptom.Filter{UserID: userID}
+	if filter != nil {
+		f.StartDate, f.EndDate = filter.StartDate, filter.EndDate
+		f.MinWellbeingScale, f.MaxWellbeingScale = filter.MinWellbeingScale, filter.MaxWellbeingScale
+	}
+	f.StartDate, f.EndDate = grantPeriod(ctx, f.StartDate, f.EndDate)
+
+	n, skip := pageBounds(limit, offset)
+	entries, total, err := r.symptomRepo.FindByFilter(ctx, f, n, skip)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*generated.SymptomEdge, len(entries))
+	for i, entry := range entries {
+		edges[i] = &generated.SymptomEdge{Node: entry, Cursor: pageCursor(skip + i)}
+	}
+	return &generated.SymptomConnection{
+		Edges:      edges,
+		PageInfo:   newPageInfo(skip, len(entries), total),
+		TotalCount: total,
+	}, nil
 }
 
 // Symptom is the resolver for the symptom field.
 func (r *queryResolver) Symptom(ctx context.Context, id string) (*symptom.SymptomEntry, error) {
-	panic(fmt.Errorf("not implemented: Symptom - symptom"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entryID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := r.symptomRepo.GetByID(ctx, entryID)
+	if errors.Is(err, symptom.ErrSymptomNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if entry.UserID != userID || !inGrantPeriod(ctx, entry.DateTime) {
+		return nil, nil
+	}
+	return entry, nil
+}
+
+// SymptomSuggestions is the resolver for the symptomSuggestions field.
+func (r *queryResolver) SymptomSuggestions(ctx context.Context, prefix string, limit *int) ([]*symptom.SymptomSuggestion, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	n := defaultSuggestionsLimit
+	if limit != nil && *limit > 0 {
+		n = min(*limit, maxSuggestionsLimit)
+	}
+
+	startDate, endDate := grantPeriod(ctx, nil, nil)
+	return r.symptomRepo.GetSuggestions(ctx, userID, prefix, startDate, endDate, n)
 }
 
 // Analyses is the resolver for the analyses field.
 func (r *queryResolver) Analyses(ctx context.Context, filter *generated.AnalysisFilter, limit *int, offset *int) (*generated.AnalysisConnection, error) {
-	panic(fmt.Errorf("not implemented: Analyses - analyses"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	f := analysis.Filter{UserID: userID}
+	if filter != nil {
+		if filter.Type != nil {
+			t := analysis.Type(strings.ToLower(string(*filter.Type)))
+			f.Type = &t
+		}
+		f.StartDate, f.EndDate = filter.StartDate, filter.EndDate
+	}
+	f.StartDate, f.EndDate = grantPeriod(ctx, f.StartDate, f.EndDate)
+
+	n, skip := pageBounds(limit, offset)
+	analyses, total, err := r.analysisRepo.FindByFilter(ctx, f, n, skip)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*generated.AnalysisEdge, len(analyses))
+	for i, a := range analyses {
+		edges[i] = &generated.AnalysisEdge{Node: a, Cursor: pageCursor(skip + i)}
+	}
+	return &generated.AnalysisConnection{
+		Edges:      edges,
+		PageInfo:   newPageInfo(skip, len(analyses), total),
+		TotalCount: total,
+	}, nil
 }
 
 // Analysis is the resolver for the analysis field.
 func (r *queryResolver) Analysis(ctx context.Context, id string) (*analysis.Analysis, error) {
-	panic(fmt.Errorf("not implemented: Analysis - analysis"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	analysisID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := r.analysisRepo.GetByID(ctx, analysisID)
+	if err != nil || a == nil {
+		return nil, err
+	}
+	if a.UserID != userID || !inGrantPeriod(ctx, a.DateTaken) {
+		return nil, nil
+	}
+	return a, nil
 }
 
 // Medications is the resolver for the medications field.
 func (r *queryResolver) Medications(ctx context.Context, activeOnly *bool) ([]*medication.Medication, error) {
-	panic(fmt.Errorf("not implemented: Medications - medications"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	medications, err := r.medicationRepo.FindByUserID(ctx, userID, activeOnly != nil && *activeOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	// По выдаче видны только лекарства, курс которых пересекается с её периодом данных
+	grant := currentGrant(ctx)
+	if grant == nil {
+		return medications, nil
+	}
+	visible := medications[:0]
+	for _, m := range medications {
+		if grant.OverlapsPeriod(m.StartDate, m.EndDate) {
+			visible = append(visible, m)
+		}
+	}
+	return visible, nil
 }
 
 // Medication is the resolver for the medication field.
 func (r *queryResolver) Medication(ctx context.Context, id string) (*medication.Medication, error) {
-	panic(fmt.Errorf("not implemented: Medication - medication"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	medID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := r.medicationRepo.GetByID(ctx, medID)
+	if err != nil || m == nil {
+		return nil, err
+	}
+	if m.UserID != userID {
+		return nil, nil
+	}
+	if grant := currentGrant(ctx); grant != nil && !grant.OverlapsPeriod(m.StartDate, m.EndDate) {
+		return nil, nil
+	}
+	return m, nil
 }
 
 // MedicationIntakes is the resolver for the medicationIntakes field.
 func (r *queryResolver) MedicationIntakes(ctx context.Context, medicationID string, date *time.Time) ([]*medication.MedicationIntake, error) {
-	panic(fmt.Errorf("not implemented: MedicationIntakes - medicationIntakes"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	medID, err := parseID(medicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := r.medicationRepo.GetByID(ctx, medID)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil || m.UserID != userID {
+		return nil, medication.ErrUnauthorized
+	}
+
+	day := time.Now()
+	if date != nil {
+		day = *date
+	}
+	intakes, err := r.intakeRepo.FindByMedicationAndDate(ctx, medID, day)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := intakes[:0]
+	for _, intake := range intakes {
+		if inGrantPeriod(ctx, intake.ScheduledTime) {
+			visible = append(visible, intake)
+		}
+	}
+	return visible, nil
+}
+
+// MedicationCompliance is the resolver for the medicationCompliance field.
+func (r *queryResolver) MedicationCompliance(ctx context.Context, medicationID string, startDate *time.Time, endDate *time.Time) (*medication.ComplianceStats, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	medID, err := parseID(medicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate, endDate = grantPeriod(ctx, startDate, endDate)
+	return r.complianceUC.Execute(ctx, medicationapp.GetComplianceInput{
+		UserID:       userID,
+		MedicationID: medID,
+		StartDate:    startDate,
+		EndDate:      endDate,
+	})
+}
+
+// MedicationHistory is the resolver for the medicationHistory field.
+func (r *queryResolver) MedicationHistory(ctx context.Context, name *string) ([]*medication.CourseTimeline, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	timelines, err := r.courseHistoryUC.Execute(ctx, medicationapp.GetCourseHistoryInput{
+		UserID: userID,
+		Name:   name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// По выдаче видны только курсы, пересекающиеся с её периодом данных
+	grant := currentGrant(ctx)
+	if grant == nil {
+		return timelines, nil
+	}
+	visible := timelines[:0]
+	for _, timeline := range timelines {
+		courses := timeline.Courses[:0]
+		for _, course := range timeline.Courses {
+			if grant.OverlapsPeriod(course.StartDate, course.EndDate) {
+				courses = append(courses, course)
+			}
+		}
+		if len(courses) > 0 {
+			timeline.Courses = courses
+			visible = append(visible, timeline)
+		}
+	}
+	return visible, nil
 }
 
 // DoctorVisits is the resolver for the doctorVisits field.
 func (r *queryResolver) DoctorVisits(ctx context.Context, limit *int, offset *int) (*generated.DoctorVisitConnection, error) {
-	panic(fmt.Errorf("not implemented: DoctorVisits - doctorVisits"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	f := doctorvisit.Filter{UserID: userID}
+	f.StartDate, f.EndDate = grantPeriod(ctx, nil, nil)
+
+	n, skip := pageBounds(limit, offset)
+	visits, total, err := r.doctorVisitRepo.FindByFilter(ctx, f, n, skip)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*generated.DoctorVisitEdge, len(visits))
+	for i, visit := range visits {
+		edges[i] = &generated.DoctorVisitEdge{Node: visit, Cursor: pageCursor(skip + i)}
+	}
+	return &generated.DoctorVisitConnection{
+		Edges:      edges,
+		PageInfo:   newPageInfo(skip, len(visits), total),
+		TotalCount: total,
+	}, nil
 }
 
 // DoctorVisit is the resolver for the doctorVisit field.
 func (r *queryResolver) DoctorVisit(ctx context.Context, id string) (*doctorvisit.DoctorVisit, error) {
-	panic(fmt.Errorf("not implemented: DoctorVisit - doctorVisit"))
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	visitID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	visit, err := r.doctorVisitRepo.GetByID(ctx, visitID)
+	if errors.Is(err, doctorvisit.ErrVisitNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if visit.UserID != userID || !inGrantPeriod(ctx, visit.VisitDate) {
+		return nil, nil
+	}
+	return visit, nil
 }
 
 // DoctorVisitReport is the resolver for the doctorVisitReport field.
 func (r *queryResolver) DoctorVisitReport(ctx context.Context, visitID string, startDate *time.Time, endDate *time.Time) (*generated.DoctorVisitReport, error) {
-	panic(fmt.Errorf("not implemented: DoctorVisitReport - doctorVisitReport"))
+	visit, err := r.Query().DoctorVisit(ctx, visitID)
+	if err != nil || visit == nil {
+		return nil, err
+	}
+
+	// По умолчанию отчёт охватывает период перед визитом, как отчёт в боте
+	end := visit.VisitDate
+	if endDate != nil {
+		end = *endDate
+	}
+	start := end.Add(-doctorvisitapp.DefaultReportPeriod)
+	if startDate != nil {
+		start = *startDate
+	}
+	clampedStart, clampedEnd := grantPeriod(ctx, &start, &end)
+
+	return r.visitReport(ctx, visit, *clampedStart, *clampedEnd)
+}
+
+// SymptomMedicationCorrelation is the resolver for the symptomMedicationCorrelation field.
+func (r *queryResolver) SymptomMedicationCorrelation(ctx context.Context, medicationID string, startDate *time.Time, endDate *time.Time) (*analytics.SymptomMedicationCorrelation, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	medID, err := parseID(medicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate, endDate = grantPeriod(ctx, startDate, endDate)
+	return r.correlationUC.Execute(ctx, analyticsapp.SymptomMedicationCorrelationInput{
+		UserID:       userID,
+		MedicationID: medID,
+		StartDate:    startDate,
+		EndDate:      endDate,
+	})
+}
+
+// ShareGrants is the resolver for the shareGrants field.
+func (r *queryResolver) ShareGrants(ctx context.Context) ([]*sharing.Grant, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.shareGrantRepo.FindByOwnerID(ctx, userID)
+}
+
+// SharedWithMe is the resolver for the sharedWithMe field.
+func (r *queryResolver) SharedWithMe(ctx context.Context) ([]*sharing.Grant, error) {
+	accountID, err := currentAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.shareAccessUC.SharedWith(ctx, accountID)
+}
+
+// ShareAccessLog is the resolver for the shareAccessLog field.
+func (r *queryResolver) ShareAccessLog(ctx context.Context, grantID *string, limit *int) ([]*sharing.AccessLogEntry, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var id *uuid.UUID
+	if grantID != nil {
+		parsed, err := parseID(*grantID)
+		if err != nil {
+			return nil, err
+		}
+		id = &parsed
+	}
+
+	n := defaultAccessLogLimit
+	if limit != nil && *limit > 0 {
+		n = min(*limit, maxAccessLogLimit)
+	}
+
+	return r.shareAccessLogRepo.FindByOwnerID(ctx, userID, id, n)
+}
+
+// ReportLinks is the resolver for the reportLinks field.
+func (r *queryResolver) ReportLinks(ctx context.Context, visitID string) ([]*sharing.ReportLink, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	id, err := parseID(visitID)
+	if err != nil {
+		return nil, err
+	}
+
+	visit, err := r.doctorVisitRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if visit.UserID != userID {
+		return nil, doctorvisit.ErrUnauthorized
+	}
+
+	return r.reportLinkRepo.FindByVisitID(ctx, id)
+}
+
+// DataExports is the resolver for the dataExports field.
+func (r *queryResolver) DataExports(ctx context.Context) ([]*export.Job, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.exportJobRepo.FindByUserID(ctx, userID)
+}
+
+// MyAuditLog is the resolver for the myAuditLog field.
+func (r *queryResolver) MyAuditLog(ctx context.Context, othersOnly *bool, limit *int) ([]*audit.Entry, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	n := defaultAuditLogLimit
+	if limit != nil && *limit > 0 {
+		n = min(*limit, maxAuditLogLimit)
+	}
+
+	filter := audit.Filter{OthersOnly: othersOnly != nil && *othersOnly}
+	return r.getAuditLogUC.Execute(ctx, userID, filter, n)
+}
+
+// ID is the resolver for the id field.
+func (r *reminderResolver) ID(ctx context.Context, obj *reminder.Reminder) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// RelatedID is the resolver for the relatedId field.
+func (r *reminderResolver) RelatedID(ctx context.Context, obj *reminder.Reminder) (*string, error) {
+	return optionalID(obj.RelatedID), nil
+}
+
+// ID is the resolver for the id field.
+func (r *reportLinkResolver) ID(ctx context.Context, obj *sharing.ReportLink) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// VisitID is the resolver for the visitId field.
+func (r *reportLinkResolver) VisitID(ctx context.Context, obj *sharing.ReportLink) (string, error) {
+	return obj.VisitID.String(), nil
+}
+
+// HasPin is the resolver for the hasPin field.
+func (r *reportLinkResolver) HasPin(ctx context.Context, obj *sharing.ReportLink) (bool, error) {
+	return obj.RequiresPIN(), nil
+}
+
+// IsActive is the resolver for the isActive field.
+func (r *reportLinkResolver) IsActive(ctx context.Context, obj *sharing.ReportLink) (bool, error) {
+	return obj.IsActive(time.Now()), nil
+}
+
+// ID is the resolver for the id field.
+func (r *shareAccessLogEntryResolver) ID(ctx context.Context, obj *sharing.AccessLogEntry) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// GrantID is the resolver for the grantId field.
+func (r *shareAccessLogEntryResolver) GrantID(ctx context.Context, obj *sharing.AccessLogEntry) (string, error) {
+	return obj.GrantID.String(), nil
+}
+
+// GranteeUserID is the resolver for the granteeUserId field.
+func (r *shareAccessLogEntryResolver) GranteeUserID(ctx context.Context, obj *sharing.AccessLogEntry) (*string, error) {
+	return optionalID(obj.GranteeUserID), nil
+}
+
+// ID is the resolver for the id field.
+func (r *shareGrantResolver) ID(ctx context.Context, obj *sharing.Grant) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// OwnerID is the resolver for the ownerId field.
+func (r *shareGrantResolver) OwnerID(ctx context.Context, obj *sharing.Grant) (string, error) {
+	return obj.OwnerID.String(), nil
+}
+
+// GranteeTelegramUserID is the resolver for the granteeTelegramUserId field.
+func (r *shareGrantResolver) GranteeTelegramUserID(ctx context.Context, obj *sharing.Grant) (*string, error) {
+	if obj.GranteeTelegramUserID == nil {
+		return nil, nil
+	}
+	id := strconv.FormatInt(*obj.GranteeTelegramUserID, 10)
+	return &id, nil
+}
+
+// IsActive is the resolver for the isActive field.
+func (r *shareGrantResolver) IsActive(ctx context.Context, obj *sharing.Grant) (bool, error) {
+	return obj.IsActive(time.Now()), nil
 }
 
 // ID is the resolver for the id field.
 func (r *symptomEntryResolver) ID(ctx context.Context, obj *symptom.SymptomEntry) (string, error) {
-	panic(fmt.Errorf("not implemented: ID - id"))
+	return obj.ID.String(), nil
 }
 
 // UserID is the resolver for the userId field.
 func (r *symptomEntryResolver) UserID(ctx context.Context, obj *symptom.SymptomEntry) (string, error) {
-	panic(fmt.Errorf("not implemented: UserID - userId"))
+	return obj.UserID.String(), nil
+}
+
+// MedicationID is the resolver for the medicationId field.
+func (r *symptomMedicationCorrelationResolver) MedicationID(ctx context.Context, obj *analytics.SymptomMedicationCorrelation) (string, error) {
+	return obj.MedicationID.String(), nil
 }
 
 // ID is the resolver for the id field.
 func (r *userResolver) ID(ctx context.Context, obj *user.User) (string, error) {
-	panic(fmt.Errorf("not implemented: ID - id"))
+	return obj.ID.String(), nil
 }
 
 // TelegramUserID is the resolver for the telegramUserId field.
-func (r *userResolver) TelegramUserID(ctx context.Context, obj *user.User) (string, error) {
-	panic(fmt.Errorf("not implemented: TelegramUserID - telegramUserId"))
+func (r *userResolver) TelegramUserID(ctx context.Context, obj *user.User) (*string, error) {
+	if obj.IsDependent() {
+		return nil, nil
+	}
+	id := strconv.FormatInt(obj.TelegramUserID, 10)
+	return &id, nil
+}
+
+// OwnerID is the resolver for the ownerId field.
+func (r *userResolver) OwnerID(ctx context.Context, obj *user.User) (*string, error) {
+	return optionalID(obj.OwnerID), nil
+}
+
+// Onboarding is the resolver for the onboarding field.
+func (r *userResolver) Onboarding(ctx context.Context, obj *user.User) (*user.Onboarding, error) {
+	if obj.IsDependent() {
+		return nil, nil
+	}
+	return &obj.Onboarding, nil
 }
 
 // DataPoints is the resolver for the dataPoints field.
 func (r *wellbeingTrendResolver) DataPoints(ctx context.Context, obj *doctorvisit.WellbeingTrend) ([]*symptom.WellbeingDataPoint, error) {
-	panic(fmt.Errorf("not implemented: DataPoints - dataPoints"))
+	points := make([]*symptom.WellbeingDataPoint, len(obj.DataPoints))
+	for i, point := range obj.DataPoints {
+		points[i] = &symptom.WellbeingDataPoint{Date: point.Date, Value: point.Value}
+	}
+	return points, nil
 }
 
 // Analysis returns generated.AnalysisResolver implementation.
 func (r *Resolver) Analysis() generated.AnalysisResolver { return &analysisResolver{r} }
 
+// AuditLogEntry returns generated.AuditLogEntryResolver implementation.
+func (r *Resolver) AuditLogEntry() generated.AuditLogEntryResolver { return &auditLogEntryResolver{r} }
+
+// CreateReportLinkResult returns generated.CreateReportLinkResultResolver implementation.
+func (r *Resolver) CreateReportLinkResult() generated.CreateReportLinkResultResolver {
+	return &createReportLinkResultResolver{r}
+}
+
+// DataExport returns generated.DataExportResolver implementation.
+func (r *Resolver) DataExport() generated.DataExportResolver { return &dataExportResolver{r} }
+
 // DoctorVisit returns generated.DoctorVisitResolver implementation.
 func (r *Resolver) DoctorVisit() generated.DoctorVisitResolver { return &doctorVisitResolver{r} }
 
+// InteractionWarning returns generated.InteractionWarningResolver implementation.
+func (r *Resolver) InteractionWarning() generated.InteractionWarningResolver {
+	return &interactionWarningResolver{r}
+}
+
 // Medication returns generated.MedicationResolver implementation.
 func (r *Resolver) Medication() generated.MedicationResolver { return &medicationResolver{r} }
 
+// MedicationCourse returns generated.MedicationCourseResolver implementation.
+func (r *Resolver) MedicationCourse() generated.MedicationCourseResolver {
+	return &medicationCourseResolver{r}
+}
+
 // MedicationIntake returns generated.MedicationIntakeResolver implementation.
 func (r *Resolver) MedicationIntake() generated.MedicationIntakeResolver {
 	return &medicationIntakeResolver{r}
 }
 
+// Milestone returns generated.MilestoneResolver implementation.
+func (r *Resolver) Milestone() generated.MilestoneResolver { return &milestoneResolver{r} }
+
 // Mutation returns generated.MutationResolver implementation.
 func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
 
 // Query returns generated.QueryResolver implementation.
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
+// Reminder returns generated.ReminderResolver implementation.
+func (r *Resolver) Reminder() generated.ReminderResolver { return &reminderResolver{r} }
+
+// ReportLink returns generated.ReportLinkResolver implementation.
+func (r *Resolver) ReportLink() generated.ReportLinkResolver { return &reportLinkResolver{r} }
+
+// ShareAccessLogEntry returns generated.ShareAccessLogEntryResolver implementation.
+func (r *Resolver) ShareAccessLogEntry() generated.ShareAccessLogEntryResolver {
+	return &shareAccessLogEntryResolver{r}
+}
+
+// ShareGrant returns generated.ShareGrantResolver implementation.
+func (r *Resolver) ShareGrant() generated.ShareGrantResolver { return &shareGrantResolver{r} }
+
 // SymptomEntry returns generated.SymptomEntryResolver implementation.
 func (r *Resolver) SymptomEntry() generated.SymptomEntryResolver { return &symptomEntryResolver{r} }
 
+// SymptomMedicationCorrelation returns generated.SymptomMedicationCorrelationResolver implementation.
+func (r *Resolver) SymptomMedicationCorrelation() generated.SymptomMedicationCorrelationResolver {
+	return &symptomMedicationCorrelationResolver{r}
+}
+
 // User returns generated.UserResolver implementation.
 func (r *Resolver) User() generated.UserResolver { return &userResolver{r} }
 
@@ -255,11 +1784,22 @@ func (r *Resolver) WellbeingTrend() generated.WellbeingTrendResolver {
 }
 
 type analysisResolver struct{ *Resolver }
+type auditLogEntryResolver struct{ *Resolver }
+type createReportLinkResultResolver struct{ *Resolver }
+type dataExportResolver struct{ *Resolver }
 type doctorVisitResolver struct{ *Resolver }
+type interactionWarningResolver struct{ *Resolver }
 type medicationResolver struct{ *Resolver }
+type medicationCourseResolver struct{ *Resolver }
 type medicationIntakeResolver struct{ *Resolver }
+type milestoneResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type reminderResolver struct{ *Resolver }
+type reportLinkResolver struct{ *Resolver }
+type shareAccessLogEntryResolver struct{ *Resolver }
+type shareGrantResolver struct{ *Resolver }
 type symptomEntryResolver struct{ *Resolver }
+type symptomMedicationCorrelationResolver struct{ *Resolver }
 type userResolver struct{ *Resolver }
 type wellbeingTrendResolver struct{ *Resolver }
@@ -0,0 +1,44 @@
+package graphql
+
+import (
+	"time"
+
+	gqlgen "github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// multipartOverhead — запас на остальные части multipart-запроса сверх самого файла
+const multipartOverhead = 1 << 20
+
+// NewServer создаёт GraphQL сервер с транспортами и расширениями handler.NewDefaultServer,
+// но с явным пределом загрузки файлов: по умолчанию gqlgen принимает не больше 32 МБ.
+// maxUploadSize — наибольший файл, который принимает API (архив импорта данных).
+func NewServer(schema gqlgen.ExecutableSchema, maxUploadSize int64) *handler.Server {
+	srv := handler.New(schema)
+
+	srv.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+	})
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{
+		MaxUploadSize: maxUploadSize + multipartOverhead,
+		// Файлы больше MaxMemory сохраняются во временные файлы, а не в память
+		MaxMemory: 32 << 20,
+	})
+
+	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
+
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.AutomaticPersistedQuery{
+		Cache: lru.New[string](100),
+	})
+	srv.AroundOperations(InteractionsCache())
+
+	return srv
+}
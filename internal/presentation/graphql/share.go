@@ -0,0 +1,168 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	gqlgen "github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+	sharingapp "github.com/health-hub-bot-api/internal/application/sharing"
+	"github.com/health-hub-bot-api/internal/domain/medication"
+	"github.com/health-hub-bot-api/internal/domain/sharing"
+)
+
+const (
+	// shareTokenHeader передаёт токен анонимной ссылки
+	shareTokenHeader = "X-Share-Token"
+	// shareGrantHeader выбирает доступ, выданный пользователю Telegram
+	shareGrantHeader = "X-Share-Grant-ID"
+)
+
+const (
+	grantContextKey   contextKey = "shareGrant"
+	granteeContextKey contextKey = "shareGrantee"
+)
+
+// sharedQueryScopes перечисляет поля Query, доступные по выдаче, и разделы,
+// которые для них нужны. Остальные поля и все мутации по выдаче недоступны.
+var sharedQueryScopes = map[string][]sharing.Scope{
+	"symptoms":           {sharing.ScopeSymptoms},
+	"symptom":            {sharing.ScopeSymptoms},
+	"symptomSuggestions": {sharing.ScopeSymptoms},
+
+	"analyses": {sharing.ScopeAnalyses},
+	"analysis": {sharing.ScopeAnalyses},
+
+	"medications":          {sharing.ScopeMedications},
+	"medication":           {sharing.ScopeMedications},
+	"medicationIntakes":    {sharing.ScopeMedications},
+	"medicationCompliance": {sharing.ScopeMedications},
+	"medicationHistory":    {sharing.ScopeMedications},
+
+	"doctorVisits": {sharing.ScopeDoctorVisits},
+	"doctorVisit":  {sharing.ScopeDoctorVisits},
+	// Отчёт сводит вместе все разделы
+	"doctorVisitReport": {sharing.ScopeDoctorVisits, sharing.ScopeSymptoms, sharing.ScopeAnalyses, sharing.ScopeMedications},
+
+	"symptomMedicationCorrelation": {sharing.ScopeSymptoms, sharing.ScopeMedications},
+}
+
+// currentGrant возвращает доступ, по которому выполняется запрос, или nil для владельца
+func currentGrant(ctx context.Context) *sharing.Grant {
+	grant, _ := ctx.Value(grantContextKey).(*sharing.Grant)
+	return grant
+}
+
+// grantPeriod ограничивает запрошенный период периодом данных доступа
+func grantPeriod(ctx context.Context, start, end *time.Time) (*time.Time, *time.Time) {
+	if grant := currentGrant(ctx); grant != nil {
+		return grant.ClampPeriod(start, end)
+	}
+	return start, end
+}
+
+// inGrantPeriod проверяет, что момент t попадает в период данных доступа;
+// для владельца всегда true
+func inGrantPeriod(ctx context.Context, t time.Time) bool {
+	grant := currentGrant(ctx)
+	return grant == nil || grant.OverlapsPeriod(t, &t)
+}
+
+// grantVisibleMedication возвращает проверку, что курс лекарства пересекается
+// с периодом данных доступа, или nil для владельца, которому видны все лекарства
+func grantVisibleMedication(ctx context.Context) func(*medication.Medication) bool {
+	grant := currentGrant(ctx)
+	if grant == nil {
+		return nil
+	}
+	return func(m *medication.Medication) bool {
+		return grant.OverlapsPeriod(m.StartDate, m.EndDate)
+	}
+}
+
+// ShareMiddleware переключает запрос на данные владельца доступа: по заголовку
+// X-Share-Token (анонимная ссылка) или X-Share-Grant-ID (доступ для пользователя
+// Telegram, требует аутентификации). Доступ проверяется на каждый запрос,
+// поэтому отозванный или истёкший доступ перестаёт работать сразу.
+func ShareMiddleware(access *sharingapp.AccessUseCase) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			ctx := req.Context()
+			token := strings.TrimSpace(req.Header.Get(shareTokenHeader))
+			grantIDValue := req.Header.Get(shareGrantHeader)
+			if token == "" && grantIDValue == "" {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			var grant *sharing.Grant
+			var granteeID *uuid.UUID
+			var err error
+			if token != "" {
+				grant, err = access.ByToken(ctx, token)
+			} else {
+				accountID, authErr := currentAccountID(ctx)
+				if authErr != nil {
+					http.Error(w, authErr.Error(), http.StatusUnauthorized)
+					return
+				}
+				grantID, parseErr := uuid.Parse(grantIDValue)
+				if parseErr != nil {
+					http.Error(w, sharing.ErrGrantNotFound.Error(), http.StatusForbidden)
+					return
+				}
+				granteeID = &accountID
+				grant, err = access.ByGrantee(ctx, accountID, grantID)
+			}
+			if errors.Is(err, sharing.ErrGrantNotFound) || errors.Is(err, sharing.ErrGrantInactive) {
+				http.Error(w, err.Error(), http.StatusForbidden)
+				return
+			}
+			if err != nil {
+				log.Printf("share: failed to load grant: %v", err)
+				http.Error(w, "internal error", http.StatusInternalServerError)
+				return
+			}
+
+			ctx = context.WithValue(ctx, grantContextKey, grant)
+			ctx = context.WithValue(ctx, granteeContextKey, granteeID)
+			next.ServeHTTP(w, req.WithContext(WithUserID(ctx, grant.OwnerID)))
+		})
+	}
+}
+
+// ShareGate ограничивает запросы по выдаче полями из sharedQueryScopes,
+// доступными по разделам выдачи, и записывает каждое обращение в журнал владельца
+func ShareGate(access *sharingapp.AccessUseCase) gqlgen.RootFieldMiddleware {
+	return func(ctx context.Context, next gqlgen.RootResolver) gqlgen.Marshaler {
+		grant := currentGrant(ctx)
+		if grant == nil {
+			return next(ctx)
+		}
+
+		field := gqlgen.GetRootFieldContext(ctx)
+		if strings.HasPrefix(field.Field.Name, "__") {
+			return next(ctx)
+		}
+
+		scopes, ok := sharedQueryScopes[field.Field.Name]
+		if field.Object != "Query" || !ok || !grant.Allows(scopes...) {
+			gqlgen.AddError(ctx, sharing.ErrAccessDenied)
+			return gqlgen.Null
+		}
+
+		granteeID, _ := ctx.Value(granteeContextKey).(*uuid.UUID)
+		if err := access.Record(ctx, grant, granteeID, field.Field.Name); err != nil {
+			// Обращение без записи в журнал не выполняется
+			log.Printf("share: failed to record access: %v", err)
+			gqlgen.AddError(ctx, errors.New("internal error"))
+			return gqlgen.Null
+		}
+
+		return next(ctx)
+	}
+}
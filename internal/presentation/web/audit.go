@@ -0,0 +1,42 @@
+package web
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"regexp"
+
+	"github.com/google/uuid"
+	auditapp "github.com/health-hub-bot-api/internal/application/audit"
+	"github.com/health-hub-bot-api/internal/domain/audit"
+)
+
+// requestIDHeader передаёт ID запроса от прокси и возвращает его клиенту
+const requestIDHeader = "X-Request-ID"
+
+// validRequestID ограничивает ID запроса, принятый от клиента или прокси
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestIDMiddleware присваивает каждому запросу ID: берёт его из заголовка
+// X-Request-ID или создаёт новый. ID возвращается в ответе и попадает в журнал аудита.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requestID := req.Header.Get(requestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = uuid.New().String()
+		}
+		w.Header().Set(requestIDHeader, requestID)
+		next.ServeHTTP(w, req.WithContext(auditapp.WithRequestID(req.Context(), requestID)))
+	})
+}
+
+// recordRead записывает обращение к данным владельца в журнал аудита.
+// Если запись не удалась, отвечает ошибкой: данные без записи в журнал не отдаются.
+func recordRead(ctx context.Context, w http.ResponseWriter, record *auditapp.RecordUseCase, entry *audit.Entry) bool {
+	if err := record.Execute(ctx, entry); err != nil {
+		log.Printf("audit: failed to record %s: %v", entry.Operation, err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,143 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	auditapp "github.com/health-hub-bot-api/internal/application/audit"
+	"github.com/health-hub-bot-api/internal/domain/analysis"
+	"github.com/health-hub-bot-api/internal/domain/audit"
+	"github.com/health-hub-bot-api/internal/domain/export"
+	"github.com/health-hub-bot-api/internal/domain/filestorage"
+	"github.com/health-hub-bot-api/internal/infrastructure/storage"
+)
+
+// AnalysisFileHandler отдаёт файл анализа по подписанной ссылке /files/analyses/{id}.
+// Файлы во внешнем хранилище (http/https URL) отдаются перенаправлением,
+// локальные — из хранилища (с расшифровкой). Скачивание записывается в журнал аудита.
+func AnalysisFileHandler(analysisRepo analysis.Repository, record *auditapp.RecordUseCase, signer *storage.URLSigner, store filestorage.Storage) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		setPrivatePageHeaders(w)
+
+		analysisID, ok := verifySignedRequest(w, req, signer, storage.AnalysisFilePath)
+		if !ok {
+			return
+		}
+
+		a, err := analysisRepo.GetByID(req.Context(), analysisID)
+		if err != nil {
+			log.Printf("files: failed to load analysis: %v", err)
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		if a == nil {
+			http.Error(w, analysis.ErrAnalysisNotFound.Error(), http.StatusNotFound)
+			return
+		}
+
+		entry := audit.NewEntry(a.UserID, audit.Actor{Kind: audit.ActorSignedLink}, audit.ActionRead, "analysisFile", audit.EntityAnalysis, &a.ID, nil)
+		if !recordRead(req.Context(), w, record, entry) {
+			return
+		}
+
+		if remote, err := url.Parse(a.FileURL); err == nil && (remote.Scheme == "http" || remote.Scheme == "https") {
+			http.Redirect(w, req, a.FileURL, http.StatusFound)
+			return
+		}
+
+		serveStoredFile(w, req, store, a.FileURL, "inline")
+	})
+}
+
+// ExportFileHandler отдаёт архив экспорта по подписанной ссылке /files/exports/{id}.
+// Скачивание записывается в журнал аудита.
+func ExportFileHandler(jobRepo export.JobRepository, record *auditapp.RecordUseCase, signer *storage.URLSigner, store filestorage.Storage) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		setPrivatePageHeaders(w)
+
+		jobID, ok := verifySignedRequest(w, req, signer, storage.ExportFilePath)
+		if !ok {
+			return
+		}
+
+		job, err := jobRepo.GetByID(req.Context(), jobID)
+		if errors.Is(err, export.ErrJobNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			log.Printf("files: failed to load export job: %v", err)
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		if !job.IsDownloadable(time.Now()) {
+			http.Error(w, export.ErrArchiveNotReady.Error(), http.StatusGone)
+			return
+		}
+
+		entry := audit.NewEntry(job.UserID, audit.Actor{Kind: audit.ActorSignedLink}, audit.ActionRead, "exportFile", audit.EntityDataExport, &job.ID, nil)
+		if !recordRead(req.Context(), w, record, entry) {
+			return
+		}
+
+		disposition := fmt.Sprintf(`attachment; filename="healthhub-export-%s.zip"`, job.CreatedAt.Format("20060102"))
+		serveStoredFile(w, req, store, *job.ArchiveURL, disposition)
+	})
+}
+
+// verifySignedRequest проверяет подпись ссылки и возвращает ID файла из пути
+func verifySignedRequest(w http.ResponseWriter, req *http.Request, signer *storage.URLSigner, path string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(req.PathValue("id"))
+	if err != nil {
+		http.Error(w, storage.ErrInvalidSignature.Error(), http.StatusForbidden)
+		return uuid.Nil, false
+	}
+	query := req.URL.Query()
+	if err := signer.Verify(path, id, query.Get("expires"), query.Get("signature"), time.Now()); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// sniffSize — сколько байт файла используется для определения Content-Type
+const sniffSize = 512
+
+// serveStoredFile отдаёт файл из хранилища с заголовком Content-Disposition.
+// Файлы хранятся зашифрованными, поэтому отдаются потоком без поддержки Range.
+func serveStoredFile(w http.ResponseWriter, req *http.Request, store filestorage.Storage, fileURL, disposition string) {
+	file, err := store.Open(req.Context(), fileURL)
+	if errors.Is(err, filestorage.ErrFileNotFound) {
+		http.Error(w, "file not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("files: failed to open file: %v", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	head := make([]byte, sniffSize)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		log.Printf("files: failed to read file: %v", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", http.DetectContentType(head[:n]))
+	w.Header().Set("Content-Disposition", disposition)
+	if _, err := w.Write(head[:n]); err != nil {
+		return
+	}
+	if _, err := io.Copy(w, file); err != nil {
+		log.Printf("files: failed to stream file: %v", err)
+	}
+}
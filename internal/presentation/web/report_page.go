@@ -0,0 +1,115 @@
+package web
+
+import (
+	"embed"
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	auditapp "github.com/health-hub-bot-api/internal/application/audit"
+	doctorvisitapp "github.com/health-hub-bot-api/internal/application/doctorvisit"
+	sharingapp "github.com/health-hub-bot-api/internal/application/sharing"
+	"github.com/health-hub-bot-api/internal/domain/audit"
+	"github.com/health-hub-bot-api/internal/domain/sharing"
+	"github.com/health-hub-bot-api/internal/infrastructure/storage"
+)
+
+// ReportPagePath — путь веб-страницы отчёта; за ним следует токен ссылки
+const ReportPagePath = "/report/"
+
+// maxPINFormSize ограничивает размер формы с PIN
+const maxPINFormSize = 1 << 10
+
+//go:embed templates/page.html
+var pageTemplates embed.FS
+
+var pageTemplate = template.Must(template.ParseFS(pageTemplates, "templates/page.html"))
+
+// pageData представляет данные служебной страницы: форма PIN или «ссылка недоступна»
+type pageData struct {
+	PINRequired bool
+	Error       string
+}
+
+// ReportPageHandler отдаёт отчёт к визиту по одноразовой ссылке /report/{token}.
+// GET открывает отчёт или показывает форму PIN, POST принимает PIN из формы.
+// Файлы анализов встраиваются по подписанным ссылкам со сроком fileURLTTL.
+// Каждое открытие отчёта записывается в журнал аудита владельца.
+func ReportPageHandler(open *sharingapp.OpenReportLinkUseCase, record *auditapp.RecordUseCase, signer *storage.URLSigner, fileURLTTL time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		setPrivatePageHeaders(w)
+
+		var pin *string
+		if req.Method == http.MethodPost {
+			req.Body = http.MaxBytesReader(w, req.Body, maxPINFormSize)
+			if err := req.ParseForm(); err != nil {
+				http.Error(w, "bad request", http.StatusBadRequest)
+				return
+			}
+			value := req.PostForm.Get("pin")
+			pin = &value
+		}
+
+		link, report, err := open.Execute(req.Context(), req.PathValue("token"), pin)
+		switch {
+		case errors.Is(err, sharing.ErrPINRequired):
+			renderPage(w, http.StatusUnauthorized, pageData{PINRequired: true})
+			return
+		case errors.Is(err, sharing.ErrInvalidPIN):
+			if !link.IsActive(time.Now()) {
+				renderPage(w, http.StatusGone, pageData{})
+				return
+			}
+			renderPage(w, http.StatusUnauthorized, pageData{PINRequired: true, Error: "Неверный PIN"})
+			return
+		case errors.Is(err, sharing.ErrReportLinkNotFound), errors.Is(err, sharing.ErrReportLinkInactive):
+			renderPage(w, http.StatusGone, pageData{})
+			return
+		case err != nil:
+			log.Printf("report page: failed to open report: %v", err)
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		actor := audit.Actor{Kind: audit.ActorReportLink, CredentialID: &link.ID}
+		entry := audit.NewEntry(link.OwnerID, actor, audit.ActionRead, "reportPage", audit.EntityDoctorVisitReport, &link.VisitID, nil)
+		if !recordRead(req.Context(), w, record, entry) {
+			return
+		}
+
+		fileExpiresAt := time.Now().Add(fileURLTTL)
+		fileURL := func(analysisID uuid.UUID) string {
+			return signer.AnalysisFileURL(analysisID, fileExpiresAt)
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := doctorvisitapp.RenderReportHTML(w, report, fileURL, link.ExpiresAt); err != nil {
+			log.Printf("report page: failed to render report: %v", err)
+		}
+	})
+}
+
+// renderPage отдаёт служебную страницу с кодом status
+func renderPage(w http.ResponseWriter, status int, data pageData) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := pageTemplate.Execute(w, data); err != nil {
+		log.Printf("report page: failed to render page: %v", err)
+	}
+}
+
+// setPrivatePageHeaders запрещает кэширование и индексацию страницы с медицинскими данными,
+// а также передачу токена из адреса в Referer
+func setPrivatePageHeaders(w http.ResponseWriter) {
+	h := w.Header()
+	h.Set("Cache-Control", "no-store")
+	h.Set("Referrer-Policy", "no-referrer")
+	h.Set("X-Robots-Tag", "noindex, nofollow")
+	h.Set("X-Content-Type-Options", "nosniff")
+	h.Set("X-Frame-Options", "DENY")
+	h.Set("Content-Security-Policy",
+		"default-src 'none'; style-src 'unsafe-inline'; img-src 'self' https:; form-action 'self'; frame-ancestors 'none'")
+}
@@ -0,0 +1,7 @@
+-- Миграция: Автозаполнение описаний симптомов
+-- Версия: 002
+
+-- Триграммный поиск похожих описаний
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_symptom_entries_description_trgm ON symptom_entries USING GIN (description gin_trgm_ops);
@@ -0,0 +1,21 @@
+-- Миграция: Серии дней и вехи вовлечённости
+-- Версия: 003
+
+-- Часовой пояс пользователя для подсчёта календарных дней
+ALTER TABLE users ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+-- Таблица достигнутых вех (каждая отмечается один раз)
+CREATE TABLE milestones (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(30) NOT NULL CHECK (kind IN ('symptom_logging', 'medication_adherence')),
+    days INTEGER NOT NULL,
+    achieved_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_milestones_unique ON milestones(user_id, kind, days);
+
+-- Поздравления с вехами отправляются через напоминания
+ALTER TABLE reminders DROP CONSTRAINT reminders_type_check;
+ALTER TABLE reminders ADD CONSTRAINT reminders_type_check
+    CHECK (type IN ('medication', 'analysis', 'symptom_check', 'milestone'));
@@ -0,0 +1,7 @@
+-- Миграция: Настройки уведомлений и ежедневный check-in
+-- Версия: 004
+
+-- Настройки напоминаний хранятся вместе с пользователем:
+-- {"check_in_times": ["09:00"], "channels": ["telegram"], "quiet_hours_start": "22:00", "quiet_hours_end": "08:00", "days": [1,2,3,4,5,6,7]}
+ALTER TABLE users ADD COLUMN notification_preferences JSONB NOT NULL DEFAULT '{}';
+
@@ -0,0 +1,14 @@
+-- Миграция: Напоминания о повторных анализах
+-- Версия: 005
+
+-- Отложенная доставка (snooze, тихие часы); scheduled_time сохраняет исходный план
+ALTER TABLE reminders ADD COLUMN postponed_until TIMESTAMP;
+
+DROP INDEX IF EXISTS idx_reminders_upcoming;
+CREATE INDEX idx_reminders_upcoming ON reminders((COALESCE(postponed_until, scheduled_time))) WHERE is_sent = FALSE;
+
+-- Поиск напоминаний по связанной сущности при очистке
+CREATE INDEX idx_reminders_related_id ON reminders(related_id) WHERE related_id IS NOT NULL;
+
+-- Повторный анализ, сданный по напоминанию
+ALTER TABLE analyses ADD COLUMN follow_up_analysis_id UUID REFERENCES analyses(id) ON DELETE SET NULL;
@@ -0,0 +1,6 @@
+-- Миграция: Напоминания о визитах к врачу
+-- Версия: 006
+
+ALTER TABLE reminders DROP CONSTRAINT reminders_type_check;
+ALTER TABLE reminders ADD CONSTRAINT reminders_type_check
+    CHECK (type IN ('medication', 'analysis', 'symptom_check', 'milestone', 'doctor_visit'));
@@ -0,0 +1,24 @@
+-- Миграция: Напоминания о приёме лекарств и пропущенные приёмы
+-- Версия: 007
+
+-- Момент, когда неотмеченный приём автоматически признан пропущенным
+ALTER TABLE medication_intakes ADD COLUMN missed_at TIMESTAMP;
+
+-- Прошедшие неотмеченные приёмы считаются пропущенными
+UPDATE medication_intakes SET missed_at = scheduled_time
+WHERE is_taken = FALSE AND scheduled_time < NOW();
+
+-- Удаляем дубли приёмов на одно время, оставляя отмеченный, а среди равных — самый ранний
+DELETE FROM medication_intakes d
+USING medication_intakes k
+WHERE d.medication_id = k.medication_id
+  AND d.scheduled_time = k.scheduled_time
+  AND d.id <> k.id
+  AND (k.is_taken, d.created_at, d.id) > (d.is_taken, k.created_at, k.id);
+
+-- Один приём лекарства на одно запланированное время
+CREATE UNIQUE INDEX idx_medication_intakes_unique ON medication_intakes(medication_id, scheduled_time);
+
+-- Выборка приёмов, ожидающих отметки
+CREATE INDEX idx_medication_intakes_unmarked ON medication_intakes(scheduled_time)
+    WHERE is_taken = FALSE AND missed_at IS NULL;
@@ -0,0 +1,19 @@
+-- Миграция: Статусы приёма лекарств
+-- Версия: 008
+
+ALTER TABLE medication_intakes ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'planned'
+    CHECK (status IN ('planned', 'taken', 'skipped', 'missed', 'late'));
+ALTER TABLE medication_intakes ADD COLUMN skip_reason TEXT;
+ALTER TABLE medication_intakes ADD COLUMN actual_dose VARCHAR(100);
+
+-- Перенос прежних отметок: принятые, автоматически пропущенные и ожидающие отметки
+UPDATE medication_intakes SET status = 'taken' WHERE is_taken = TRUE;
+UPDATE medication_intakes SET status = 'missed' WHERE is_taken = FALSE AND missed_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_medication_intakes_unmarked;
+ALTER TABLE medication_intakes DROP COLUMN is_taken;
+
+-- Выборка приёмов, ожидающих отметки
+CREATE INDEX idx_medication_intakes_unmarked ON medication_intakes(scheduled_time)
+    WHERE status = 'planned';
+CREATE INDEX idx_medication_intakes_status ON medication_intakes(medication_id, status);
@@ -0,0 +1,10 @@
+-- Миграция: Приём лекарств по необходимости и ограничения
+-- Версия: 009
+
+-- Ограничения задаёт сам пользователь; NULL — ограничения нет
+ALTER TABLE medications ADD COLUMN max_doses_per_24h INT CHECK (max_doses_per_24h > 0);
+ALTER TABLE medications ADD COLUMN min_dose_interval_minutes INT CHECK (min_dose_interval_minutes > 0);
+
+-- Приёмы по необходимости не планируются заранее и хранят причину
+ALTER TABLE medication_intakes ADD COLUMN is_as_needed BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE medication_intakes ADD COLUMN reason TEXT;
@@ -0,0 +1,8 @@
+-- Миграция: Интервальные, циклические расписания и этапы дозировки
+-- Версия: 010
+
+-- Новые параметры расписания хранятся в schedule_details как необязательные ключи
+-- (interval_hours, every_n_days, cycle, dose_steps), поэтому прежние записи не меняются.
+
+-- Доза по этапу курса для конкретного приёма
+ALTER TABLE medication_intakes ADD COLUMN planned_dose VARCHAR(100);
@@ -0,0 +1,17 @@
+-- Миграция: Структурированная доза и учёт запаса лекарств
+-- Версия: 011
+
+-- Структурированная доза одного приёма; строка dosage остаётся для отображения
+ALTER TABLE medications ADD COLUMN dosage_amount NUMERIC(10, 3) CHECK (dosage_amount > 0);
+ALTER TABLE medications ADD COLUMN dosage_unit VARCHAR(20)
+    CHECK (dosage_unit IN ('mg', 'mcg', 'g', 'ml', 'iu', 'tablet', 'capsule', 'drop', 'puff', 'sachet'));
+ALTER TABLE medications ADD COLUMN dosage_form VARCHAR(20)
+    CHECK (dosage_form IN ('tablet', 'capsule', 'liquid', 'injection', 'drops', 'spray', 'inhaler', 'topical', 'powder', 'other'));
+
+-- Остаток в единицах дозы и отметка о напоминании о пополнении текущего запаса
+ALTER TABLE medications ADD COLUMN stock_quantity NUMERIC(10, 3) CHECK (stock_quantity >= 0);
+ALTER TABLE medications ADD COLUMN refill_reminded_at TIMESTAMP;
+
+ALTER TABLE reminders DROP CONSTRAINT reminders_type_check;
+ALTER TABLE reminders ADD CONSTRAINT reminders_type_check
+    CHECK (type IN ('medication', 'analysis', 'symptom_check', 'milestone', 'doctor_visit', 'refill'));
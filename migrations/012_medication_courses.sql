@@ -0,0 +1,21 @@
+-- Миграция: История курсов лекарств
+-- Версия: 012
+
+-- Завершённые курсы хранятся отдельно и переживают удаление лекарства,
+-- поэтому medication_id не ссылается на medications
+CREATE TABLE medication_courses (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    medication_id UUID NOT NULL,
+    medication_name VARCHAR(255) NOT NULL,
+    dosage VARCHAR(100) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('completed', 'stopped', 'replaced')),
+    replaced_by_id UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_medication_courses_user_id ON medication_courses(user_id, start_date);
+CREATE INDEX idx_medication_courses_user_end ON medication_courses(user_id, end_date);
+CREATE INDEX idx_medication_courses_medication_id ON medication_courses(medication_id);
@@ -0,0 +1,11 @@
+-- Миграция: Подопечные профили
+-- Версия: 013
+
+-- Подопечный профиль (ребёнок, пожилой родственник) ведёт владелец аккаунта Telegram;
+-- своего telegram_user_id у такого профиля нет
+ALTER TABLE users ALTER COLUMN telegram_user_id DROP NOT NULL;
+ALTER TABLE users ADD COLUMN owner_id UUID REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE users ADD CONSTRAINT users_account_or_dependent
+    CHECK ((owner_id IS NULL) = (telegram_user_id IS NOT NULL));
+
+CREATE INDEX idx_users_owner_id ON users(owner_id) WHERE owner_id IS NOT NULL;
@@ -0,0 +1,34 @@
+-- Миграция: Доступ к данным для врачей и близких
+-- Версия: 014
+
+-- Доступ только для чтения выдаётся либо пользователю Telegram (grantee_telegram_user_id),
+-- либо по анонимной ссылке (token_hash — SHA-256 токена, сам токен не хранится)
+CREATE TABLE share_grants (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    grantee_telegram_user_id BIGINT,
+    token_hash VARCHAR(64) UNIQUE,
+    scopes JSONB NOT NULL,
+    data_start_date DATE,
+    data_end_date DATE,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK ((grantee_telegram_user_id IS NULL) <> (token_hash IS NULL))
+);
+
+CREATE INDEX idx_share_grants_owner_id ON share_grants(owner_id, created_at);
+CREATE INDEX idx_share_grants_grantee ON share_grants(grantee_telegram_user_id) WHERE grantee_telegram_user_id IS NOT NULL;
+
+-- Журнал обращений получателей к данным владельца
+CREATE TABLE share_access_log (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    grant_id UUID NOT NULL REFERENCES share_grants(id) ON DELETE CASCADE,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    grantee_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    field VARCHAR(100) NOT NULL,
+    accessed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_share_access_log_owner ON share_access_log(owner_id, accessed_at);
+CREATE INDEX idx_share_access_log_grant ON share_access_log(grant_id, accessed_at);
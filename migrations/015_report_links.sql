@@ -0,0 +1,23 @@
+-- Миграция: Ссылки на веб-страницу отчёта для врача
+-- Версия: 015
+
+-- token_hash — SHA-256 токена из ссылки, pin_hash — SHA-256 PIN с ID ссылки;
+-- сами токен и PIN не хранятся
+CREATE TABLE report_links (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    visit_id UUID NOT NULL REFERENCES doctor_visits(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    pin_hash VARCHAR(64),
+    period_start TIMESTAMP NOT NULL,
+    period_end TIMESTAMP NOT NULL,
+    max_views INTEGER NOT NULL CHECK (max_views > 0),
+    views INTEGER NOT NULL DEFAULT 0,
+    failed_pin_attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (period_end >= period_start)
+);
+
+CREATE INDEX idx_report_links_visit_id ON report_links(visit_id, created_at);
@@ -0,0 +1,20 @@
+-- Миграция: Экспорт персональных данных в архив
+-- Версия: 016
+
+-- Задача собирает все данные профиля в ZIP (JSON и CSV по сущностям, файлы анализов,
+-- манифест); archive_url — расположение архива в хранилище файлов
+CREATE TABLE export_jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'processing', 'completed', 'failed', 'expired')),
+    archive_url VARCHAR(500),
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    error TEXT,
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX idx_export_jobs_user_id ON export_jobs(user_id, created_at);
+CREATE INDEX idx_export_jobs_status ON export_jobs(status, created_at);
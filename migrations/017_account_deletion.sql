@@ -0,0 +1,6 @@
+-- Миграция: Удаление аккаунта со сроком восстановления
+-- Версия: 017
+
+-- Удалённые пользователи хранятся до истечения срока восстановления,
+-- после чего задача стирает их вместе со связанными данными (ON DELETE CASCADE)
+CREATE INDEX idx_users_pending_deletion ON users(deleted_at) WHERE deleted_at IS NOT NULL;
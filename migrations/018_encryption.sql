@@ -0,0 +1,19 @@
+-- Миграция: Шифрование чувствительных полей на уровне приложения
+-- Версия: 018
+
+-- Ключи данных профилей (конвертное шифрование): ключ шифрует описания симптомов,
+-- вопросы к врачу и заметки о приёмах и хранится зашифрованным мастер-ключом
+-- из конфигурации. Выведенные при ротации ключи остаются, пока ими
+-- зашифровано хоть одно значение. Ключи удаляются вместе с профилем.
+CREATE TABLE user_data_keys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    master_key_id VARCHAR(64) NOT NULL,
+    wrapped_key BYTEA NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    retired_at TIMESTAMP
+);
+
+-- Один действующий ключ на профиль
+CREATE UNIQUE INDEX idx_user_data_keys_active ON user_data_keys(user_id) WHERE retired_at IS NULL;
+CREATE INDEX idx_user_data_keys_master_key ON user_data_keys(master_key_id);
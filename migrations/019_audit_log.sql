@@ -0,0 +1,35 @@
+-- Миграция: Журнал аудита обращений к медицинским данным
+-- Версия: 019
+
+-- Каждое изменение и чтение чувствительных данных: кто (actor_kind, actor_user_id,
+-- доступ или ссылка в actor_credential_id), что сделал, с какой сущностью и
+-- какими полями. Значения полей не хранятся. Журнал только пополняется: записи
+-- удаляются по сроку хранения или вместе с профилем.
+CREATE TABLE audit_log (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    actor_kind VARCHAR(20) NOT NULL CHECK (actor_kind IN ('owner', 'account_holder', 'grantee', 'share_link', 'report_link', 'signed_link')),
+    actor_user_id UUID,
+    actor_credential_id UUID,
+    action VARCHAR(10) NOT NULL CHECK (action IN ('read', 'create', 'update', 'delete')),
+    operation VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id UUID,
+    fields JSONB NOT NULL DEFAULT '[]',
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_owner ON audit_log(owner_id, created_at);
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at);
+
+-- Записи журнала не изменяются
+CREATE OR REPLACE FUNCTION audit_log_forbid_update() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_forbid_update();
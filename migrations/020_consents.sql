@@ -0,0 +1,17 @@
+-- Миграция: Согласия на обработку персональных данных
+-- Версия: 020
+
+-- История согласий владельцев аккаунтов: каждое принятие версии политики —
+-- новая запись, отзыв заполняет withdrawn_at. evidence_hash — SHA-256 от
+-- IP-адреса и initData запроса, в котором дано согласие.
+CREATE TABLE consents (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    policy_version VARCHAR(50) NOT NULL,
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('webapp', 'bot')),
+    evidence_hash VARCHAR(64) NOT NULL DEFAULT '',
+    accepted_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    withdrawn_at TIMESTAMP
+);
+
+CREATE INDEX idx_consents_user ON consents(user_id, accepted_at DESC);
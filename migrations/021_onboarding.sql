@@ -0,0 +1,14 @@
+-- Миграция: Онбординг владельца аккаунта
+-- Версия: 021
+
+-- Прохождение онбординга хранится вместе с пользователем:
+-- {"completed_steps": ["consent", "profile"], "skipped_steps": ["first_action"], "completed_at": "2026-01-01T10:00:00Z"}
+ALTER TABLE users ADD COLUMN onboarding JSONB NOT NULL DEFAULT '{}';
+
+-- Аккаунты, созданные до появления онбординга, считаются прошедшими его
+UPDATE users
+SET onboarding = jsonb_build_object(
+    'completed_steps', '["consent", "profile", "first_action"]'::jsonb,
+    'completed_at', to_char(NOW() AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"')
+)
+WHERE owner_id IS NULL;
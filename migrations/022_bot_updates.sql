@@ -0,0 +1,12 @@
+-- Миграция: Обработанные обновления Telegram-бота
+-- Версия: 022
+
+-- Telegram повторяет доставку обновления, если не дождался ответа вебхука.
+-- update_id принятых обновлений хранится, пока возможна повторная доставка,
+-- чтобы повтор не создал запись самочувствия или анализ ещё раз.
+CREATE TABLE bot_updates (
+    update_id BIGINT PRIMARY KEY,
+    received_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_bot_updates_received ON bot_updates(received_at);
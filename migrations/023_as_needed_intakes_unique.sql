@@ -0,0 +1,9 @@
+-- Миграция: Приёмы по необходимости вне уникального индекса расписания
+-- Версия: 023
+
+-- Приём по необходимости хранит фактическое время в scheduled_time и не занимает
+-- слот расписания: две дозы, записанные на одну минуту, не должны конфликтовать.
+-- Уникальность времени остаётся только для запланированных приёмов.
+DROP INDEX idx_medication_intakes_unique;
+CREATE UNIQUE INDEX idx_medication_intakes_unique ON medication_intakes(medication_id, scheduled_time)
+    WHERE is_as_needed = FALSE;